@@ -0,0 +1,149 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/node"
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+const attachUsage = `Interactive JSON-RPC shell, enter one request per line:
+  module_method [param ...]   e.g. node_peers or admin_setLogLevel "" debug
+  {"jsonrpc":"2.0",...}       a raw JSON-RPC request or batch
+  exit                        leave the shell
+Params are parsed as JSON values, anything else is sent as a string.`
+
+// attach opens an interactive JSON-RPC shell over the IPC endpoint of a running
+// node. If no endpoint is given, it is resolved from the config file if any, or
+// the default node config otherwise.
+func attach(endpoint string) error {
+	if endpoint == "" {
+		var err error
+		if endpoint, err = defaultIPCEndpoint(); err != nil {
+			return err
+		}
+	}
+	conn, err := rpc.DialIPC(endpoint)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fmt.Printf("Attached to %s\n%s\n", endpoint, attachUsage)
+	go printResponses(conn)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for id := 1; ; id++ {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case line == "exit" || line == "quit":
+			return nil
+		}
+		request, err := makeRequest(id, line)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		if _, err := conn.Write(append(request, '\n')); err != nil {
+			return err
+		}
+	}
+}
+
+// defaultIPCEndpoint resolves the IPC endpoint of the node configured by the
+// config flag, falling back to the default node config.
+func defaultIPCEndpoint() (string, error) {
+	config := node.DefaultConfig
+	if args.config != "" {
+		c, err := LoadConfig(args.config)
+		if err != nil {
+			return "", err
+		}
+		config.Name, config.DataDir = c.Node.Name, c.Node.DataDir
+		if c.Node.IPCPath != nil {
+			config.IPCPath = *c.Node.IPCPath
+		}
+	}
+	endpoint := config.IPCEndpoint()
+	if endpoint == "" {
+		return "", fmt.Errorf("no IPC endpoint configured")
+	}
+	return endpoint, nil
+}
+
+// makeRequest turns an input line into a JSON-RPC request. Lines starting with
+// a brace or a bracket are taken as raw requests.
+func makeRequest(id int, line string) ([]byte, error) {
+	if strings.HasPrefix(line, "{") || strings.HasPrefix(line, "[") {
+		if !json.Valid([]byte(line)) {
+			return nil, fmt.Errorf("invalid JSON request")
+		}
+		return []byte(line), nil
+	}
+	fields := strings.Fields(line)
+	params := make([]json.RawMessage, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		if !json.Valid([]byte(field)) {
+			field = fmt.Sprintf("%q", field)
+		}
+		params = append(params, json.RawMessage(field))
+	}
+	return json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  fields[0],
+		"params":  params,
+	})
+}
+
+// printResponses prints every response and subscription notification received
+// from the node until the connection is closed.
+func printResponses(conn net.Conn) {
+	dec := json.NewDecoder(conn)
+	for {
+		var msg json.RawMessage
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				fmt.Println("\nconnection closed by the node")
+				os.Exit(0)
+			}
+			fmt.Fprintf(os.Stderr, "\nconnection error: %v\n", err)
+			os.Exit(1)
+		}
+		out, err := json.MarshalIndent(msg, "", "  ")
+		if err != nil {
+			out = msg
+		}
+		fmt.Printf("\n%s\n> ", out)
+	}
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
 	"github.com/kardiachain/go-kardia/node"
+	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -56,6 +57,7 @@ import (
 const (
 	LevelDb = iota
 	MongoDb
+	BoltDb
 )
 
 type flags struct {
@@ -90,6 +92,65 @@ func LoadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
+// getRPCLimits gets the limits of the HTTP endpoint, overriding the defaults with the configured ones
+func (c *Config) getRPCLimits() rpc.Limits {
+	limits := node.DefaultConfig.HTTPLimits
+	l := c.Node.RPCLimits
+	if l == nil {
+		return limits
+	}
+	if l.BatchItemLimit != nil {
+		limits.BatchItemLimit = *l.BatchItemLimit
+	}
+	if l.BatchResponseMaxSize != nil {
+		limits.BatchResponseMaxSize = *l.BatchResponseMaxSize
+	}
+	if l.Timeout != nil {
+		limits.Timeout = time.Duration(*l.Timeout) * time.Millisecond
+	}
+	if len(l.MethodTimeouts) > 0 {
+		methodTimeouts := make(map[string]time.Duration)
+		for method, timeout := range limits.MethodTimeouts {
+			methodTimeouts[method] = timeout
+		}
+		for method, ms := range l.MethodTimeouts {
+			methodTimeouts[method] = time.Duration(ms) * time.Millisecond
+		}
+		limits.MethodTimeouts = methodTimeouts
+	}
+	if l.BatchTimeout != nil {
+		limits.BatchTimeout = time.Duration(*l.BatchTimeout) * time.Millisecond
+	}
+	limits.RateLimit, limits.RateBurst = l.RateLimit, l.RateBurst
+	return limits
+}
+
+// getRPCAuth gets the authentication of an HTTP endpoint from config, nil if it's disabled
+func getRPCAuth(a *RPCAuth) (*rpc.AuthConfig, error) {
+	if a == nil {
+		return nil, nil
+	}
+	auth := &rpc.AuthConfig{
+		PublicModules: a.PublicModules,
+		JWTModules:    a.JWTModules,
+		APIKeys:       make(map[string][]string),
+	}
+	if a.JWTSecretFile != "" {
+		secret, err := rpc.ReadJWTSecret(a.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		auth.JWTSecret = secret
+	}
+	for _, key := range a.APIKeys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("empty API key")
+		}
+		auth.APIKeys[key.Key] = key.Modules
+	}
+	return auth, nil
+}
+
 // getP2P gets p2p's config from config
 func (c *Config) getP2PConfig() (*p2p.Config, error) {
 	peer := c.P2P
@@ -104,15 +165,21 @@ func (c *Config) getP2PConfig() (*p2p.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	nodesFile := peer.NodesFile
+	if nodesFile != "" && !filepath.IsAbs(nodesFile) {
+		nodesFile = filepath.Join(c.DataDir, c.Name, nodesFile)
+	}
 	return &p2p.Config{
-		PrivateKey: privKey,
-		MaxPeers:   peer.MaxPeers,
-		ListenAddr: peer.ListenAddress,
-		NAT:        nat.Any(),
+		PrivateKey:       privKey,
+		MaxPeers:         peer.MaxPeers,
+		ListenAddr:       peer.ListenAddress,
+		NAT:              nat.Any(),
+		DNSDiscoveryURLs: peer.DNSDiscovery,
+		NodesFile:        nodesFile,
 	}, nil
 }
 
-// getDbInfo gets database information from config. Currently, it only supports levelDb and Mondodb
+// getDbInfo gets database information from config. Currently, it supports levelDb, Mondodb and bolt
 func (c *Config) getDbInfo(isDual bool) storage.DbInfo {
 	database := c.MainChain.Database
 	if isDual {
@@ -127,9 +194,28 @@ func (c *Config) getDbInfo(isDual bool) storage.DbInfo {
 				panic(err)
 			}
 		}
-		return storage.NewLevelDbInfo(nodeDir, database.Caches, database.Handles)
+		info := storage.NewLevelDbInfo(nodeDir, database.Caches, database.Handles)
+		if database.Ancient != "" {
+			info.Ancient = filepath.Join(nodeDir, database.Ancient)
+			info.FreezeThreshold = database.FreezeThreshold
+		}
+		return info
 	case MongoDb:
 		return storage.NewMongoDbInfo(database.URI, database.Name, database.Drop == 1)
+	case BoltDb:
+		nodeDir := filepath.Join(c.DataDir, c.Name, database.Dir)
+		if database.Drop == 1 {
+			// Clear all contents within data dir
+			if err := removeDirContents(nodeDir); err != nil {
+				panic(err)
+			}
+		}
+		info := storage.NewBoltDbInfo(nodeDir)
+		if database.Ancient != "" {
+			info.Ancient = filepath.Join(nodeDir, database.Ancient)
+			info.FreezeThreshold = database.FreezeThreshold
+		}
+		return info
 	default:
 		return nil
 	}
@@ -171,13 +257,53 @@ func (c *Config) getGenesis(isDual bool) (*genesis.Genesis, error) {
 			return nil, err
 		}
 	}
+	// Persist the consensus timing in the config of a new chain, so that nodes
+	// and peers running with a different timing are noticed.
+	chainConfig := *configs.TestnetChainConfig
+	chainConfig.ConsensusTiming = c.getConsensusConfig(isDual).Timing()
+	if g != nil {
+		chainConfig.HeaderCommitmentsHeight = g.HeaderCommitmentsHeight
+		chainConfig.SystemPhasesHeight = g.SystemPhasesHeight
+		chainConfig.PrecompilesV1Height = g.PrecompilesV1Height
+	}
 	return &genesis.Genesis{
-		Config:   configs.TestnetChainConfig,
+		Config:   &chainConfig,
 		GasLimit: 16777216, // maximum number of uint24
 		Alloc:    ga,
 	}, nil
 }
 
+// getConsensusConfig gets the consensus timing and empty-block policy of the
+// main or dual chain, using the defaults for values not set in config.
+func (c *Config) getConsensusConfig(isDual bool) *configs.ConsensusConfig {
+	cfg := configs.DefaultConsensusConfig()
+	cs := c.MainChain.Consensus
+	if isDual {
+		cs = c.DualChain.Consensus
+	}
+	if cs == nil {
+		return cfg
+	}
+	setTimeout := func(timeout *time.Duration, ms *uint64) {
+		if ms != nil {
+			*timeout = time.Duration(*ms) * time.Millisecond
+		}
+	}
+	setTimeout(&cfg.TimeoutPropose, cs.TimeoutPropose)
+	setTimeout(&cfg.TimeoutProposeDelta, cs.TimeoutProposeDelta)
+	setTimeout(&cfg.TimeoutPrevote, cs.TimeoutPrevote)
+	setTimeout(&cfg.TimeoutPrevoteDelta, cs.TimeoutPrevoteDelta)
+	setTimeout(&cfg.TimeoutPrecommit, cs.TimeoutPrecommit)
+	setTimeout(&cfg.TimeoutPrecommitDelta, cs.TimeoutPrecommitDelta)
+	setTimeout(&cfg.TimeoutCommit, cs.TimeoutCommit)
+	setTimeout(&cfg.CreateEmptyBlocksInterval, cs.CreateEmptyBlocksInterval)
+	cfg.SkipTimeoutCommit = cs.SkipTimeoutCommit == 1
+	if cs.CreateEmptyBlocks != nil {
+		cfg.CreateEmptyBlocks = *cs.CreateEmptyBlocks == 1
+	}
+	return cfg
+}
+
 // getMainChainConfig gets mainchain's config from config
 func (c *Config) getMainChainConfig() (*node.MainChainConfig, error) {
 	chain := c.MainChain
@@ -244,6 +370,10 @@ func (c *Config) getMainChainConfig() (*node.MainChainConfig, error) {
 	}
 	// assign consensus to genesisData
 	genesisData.ConsensusInfo = consensus
+	logsBlockRange := node.DefaultConfig.MainChainConfig.LogsBlockRange
+	if chain.LogsBlockRange != nil {
+		logsBlockRange = *chain.LogsBlockRange
+	}
 	mainChainConfig := node.MainChainConfig{
 		ValidatorIndexes: c.MainChain.Validators,
 		DBInfo:           dbInfo,
@@ -251,10 +381,13 @@ func (c *Config) getMainChainConfig() (*node.MainChainConfig, error) {
 		TxPool:           c.getTxPoolConfig(),
 		AcceptTxs:        chain.AcceptTxs,
 		IsZeroFee:        chain.ZeroFee == 1,
+		AddressIndex:     chain.AddressIndex == 1,
+		LogsBlockRange:   logsBlockRange,
 		NetworkId:        chain.NetworkID,
 		ChainId:          chain.ChainID,
 		ServiceName:      chain.ServiceName,
 		BaseAccount:      baseAccount,
+		Consensus:        c.getConsensusConfig(false),
 	}
 	return &mainChainConfig, nil
 }
@@ -290,6 +423,7 @@ func (c *Config) getDualChainConfig() (*node.DualChainConfig, error) {
 		ChainId:          c.DualChain.ChainID,
 		DualProtocolName: *c.DualChain.Protocol,
 		BaseAccount:      baseAccount,
+		Consensus:        c.getConsensusConfig(true),
 	}
 	return &dualChainConfig, nil
 }
@@ -311,10 +445,23 @@ func (c *Config) getNodeConfig() (*node.NodeConfig, error) {
 		HTTPCors:         n.HTTPCors,
 		HTTPVirtualHosts: n.HTTPVirtualHosts,
 		HTTPModules:      n.HTTPModules,
+		AdminHost:        n.AdminHost,
+		AdminPort:        n.AdminPort,
+		IPCPath:          node.DefaultIPCPath,
+		HTTPLimits:       c.getRPCLimits(),
 		MainChainConfig:  node.MainChainConfig{},
 		DualChainConfig:  node.DualChainConfig{},
 		PeerProxyIP:      "",
 	}
+	if n.IPCPath != nil {
+		nodeConfig.IPCPath = *n.IPCPath
+	}
+	if nodeConfig.HTTPAuth, err = getRPCAuth(n.RPCAuth); err != nil {
+		return nil, err
+	}
+	if nodeConfig.AdminAuth, err = getRPCAuth(n.AdminAuth); err != nil {
+		return nil, err
+	}
 	mainChainConfig, err := c.getMainChainConfig()
 	if err != nil {
 		return nil, err
@@ -341,7 +488,8 @@ func (c *Config) newLog() log.Logger {
 		fmt.Printf("invalid log level argument, default to INFO: %v \n", err)
 		level = log.LvlInfo
 	}
-	log.Root().SetHandler(log.LvlFilterHandler(level,
+	// Use a level handler so the verbosity can be adjusted through the admin API.
+	log.Root().SetHandler(log.NewLevelHandler(level,
 		log.StreamHandler(os.Stdout, log.TerminalFormat(true))))
 	return log.New()
 }
@@ -597,6 +745,20 @@ func waitForever() {
 
 func main() {
 	flag.Parse()
+	if flag.Arg(0) == "attach" {
+		if err := attach(flag.Arg(1)); err != nil {
+			fmt.Fprintf(os.Stderr, "attach failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "reindex" {
+		if err := reindex(flag.Arg(1)); err != nil {
+			fmt.Fprintf(os.Stderr, "reindex failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 	if args.config != "" {
 		config, err := LoadConfig(args.config)
 		if err != nil {
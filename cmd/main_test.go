@@ -0,0 +1,92 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/configs"
+)
+
+func TestGetConsensusConfig(t *testing.T) {
+	propose, commit, interval := uint64(1500), uint64(0), uint64(10000)
+	waitForTxs := uint(0)
+	c := &Config{
+		MainChain: &Chain{Consensus: &Consensus{
+			TimeoutPropose:            &propose,
+			TimeoutCommit:             &commit,
+			SkipTimeoutCommit:         1,
+			CreateEmptyBlocks:         &waitForTxs,
+			CreateEmptyBlocksInterval: &interval,
+		}},
+		DualChain: &Chain{},
+	}
+	defaults := configs.DefaultConsensusConfig()
+
+	cfg := c.getConsensusConfig(false)
+	if cfg.TimeoutPropose != 1500*time.Millisecond {
+		t.Errorf("TimeoutPropose = %v, want 1.5s", cfg.TimeoutPropose)
+	}
+	if cfg.TimeoutCommit != 0 {
+		t.Errorf("TimeoutCommit = %v, want 0", cfg.TimeoutCommit)
+	}
+	if cfg.TimeoutPrevote != defaults.TimeoutPrevote {
+		t.Errorf("TimeoutPrevote = %v, want the default %v", cfg.TimeoutPrevote, defaults.TimeoutPrevote)
+	}
+	if !cfg.SkipTimeoutCommit || cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval != 10*time.Second {
+		t.Errorf("empty block policy not applied: %+v", cfg)
+	}
+
+	// The dual chain has no consensus section and keeps the defaults.
+	if cfg := c.getConsensusConfig(true); *cfg.Timing() != *defaults.Timing() {
+		t.Errorf("dual chain timing = %v, want the defaults %v", cfg.Timing(), defaults.Timing())
+	}
+}
+
+func TestGetGenesisForkHeights(t *testing.T) {
+	headerCommitments, systemPhases := uint64(0), uint64(100)
+	c := &Config{
+		MainChain: &Chain{Genesis: &Genesis{
+			HeaderCommitmentsHeight: &headerCommitments,
+			SystemPhasesHeight:      &systemPhases,
+		}},
+		DualChain: &Chain{},
+	}
+
+	g, err := c.getGenesis(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := g.Config
+	if !cfg.IsHeaderCommitments(0) || cfg.IsSystemPhases(99) || !cfg.IsSystemPhases(100) || cfg.PrecompilesV1Height != nil {
+		t.Errorf("fork heights not applied: %v", cfg)
+	}
+	if configs.TestnetChainConfig.SystemPhasesHeight != nil {
+		t.Error("fork heights set on the testnet chain config")
+	}
+
+	// The dual chain has no genesis section and keeps the forks off.
+	if g, err = c.getGenesis(true); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := g.Config; cfg.HeaderCommitmentsHeight != nil || cfg.SystemPhasesHeight != nil || cfg.PrecompilesV1Height != nil {
+		t.Errorf("dual chain forks active: %v", cfg)
+	}
+}
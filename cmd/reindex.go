@@ -0,0 +1,80 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+)
+
+// reindex builds the address index of the main chain stored in the database of the
+// configured node, which must not be running, from the given height on. Since blocks
+// are not executed again, the accounts of internal value transfers are not indexed.
+func reindex(from string) error {
+	if args.config == "" {
+		return fmt.Errorf("config file is required")
+	}
+	c, err := LoadConfig(args.config)
+	if err != nil {
+		return err
+	}
+	if c.MainChain.Database.Drop == 1 {
+		return fmt.Errorf("database is configured to be dropped")
+	}
+	height := uint64(1)
+	if from != "" {
+		if height, err = strconv.ParseUint(from, 10, 64); err != nil {
+			return fmt.Errorf("invalid height %q: %v", from, err)
+		}
+	}
+	dbInfo := c.getDbInfo(false)
+	if dbInfo == nil {
+		return fmt.Errorf("cannot get dbInfo")
+	}
+	db, err := dbInfo.Start()
+	if err != nil {
+		return err
+	}
+	defer db.DB().Close()
+
+	head := db.ReadHeaderNumber(db.ReadHeadBlockHash())
+	if head == nil {
+		return fmt.Errorf("no chain found in database")
+	}
+	log.Info("Reindexing addresses", "from", height, "to", *head)
+	logged := time.Now()
+	for ; height <= *head; height++ {
+		hash := db.ReadCanonicalHash(height)
+		block := db.ReadBlock(hash, height)
+		if block == nil {
+			return fmt.Errorf("block %d missing", height)
+		}
+		blockchain.WriteAddressIndex(db, block, db.ReadReceipts(hash, height), nil)
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Reindexing addresses", "height", height, "head", *head)
+			logged = time.Now()
+		}
+	}
+	log.Info("Reindexed addresses", "head", *head)
+	return nil
+}
@@ -34,11 +34,38 @@ type (
 		HTTPModules       []string `yaml:"HTTPModules"`
 		HTTPVirtualHosts  []string `yaml:"HTTPVirtualHosts"`
 		HTTPCors          []string `yaml:"HTTPCors"`
+		AdminHost         string   `yaml:"AdminHost,omitempty"`
+		AdminPort         int      `yaml:"AdminPort,omitempty"`
+		IPCPath           *string  `yaml:"IPCPath,omitempty"` // unset is the default path, empty disables IPC
+		RPCLimits         *RPCLimits `yaml:"RPCLimits,omitempty"`
+		RPCAuth           *RPCAuth   `yaml:"RPCAuth,omitempty"`
+		AdminAuth         *RPCAuth   `yaml:"AdminAuth,omitempty"` // required unless AdminHost is a loopback interface
+	}
+	RPCLimits struct { // RPCLimits bounds the clients of the HTTP endpoint. Unset values keep their defaults.
+		BatchItemLimit       *int              `yaml:"BatchItemLimit,omitempty"`       // 0 is no limit
+		BatchResponseMaxSize *int              `yaml:"BatchResponseMaxSize,omitempty"` // in bytes, 0 is no limit
+		Timeout              *uint64           `yaml:"Timeout,omitempty"`              // in milliseconds, 0 is no timeout
+		MethodTimeouts       map[string]uint64 `yaml:"MethodTimeouts,omitempty"`       // in milliseconds, by method such as kai_estimateGas
+		BatchTimeout         *uint64           `yaml:"BatchTimeout,omitempty"`         // in milliseconds, 0 is Timeout
+		RateLimit            float64           `yaml:"RateLimit,omitempty"`            // requests per second per API key or IP, 0 is no limit
+		RateBurst            int               `yaml:"RateBurst,omitempty"`            // at least BatchItemLimit, 0 is the larger of BatchItemLimit and RateLimit
+	}
+	RPCAuth struct { // RPCAuth restricts each client of the HTTP endpoint to the modules of its credentials. "*" is every module.
+		PublicModules []string `yaml:"PublicModules"`           // modules served without credentials
+		JWTSecretFile string   `yaml:"JWTSecretFile,omitempty"` // file of the hex encoded HMAC secret of the bearer tokens
+		JWTModules    []string `yaml:"JWTModules,omitempty"`    // modules served with a valid token
+		APIKeys       []APIKey `yaml:"APIKeys,omitempty"`
+	}
+	APIKey struct {
+		Key     string   `yaml:"Key"`
+		Modules []string `yaml:"Modules"` // modules served with the X-Api-Key header set to Key
 	}
 	P2P struct {
 		PrivateKey    string    `yaml:"PrivateKey"`
 		ListenAddress string    `yaml:"ListenAddress"`
 		MaxPeers      int       `yaml:"MaxPeers"`
+		DNSDiscovery  []string  `yaml:"DNSDiscovery,omitempty"`
+		NodesFile     string    `yaml:"NodesFile,omitempty"`
 	}
 	Chain struct {
 		ServiceName   string         `yaml:"ServiceName"`
@@ -47,6 +74,8 @@ type (
 		NetworkID     uint64         `yaml:"NetworkID"`
 		AcceptTxs     uint32         `yaml:"AcceptTxs"`
 		ZeroFee       uint           `yaml:"ZeroFee"`
+		AddressIndex  uint           `yaml:"AddressIndex,omitempty"`
+		LogsBlockRange *uint64        `yaml:"LogsBlockRange,omitempty"` // maximum number of blocks of a kai_getLogs query, 0 is no limit
 		IsDual        uint           `yaml:"IsDual"`
 		Consensus     *Consensus     `yaml:"Consensus,omitempty"`
 		Genesis       *Genesis       `yaml:"Genesis,omitempty"`
@@ -64,6 +93,10 @@ type (
 		Addresses      []string      `yaml:"Addresses"`
 		GenesisAmount  string        `yaml:"GenesisAmount"`
 		Contracts      []Contract    `yaml:"Contracts"`
+		// Heights from which the forks of the chain config are active, unset is no activation
+		HeaderCommitmentsHeight *uint64 `yaml:"HeaderCommitmentsHeight,omitempty"`
+		SystemPhasesHeight      *uint64 `yaml:"SystemPhasesHeight,omitempty"`
+		PrecompilesV1Height     *uint64 `yaml:"PrecompilesV1Height,omitempty"`
 	}
 	Consensus struct {
 		MaxViolatePercentageAllowed uint64           `yaml:"MaxViolatePercentageAllowed"`
@@ -75,6 +108,17 @@ type (
 		LockedPeriod               uint64            `yaml:"LockedPeriod"`  // LockedPeriod defines the period in block that user cannot withdraw staked KAI.
 		Compilation                Compilation       `yaml:"Compilation"`
 		Deployment                 Deployment        `yaml:"Deployment"`
+		// Consensus timing, all in milliseconds. Unset values keep their defaults.
+		TimeoutPropose             *uint64           `yaml:"TimeoutPropose,omitempty"`
+		TimeoutProposeDelta        *uint64           `yaml:"TimeoutProposeDelta,omitempty"`
+		TimeoutPrevote             *uint64           `yaml:"TimeoutPrevote,omitempty"`
+		TimeoutPrevoteDelta        *uint64           `yaml:"TimeoutPrevoteDelta,omitempty"`
+		TimeoutPrecommit           *uint64           `yaml:"TimeoutPrecommit,omitempty"`
+		TimeoutPrecommitDelta      *uint64           `yaml:"TimeoutPrecommitDelta,omitempty"`
+		TimeoutCommit              *uint64           `yaml:"TimeoutCommit,omitempty"`
+		SkipTimeoutCommit          uint              `yaml:"SkipTimeoutCommit,omitempty"`         // 1 is yes
+		CreateEmptyBlocks          *uint             `yaml:"CreateEmptyBlocks,omitempty"`         // 0 waits for txs, default 1
+		CreateEmptyBlocksInterval  *uint64           `yaml:"CreateEmptyBlocksInterval,omitempty"` // max wait for txs before an empty block, 0 is forever
 	}
 	Compilation struct { // Compilation contains compiled bytecodes and abi for Master.sol, Node.sol and Staker.sol
 		Master     CompilationInfo  `yaml:"Master"`
@@ -128,6 +172,8 @@ type (
 		URI          string    `yaml:"URI"`
 		Name         string    `yaml:"Name"`
 		Drop         int       `yaml:"Drop"`
+		Ancient      string    `yaml:"Ancient,omitempty"`
+		FreezeThreshold uint64 `yaml:"FreezeThreshold,omitempty"`
 	}
 	Event struct {
 		MasterSmartContract string           `yaml:"MasterSmartContract"`
@@ -54,12 +54,17 @@ var (
 		},
 	}
 
-	// TestChainConfig contains the chain parameters to run unit test.
+	// TestChainConfig contains the chain parameters to run unit test, with all the
+	// forks active from genesis. Networks run with the other configs, whose forks
+	// are off until given an activation height above their head.
 	TestChainConfig = &types.ChainConfig{
 		Kaicon: &types.KaiconConfig{
 			Period: 15,
 			Epoch:  30000,
 		},
+		HeaderCommitmentsHeight: new(uint64),
+		SystemPhasesHeight:      new(uint64),
+		PrecompilesV1Height:     new(uint64),
 	}
 )
 
@@ -112,6 +117,23 @@ func (cfg *ConsensusConfig) WaitForTxs() bool {
 	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0
 }
 
+// Timing returns the timing of the consensus config, as persisted in the chain
+// config and exchanged with peers.
+func (cfg *ConsensusConfig) Timing() *types.ConsensusTimingConfig {
+	return &types.ConsensusTimingConfig{
+		TimeoutPropose:            uint64(cfg.TimeoutPropose / time.Millisecond),
+		TimeoutProposeDelta:       uint64(cfg.TimeoutProposeDelta / time.Millisecond),
+		TimeoutPrevote:            uint64(cfg.TimeoutPrevote / time.Millisecond),
+		TimeoutPrevoteDelta:       uint64(cfg.TimeoutPrevoteDelta / time.Millisecond),
+		TimeoutPrecommit:          uint64(cfg.TimeoutPrecommit / time.Millisecond),
+		TimeoutPrecommitDelta:     uint64(cfg.TimeoutPrecommitDelta / time.Millisecond),
+		TimeoutCommit:             uint64(cfg.TimeoutCommit / time.Millisecond),
+		SkipTimeoutCommit:         cfg.SkipTimeoutCommit,
+		CreateEmptyBlocks:         cfg.CreateEmptyBlocks,
+		CreateEmptyBlocksInterval: uint64(cfg.CreateEmptyBlocksInterval / time.Millisecond),
+	}
+}
+
 // Commit returns the amount of time to wait for straggler votes after receiving +2/3 precommits for a single block (ie. a commit).
 func (cfg *ConsensusConfig) Commit(t time.Time) time.Time {
 	return t.Add(cfg.TimeoutCommit)
@@ -179,7 +201,7 @@ var GenesisAccounts = map[string]*big.Int{
 	//"0x36BE7365e6037bD0FDa455DC4d197B07A2002547": 100000000,
 }
 
-//  GenesisAddrKeys maps genesis account addresses to private keys.
+// GenesisAddrKeys maps genesis account addresses to private keys.
 var GenesisAddrKeys = map[string]string{
 	"0xc1fe56E3F58D3244F606306611a5d10c8333f1f6": "8843ebcb1021b00ae9a644db6617f9c6d870e5fd53624cefe374c1d2d710fd06",
 	"0x7cefC13B6E2aedEeDFB7Cb6c32457240746BAEe5": "77cfc693f7861a6e1ea817c593c04fbc9b63d4d3146c5753c008cfc67cffca79",
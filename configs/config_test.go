@@ -0,0 +1,49 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package configs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsensusConfigTiming(t *testing.T) {
+	cfg := DefaultConsensusConfig()
+	cfg.TimeoutPropose = 1500 * time.Millisecond
+	cfg.SkipTimeoutCommit = true
+	cfg.CreateEmptyBlocksInterval = 10 * time.Second
+
+	timing := cfg.Timing()
+	if timing.TimeoutPropose != 1500 || timing.CreateEmptyBlocksInterval != 10000 {
+		t.Errorf("timing not in milliseconds: %v", timing)
+	}
+	if timing.TimeoutCommit != uint64(cfg.TimeoutCommit/time.Millisecond) {
+		t.Errorf("TimeoutCommit = %d, want %v", timing.TimeoutCommit, cfg.TimeoutCommit)
+	}
+	if !timing.SkipTimeoutCommit || timing.CreateEmptyBlocks != cfg.CreateEmptyBlocks {
+		t.Errorf("empty block policy not carried: %v", timing)
+	}
+	if !timing.Equal(cfg.Timing()) {
+		t.Errorf("timing of the same config differs")
+	}
+	cfg.TimeoutPrevote += time.Millisecond
+	if timing.Equal(cfg.Timing()) {
+		t.Errorf("timing of different configs is equal")
+	}
+}
@@ -32,7 +32,7 @@ type BaseBlockOperations interface {
 	LoadBlockCommit(height uint64) *types.Commit
 	LoadSeenCommit(height uint64) *types.Commit
 	CreateProposalBlock(height int64, state LastestBlockState, proposerAddr common.Address, commit *types.Commit) (block *types.Block, blockParts *types.PartSet)
-	CommitAndValidateBlockTxs(block *types.Block) (common.Hash, error)
+	CommitAndValidateBlockTxs(block *types.Block) (common.Hash, types.Receipts, error)
 	SaveBlock(block *types.Block, partSet *types.PartSet, seenCommit *types.Commit)
 	LoadBlockPart(height uint64, index int) *types.Part
 	LoadBlockMeta(height uint64) *types.BlockMeta
@@ -20,6 +20,7 @@ package consensus
 
 import (
 	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
 )
 
 type BaseProtocol interface {
@@ -32,4 +33,7 @@ type BaseProtocol interface {
 
 	// Broadcast message to all other peers.
 	Broadcast(msg interface{}, msgType uint64)
+
+	// ReportPeer charges the peer with a misbehavior, eventually banning it.
+	ReportPeer(id discover.NodeID, m p2p.Misbehavior, err error)
 }
@@ -0,0 +1,109 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package consensus
+
+import (
+	"time"
+
+	service "github.com/kardiachain/go-kardia/kai/service/const"
+	cmn "github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// ByzantineConfig scripts faulty behaviors of a validator, to test how the
+// honest validators cope with them. Only use it in tests.
+type ByzantineConfig struct {
+	// EquivocateHeight is the height at which the validator signs a conflicting
+	// second vote for every vote and, as proposer, a conflicting second proposal,
+	// sending both to its peers. Zero disables equivocation.
+	EquivocateHeight uint64
+
+	// SilentHeight and SilentFromRound..SilentToRound are the height and the
+	// rounds, inclusive, in which the validator withholds its proposals, and its
+	// votes too with SilentVotes. Zero disables the silence.
+	SilentHeight    uint64
+	SilentFromRound int
+	SilentToRound   int
+	SilentVotes     bool
+
+	// GossipDelay delays every consensus message sent to a single peer.
+	GossipDelay time.Duration
+}
+
+func (b *ByzantineConfig) equivocates(height *cmn.BigInt) bool {
+	return b != nil && b.EquivocateHeight != 0 && height.EqualsUint64(b.EquivocateHeight)
+}
+
+func (b *ByzantineConfig) silent(height, round *cmn.BigInt) bool {
+	if b == nil || b.SilentHeight == 0 || !height.EqualsUint64(b.SilentHeight) {
+		return false
+	}
+	return round.Int64() >= int64(b.SilentFromRound) && round.Int64() <= int64(b.SilentToRound)
+}
+
+func (b *ByzantineConfig) silentVotes(height, round *cmn.BigInt) bool {
+	return b.silent(height, round) && b.SilentVotes
+}
+
+// conflictingBlockID returns a block ID other than the given one: nil for a
+// block, a made up block for nil.
+func conflictingBlockID(blockID types.BlockID) types.BlockID {
+	if !blockID.IsZero() {
+		return types.BlockID{}
+	}
+	hash := cmn.BytesToHash(crypto.Keccak256([]byte("equivocation")))
+	return types.BlockID{Hash: hash, PartsHeader: types.PartSetHeader{Total: *cmn.NewBigInt32(1), Hash: hash}}
+}
+
+// delayedMsgWriter delays every message written to the wrapped writer.
+type delayedMsgWriter struct {
+	p2p.MsgReadWriter
+	delay time.Duration
+}
+
+func (w delayedMsgWriter) WriteMsg(msg p2p.Msg) error {
+	time.Sleep(w.delay)
+	return w.MsgReadWriter.WriteMsg(msg)
+}
+
+// equivocateVote signs a vote conflicting with the given one and sends it to
+// the peers only, keeping the given vote in our own vote set.
+func (cs *ConsensusState) equivocateVote(vote *types.Vote) {
+	blockID := conflictingBlockID(vote.BlockID)
+	conflicting, err := cs.signVote(vote.Type, blockID.Hash, blockID.PartsHeader)
+	if err != nil || cs.broadcast == nil {
+		return
+	}
+	cs.logger.Warn("Equivocating vote", "vote", vote, "conflicting", conflicting)
+	cs.broadcast(&VoteMessage{conflicting}, service.CsVoteMsg)
+}
+
+// equivocateProposal signs a proposal conflicting with the given one and sends
+// it to the peers right away, ahead of the gossip of the given proposal.
+func (cs *ConsensusState) equivocateProposal(proposal *types.Proposal) {
+	conflicting := types.NewProposal(proposal.Height, proposal.Round, proposal.POLRound, conflictingBlockID(proposal.POLBlockID))
+	conflicting.Timestamp = proposal.Timestamp
+	if err := cs.privValidator.SignProposal(cs.state.ChainID, conflicting); err != nil || cs.broadcast == nil {
+		return
+	}
+	cs.logger.Warn("Equivocating proposal", "proposal", proposal, "conflicting", conflicting)
+	cs.broadcast(&ProposalMessage{conflicting}, service.CsProposalMsg)
+}
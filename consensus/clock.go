@@ -0,0 +1,150 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package consensus
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the consensus state: the timestamps of votes,
+// the start of heights and the timeouts of rounds. Tests replace the system clock
+// by a SimClock to control when timeouts fire.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a time.Timer created by a Clock.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// SystemClock is the Clock of the system time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+// SimClock is a simulated Clock. Its time only moves when advanced, firing the
+// timers that expire on the way in order of expiry.
+type SimClock struct {
+	mtx    sync.Mutex
+	now    time.Time
+	timers []*simTimer
+}
+
+// NewSimClock creates a simulated clock starting at the given time.
+func NewSimClock(now time.Time) *SimClock {
+	return &SimClock{now: now}
+}
+
+// Now returns the simulated time.
+func (c *SimClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+// NewTimer creates a timer firing once the clock advanced by d.
+func (c *SimClock) NewTimer(d time.Duration) Timer {
+	t := &simTimer{clock: c, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the simulated time forward by d.
+func (c *SimClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = c.now.Add(d)
+	sort.Slice(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+	for len(c.timers) > 0 && !c.timers[0].deadline.After(c.now) {
+		c.timers[0].fire(c.now)
+		c.timers = c.timers[1:]
+	}
+}
+
+// remove unschedules the given timer, reporting whether it was scheduled.
+func (c *SimClock) remove(t *simTimer) bool {
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type simTimer struct {
+	clock    *SimClock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *simTimer) C() <-chan time.Time { return t.c }
+
+// Stop unschedules the timer, reporting whether it had not fired yet.
+func (t *simTimer) Stop() bool {
+	t.clock.mtx.Lock()
+	defer t.clock.mtx.Unlock()
+
+	return t.clock.remove(t)
+}
+
+// Reset reschedules the timer to fire once the clock advanced by d. Like a
+// time.Timer, a non-positive d fires the timer right away.
+func (t *simTimer) Reset(d time.Duration) bool {
+	t.clock.mtx.Lock()
+	defer t.clock.mtx.Unlock()
+
+	active := t.clock.remove(t)
+	t.deadline = t.clock.now.Add(d)
+	if d <= 0 {
+		t.fire(t.clock.now)
+	} else {
+		t.clock.timers = append(t.clock.timers, t)
+	}
+	return active
+}
+
+func (t *simTimer) fire(now time.Time) {
+	select {
+	case t.c <- now:
+	default:
+	}
+}
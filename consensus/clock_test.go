@@ -0,0 +1,97 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	cstypes "github.com/kardiachain/go-kardia/consensus/types"
+	cmn "github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+)
+
+func TestSimClockTimers(t *testing.T) {
+	clock := NewSimClock(time.Unix(1000, 0))
+	t1 := clock.NewTimer(time.Second)
+	t2 := clock.NewTimer(2 * time.Second)
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-t1.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	select {
+	case now := <-t1.C():
+		if !now.Equal(time.Unix(1001, 0)) {
+			t.Errorf("timer fired at %v, want %v", now, time.Unix(1001, 0))
+		}
+	default:
+		t.Fatal("timer didn't fire")
+	}
+	if !t2.Stop() {
+		t.Error("stopping pending timer returned false")
+	}
+	clock.Advance(time.Hour)
+	select {
+	case <-t2.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+}
+
+func TestTimeoutTickerSimClock(t *testing.T) {
+	clock := NewSimClock(time.Unix(1000, 0))
+	ticker := NewTimeoutTickerWithClock(clock)
+	ticker.SetLogger(log.New())
+	ticker.Start()
+	defer ticker.Stop()
+
+	schedule := func(d time.Duration, round int, step cstypes.RoundStepType) {
+		ticker.ScheduleTimeout(timeoutInfo{d, cmn.NewBigInt32(1), cmn.NewBigInt32(round), step})
+	}
+	// The ticker picks up scheduled timeouts asynchronously
+	advanceUntil := func(d time.Duration) timeoutInfo {
+		for {
+			clock.Advance(d)
+			select {
+			case ti := <-ticker.Chan():
+				return ti
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+
+	schedule(time.Second, 0, cstypes.RoundStepPropose)
+	// A later step replaces the pending timeout
+	schedule(time.Minute, 0, cstypes.RoundStepPrevoteWait)
+	// Older steps are ignored
+	schedule(time.Millisecond, 0, cstypes.RoundStepNewRound)
+
+	start := clock.Now()
+	ti := advanceUntil(10 * time.Second)
+	if ti.Step != cstypes.RoundStepPrevoteWait {
+		t.Errorf("timeout of step %v, want %v", ti.Step, cstypes.RoundStepPrevoteWait)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed < time.Minute {
+		t.Errorf("timeout after %v, want %v", elapsed, time.Minute)
+	}
+}
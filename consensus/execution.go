@@ -37,12 +37,12 @@ type EvidencePool interface {
 // If the block is invalid, it returns an error.
 // Validation does not mutate state, but does require historical information from the stateDB,
 // ie. to verify evidence from a validator at an old height.
-func ValidateBlock(state LastestBlockState, block *types.Block) error {
-	return validateBlock(state, block)
+func ValidateBlock(config *types.ChainConfig, state LastestBlockState, block *types.Block) error {
+	return validateBlock(config, state, block)
 }
 
 type BlockStore interface {
-	CommitAndValidateBlockTxs(*types.Block) (cmn.Hash, error)
+	CommitAndValidateBlockTxs(*types.Block) (cmn.Hash, types.Receipts, error)
 }
 
 // Validates the block against the state, and saves the new state.
@@ -51,16 +51,16 @@ type BlockStore interface {
 // It takes a blockID to avoid recomputing the parts hash.
 func ApplyBlock(logger log.Logger, st LastestBlockState, blockStore BlockStore, blockID types.BlockID, block *types.Block, bc base.BaseBlockChain) (LastestBlockState, error) {
 
-	if err := ValidateBlock(st, block); err != nil {
+	if err := ValidateBlock(bc.Config(), st, block); err != nil {
 		return st, state.ErrInvalidBlock(err)
 	}
 
-	appHash, err := blockStore.CommitAndValidateBlockTxs(block)
+	appHash, receipts, err := blockStore.CommitAndValidateBlockTxs(block)
 	if err != nil {
 		return st, err
 	}
 	// update the state with the block and responses
-	st, err = updateState(logger, st, appHash, blockID, block.Header(), bc)
+	st, err = updateState(logger, st, appHash, receipts, blockID, block.Header(), bc)
 	if err != nil {
 		return st, fmt.Errorf("commit failed for application: %v", err)
 	}
@@ -71,7 +71,7 @@ func ApplyBlock(logger log.Logger, st LastestBlockState, blockStore BlockStore,
 }
 
 // updateState returns a new State updated according to the header and responses.
-func updateState(logger log.Logger, state LastestBlockState, appHash cmn.Hash, blockID types.BlockID, header *types.Header, bc base.BaseBlockChain) (LastestBlockState, error) {
+func updateState(logger log.Logger, state LastestBlockState, appHash cmn.Hash, receipts types.Receipts, blockID types.BlockID, header *types.Header, bc base.BaseBlockChain) (LastestBlockState, error) {
 	logger.Trace("updateState", "state", state, "blockID", blockID, "header")
 
 	// Update the validator set with the latest abciResponses
@@ -102,5 +102,7 @@ func updateState(logger log.Logger, state LastestBlockState, appHash cmn.Hash, b
 		LastValidators:              prevVals,
 		LastHeightValidatorsChanged: lastHeightValsChanged,
 		AppHash:                     appHash,
+		LastReceiptHash:             types.DeriveSha(receipts),
+		LastBloom:                   types.CreateBloom(receipts),
 	}, nil
 }
@@ -0,0 +1,76 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/base"
+	cmn "github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// fetchTimeChain is a chain fetching the validators of the next period the given
+// number of blocks before the end of the current one.
+type fetchTimeChain struct {
+	base.BaseBlockChain
+	fetchTime uint64
+}
+
+func (c *fetchTimeChain) GetFetchNewValidatorsTime() uint64 {
+	return c.fetchTime
+}
+
+func newTestValidatorSet(t *testing.T) *types.ValidatorSet {
+	var vals []*types.Validator
+	for _, power := range []int64{1, 2, 4} {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals = append(vals, types.NewValidator(key.PublicKey, power))
+	}
+	return types.NewValidatorSet(vals, 0, 1000000)
+}
+
+// Tests that a node restarted at any height agrees on the next proposer with the
+// nodes which applied every block since the start of the validator set.
+func TestReplayProposerAfterRestart(t *testing.T) {
+	vals := newTestValidatorSet(t)
+	st := LastestBlockState{
+		LastBlockHeight: cmn.NewBigUint64(0),
+		Validators:      vals.Copy(),
+		LastValidators:  vals.Copy(),
+	}
+	bc := &fetchTimeChain{fetchTime: 10}
+	for height := uint64(1); height <= 20; height++ {
+		var err error
+		st, err = updateState(log.New(), st, cmn.Hash{}, nil, types.BlockID{}, &types.Header{Height: height}, bc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		restarted := vals.Copy()
+		restarted.ReplayProposer(int64(height))
+		if want, got := st.Validators.GetProposer().Address, restarted.GetProposer().Address; !want.Equal(got) {
+			t.Fatalf("height %d: restarted proposer %x, want %x", height, got, want)
+		}
+	}
+}
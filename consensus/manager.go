@@ -30,6 +30,7 @@ import (
 	cmn "github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
 	"github.com/kardiachain/go-kardia/types"
 )
 
@@ -42,7 +43,8 @@ type ConsensusManager struct {
 
 	conS *ConsensusState
 
-	mtx sync.RWMutex
+	mtx        sync.RWMutex
+	peerStates map[discover.NodeID]*PeerState // Consensus state of connected peers
 	//eventBus *types.EventBus
 
 	running bool
@@ -51,21 +53,59 @@ type ConsensusManager struct {
 // NewConsensusManager returns a new ConsensusManager with the given
 // consensusState.
 func NewConsensusManager(id string, consensusState *ConsensusState) *ConsensusManager {
-	return &ConsensusManager{
-		id:     id,
-		logger: consensusState.logger,
-		conS:   consensusState,
+	conR := &ConsensusManager{
+		id:         id,
+		logger:     consensusState.logger,
+		conS:       consensusState,
+		peerStates: make(map[discover.NodeID]*PeerState),
 	}
+	consensusState.reportPeer = conR.reportPeer
+	consensusState.broadcast = conR.broadcast
+	return conR
 }
 
 func (conR *ConsensusManager) SetProtocol(protocol BaseProtocol) {
 	conR.protocol = protocol
 }
 
+// reportPeer charges the given peer with a misbehavior detected while
+// processing its consensus messages.
+func (conR *ConsensusManager) reportPeer(peerID discover.NodeID, m p2p.Misbehavior, err error) {
+	if conR.protocol == nil {
+		return
+	}
+	conR.protocol.ReportPeer(peerID, m, err)
+}
+
+// broadcast sends the given message to all peers.
+func (conR *ConsensusManager) broadcast(msg interface{}, msgType uint64) {
+	if conR.protocol == nil {
+		return
+	}
+	conR.protocol.Broadcast(msg, msgType)
+}
+
 func (conR *ConsensusManager) SetPrivValidator(priv *types.PrivValidator) {
 	conR.conS.SetPrivValidator(priv)
 }
 
+// SetClock replaces the system clock of the consensus. It must be called before
+// Start.
+func (conR *ConsensusManager) SetClock(clock Clock) {
+	conR.conS.SetClock(clock)
+}
+
+// SetByzantine makes the validator misbehave as configured. It must be called
+// before Start and only in tests.
+func (conR *ConsensusManager) SetByzantine(byzantine *ByzantineConfig) {
+	conR.conS.SetByzantine(byzantine)
+}
+
+// Evidence returns the evidence of misbehaving validators detected so far.
+func (conR *ConsensusManager) Evidence() []types.Evidence {
+	return conR.conS.Evidence()
+}
+
 func (conR *ConsensusManager) Validator() *types.Validator {
 	if _, val := conR.conS.Validators.GetByAddress(conR.conS.privValidator.GetAddress()); val != nil {
 		return val
@@ -111,10 +151,18 @@ func (conR *ConsensusManager) AddPeer(p *p2p.Peer, rw p2p.MsgReadWriter) {
 		return
 	}
 
+	if b := conR.conS.byzantine; b != nil && b.GossipDelay > 0 {
+		rw = delayedMsgWriter{rw, b.GossipDelay}
+	}
+
 	//// Create peerState for peer
 	peerState := NewPeerState(p, rw).SetLogger(conR.logger)
 	p.Set(conR.GetPeerStateKey(), peerState)
 
+	conR.mtx.Lock()
+	conR.peerStates[p.ID()] = peerState
+	conR.mtx.Unlock()
+
 	// Begin routines for this peer.
 	go conR.gossipDataRoutine(p, peerState)
 	go conR.gossipVotesRoutine(p, peerState)
@@ -122,7 +170,26 @@ func (conR *ConsensusManager) AddPeer(p *p2p.Peer, rw p2p.MsgReadWriter) {
 }
 
 func (conR *ConsensusManager) RemovePeer(p *p2p.Peer, reason interface{}) {
-	conR.logger.Warn("ConsensusManager.RemovePeer - not yet implemented")
+	conR.mtx.Lock()
+	delete(conR.peerStates, p.ID())
+	conR.mtx.Unlock()
+}
+
+// GetRoundState returns a copy of the current round state of the consensus.
+func (conR *ConsensusManager) GetRoundState() *cstypes.RoundState {
+	return conR.conS.GetRoundState()
+}
+
+// PeerRoundStates returns the known round states of all connected peers.
+func (conR *ConsensusManager) PeerRoundStates() map[discover.NodeID]*cstypes.PeerRoundState {
+	conR.mtx.RLock()
+	defer conR.mtx.RUnlock()
+
+	states := make(map[discover.NodeID]*cstypes.PeerRoundState, len(conR.peerStates))
+	for id, ps := range conR.peerStates {
+		states[id] = ps.GetRoundState()
+	}
+	return states
 }
 
 func (conR *ConsensusManager) GetPeerStateKey() string {
@@ -169,6 +236,7 @@ func (conR *ConsensusManager) ReceiveNewRoundStep(generalMsg p2p.Msg, src *p2p.P
 	var msg NewRoundStepMessage
 	if err := generalMsg.Decode(&msg); err != nil {
 		conR.logger.Error("Invalid message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 	conR.logger.Trace("Decoded msg", "msg", msg)
@@ -193,11 +261,13 @@ func (conR *ConsensusManager) ReceiveNewBlockPart(generalMsg p2p.Msg, src *p2p.P
 	msg := &BlockPartMessage{}
 	if err := generalMsg.Decode(msg); err != nil {
 		conR.logger.Error("Failed to decode block part message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 
 	if err := msg.ValidateBasic(); err != nil {
 		conR.logger.Error("Failed to validate block part message", "peer", src, "msg", msg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidBlockPart, err)
 		return
 	}
 
@@ -222,11 +292,13 @@ func (conR *ConsensusManager) ReceiveNewValidBlock(generalMsg p2p.Msg, src *p2p.
 	msg := &NewValidBlockMessage{}
 	if err := generalMsg.Decode(msg); err != nil {
 		conR.logger.Error("Failed to decode valid block message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 
 	if err := msg.ValidateBasic(); err != nil {
 		conR.logger.Error("Failed to validate valid block message ", "peer", src, "msg", msg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 
@@ -250,6 +322,7 @@ func (conR *ConsensusManager) ReceiveNewProposal(generalMsg p2p.Msg, src *p2p.Pe
 	msg := &ProposalMessage{}
 	if err := generalMsg.Decode(msg); err != nil {
 		conR.logger.Error("Invalid proposal message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 
@@ -283,6 +356,7 @@ func (conR *ConsensusManager) ReceiveNewVote(generalMsg p2p.Msg, src *p2p.Peer)
 	var msg VoteMessage
 	if err := generalMsg.Decode(&msg); err != nil {
 		conR.logger.Error("Invalid vote message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 	conR.logger.Trace("Decoded msg", "msg", msg.Vote)
@@ -317,6 +391,7 @@ func (conR *ConsensusManager) ReceiveHasVote(generalMsg p2p.Msg, src *p2p.Peer)
 	var msg HasVoteMessage
 	if err := generalMsg.Decode(&msg); err != nil {
 		conR.logger.Error("Invalid HasVoteMessage", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 	conR.logger.Trace("Decoded msg", "msg", msg)
@@ -342,6 +417,7 @@ func (conR *ConsensusManager) ReceiveProposalPOL(generalMsg p2p.Msg, src *p2p.Pe
 	var msg ProposalPOLMessage
 	if err := generalMsg.Decode(&msg); err != nil {
 		conR.logger.Error("Invalid ProposalPOLMessage", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 	conR.logger.Trace("Decoded msg", "msg", msg)
@@ -367,6 +443,7 @@ func (conR *ConsensusManager) ReceiveVoteSetMaj23(generalMsg p2p.Msg, src *p2p.P
 	var msg VoteSetMaj23Message
 	if err := generalMsg.Decode(&msg); err != nil {
 		conR.logger.Error("Invalid VoteSetMaj23Message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 	conR.logger.Trace("Decoded msg", "msg", &msg)
@@ -424,6 +501,7 @@ func (conR *ConsensusManager) ReceiveVoteSetBits(generalMsg p2p.Msg, src *p2p.Pe
 	var msg VoteSetBitsMessage
 	if err := generalMsg.Decode(&msg); err != nil {
 		conR.logger.Error("Invalid VoteSetBitsMessage", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return
 	}
 	conR.logger.Trace("Decoded msg", "msg", msg)
@@ -1405,6 +1483,7 @@ func (m *NewValidBlockMessage) String() string {
 func (conR *ConsensusManager) decodeMsgAndGetPeerState(decodedMsg interface{}, generalMsg p2p.Msg, src *p2p.Peer) (*PeerState, error) {
 	if err := generalMsg.Decode(decodedMsg); err != nil {
 		conR.logger.Error("Invalid block part message", "msg", generalMsg, "err", err)
+		src.Report(p2p.MisbehaviorInvalidMsg, err)
 		return nil, err
 	}
 	ps, ok := src.Get(conR.GetPeerStateKey()).(*PeerState)
@@ -33,11 +33,13 @@ import (
 	"github.com/kardiachain/go-kardia/lib/rlp"
 
 	"github.com/ebuchman/fail-test"
+	pkgerrors "github.com/pkg/errors"
 
 	cfg "github.com/kardiachain/go-kardia/configs"
 	cstypes "github.com/kardiachain/go-kardia/consensus/types"
 	cmn "github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/lib/p2p/discover"
 	"github.com/kardiachain/go-kardia/types"
 )
@@ -46,10 +48,19 @@ var (
 	msgQueueSize = 1000
 )
 
+const (
+	// evidenceMaxAge is the number of heights the evidence of misbehaving
+	// validators is kept for.
+	evidenceMaxAge = 100
+	// maxEvidence bounds the evidence kept, the oldest being dropped first.
+	maxEvidence = 1000
+)
+
 var (
 	ErrInvalidProposalSignature = errors.New("Error invalid proposal signature")
 	ErrInvalidProposalPOLRound  = errors.New("Error invalid proposal POL round")
 	ErrAddingVote               = errors.New("Error adding vote")
+	ErrInvalidVoteSignature     = errors.New("Error invalid vote signature")
 	ErrVoteHeightMismatch       = errors.New("Error vote height mismatch")
 )
 
@@ -108,6 +119,7 @@ type ConsensusState struct {
 	mtx sync.RWMutex
 	cstypes.RoundState
 	state         LastestBlockState // State until height-1.
+	clock         Clock
 	timeoutTicker TimeoutTicker
 
 	// State changes may be triggered by: msgs from peers,
@@ -133,6 +145,18 @@ type ConsensusState struct {
 	votingStrategy map[VoteTurn]int
 
 	updateVals bool
+
+	// reportPeer charges a peer with a misbehavior, set by the consensus manager
+	reportPeer func(peerID discover.NodeID, m p2p.Misbehavior, err error)
+
+	// broadcast sends a message to all peers, set by the consensus manager
+	broadcast func(msg interface{}, msgType uint64)
+
+	// byzantine scripts faulty behaviors of the validator in tests
+	byzantine *ByzantineConfig
+
+	// evidence of misbehaving validators detected at the last heights
+	evidence []types.Evidence
 }
 
 // NewConsensusState returns a new ConsensusState.
@@ -150,6 +174,7 @@ func NewConsensusState(
 		blockOperations:  blockOperations,
 		peerMsgQueue:     make(chan msgInfo, msgQueueSize),
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
+		clock:            SystemClock,
 		timeoutTicker:    NewTimeoutTicker(),
 		done:             make(chan struct{}),
 		evsw:             NewEventSwitch(),
@@ -174,6 +199,57 @@ func NewConsensusState(
 	return cs
 }
 
+// SetClock replaces the system clock by the given clock. It must be called
+// before Start.
+func (cs *ConsensusState) SetClock(clock Clock) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	cs.clock = clock
+	cs.timeoutTicker = NewTimeoutTickerWithClock(clock)
+	cs.timeoutTicker.SetLogger(cs.logger)
+}
+
+// SetByzantine makes the validator misbehave as configured. It must be called
+// before Start and only in tests.
+func (cs *ConsensusState) SetByzantine(byzantine *ByzantineConfig) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	cs.byzantine = byzantine
+}
+
+// Evidence returns the evidence of misbehaving validators detected at the last
+// evidenceMaxAge heights, up to maxEvidence of them.
+func (cs *ConsensusState) Evidence() []types.Evidence {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+
+	return append([]types.Evidence(nil), cs.evidence...)
+}
+
+// addEvidence records the given evidence unless known already, dropping the
+// evidence older than evidenceMaxAge heights and, beyond maxEvidence, the oldest.
+func (cs *ConsensusState) addEvidence(ev types.Evidence) {
+	for _, known := range cs.evidence {
+		if known.Equal(ev) {
+			return
+		}
+	}
+	cs.logger.Warn("Detected misbehaving validator", "evidence", ev)
+
+	kept := cs.evidence[:0]
+	for _, known := range cs.evidence {
+		if known.Height().Uint64()+evidenceMaxAge >= cs.Height.Uint64() {
+			kept = append(kept, known)
+		}
+	}
+	if len(kept) >= maxEvidence {
+		kept = append(kept[:0], kept[len(kept)-maxEvidence+1:]...)
+	}
+	cs.evidence = append(kept, ev)
+}
+
 // SetPrivValidator sets the private validator account for signing votes.
 func (cs *ConsensusState) SetPrivValidator(priv *types.PrivValidator) {
 	cs.mtx.Lock()
@@ -258,7 +334,7 @@ func (cs *ConsensusState) updateToState(state LastestBlockState) {
 		// And alternative solution that relies on clocks:
 		//  cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
 		cs.logger.Trace("cs.CommitTime is 0")
-		cs.StartTime = big.NewInt(cs.config.Commit(time.Now()).Unix())
+		cs.StartTime = big.NewInt(cs.config.Commit(cs.clock.Now()).Unix())
 	} else {
 		commitTime := time.Unix(cs.CommitTime.Int64(), 0)
 		cs.StartTime = big.NewInt(cs.config.Commit(commitTime).Unix())
@@ -308,6 +384,11 @@ func (cs *ConsensusState) decideProposal(height *cmn.BigInt, round *cmn.BigInt)
 	var block *types.Block
 	var blockParts *types.PartSet
 
+	if cs.byzantine.silent(height, round) {
+		cs.logger.Info("Withholding proposal", "height", height, "round", round)
+		return
+	}
+
 	// Decide on block
 	if cs.ValidBlock != nil {
 		// If there is valid block, choose that.
@@ -325,6 +406,7 @@ func (cs *ConsensusState) decideProposal(height *cmn.BigInt, round *cmn.BigInt)
 	// Make proposal
 	propBlockID := types.BlockID{Hash: block.Hash(), PartsHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
+	proposal.Timestamp = big.NewInt(cs.clock.Now().Unix())
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, proposal); err == nil {
 		cs.logger.Info("Signed proposal", "height", height, "round", round, "proposal", propBlockID.Hash)
 		// send proposal and block parts on internal msg queue
@@ -335,6 +417,10 @@ func (cs *ConsensusState) decideProposal(height *cmn.BigInt, round *cmn.BigInt)
 		}
 		cs.logger.Info("Signed proposal", "height", height, "round", round, "proposal", proposal)
 		cs.logger.Debug(fmt.Sprintf("Signed proposal block: %s", block.Hash()))
+
+		if cs.byzantine.equivocates(height) {
+			cs.equivocateProposal(proposal)
+		}
 	}
 }
 
@@ -379,8 +465,9 @@ func (cs *ConsensusState) setProposal(proposal *types.Proposal) error {
 
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *ConsensusState) scheduleRound0(rs *cstypes.RoundState) {
-	cs.logger.Info("scheduleRound0", "now", time.Now(), "startTime", time.Unix(cs.StartTime.Int64(), 0))
-	sleepDuration := time.Duration(rs.StartTime.Int64() - time.Now().Unix()) // nolint: gotype, gosimple
+	now := cs.clock.Now()
+	cs.logger.Info("scheduleRound0", "now", now, "startTime", time.Unix(cs.StartTime.Int64(), 0))
+	sleepDuration := time.Duration(rs.StartTime.Int64() - now.Unix()) // nolint: gotype, gosimple
 	cs.scheduleTimeout(sleepDuration, rs.Height, cmn.NewBigInt32(0), cstypes.RoundStepNewHeight)
 }
 
@@ -421,19 +508,21 @@ func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID discover.NodeID) (
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
 		// But if it's a conflicting sig, add it to the cs.evpool.
-		// If it's otherwise invalid, punish peer.
+		// If its signature is invalid, punish peer.
 		if err == ErrVoteHeightMismatch {
 			return added, err
-		} else if _, ok := err.(*types.ErrVoteConflictingVotes); ok {
+		} else if voteErr, ok := err.(*types.ErrVoteConflictingVotes); ok {
 			if vote.ValidatorAddress.Equal(cs.privValidator.GetAddress()) {
 				cs.logger.Error("Found conflicting vote from ourselves. Did you unsafe_reset a validator?", "height", vote.Height, "round", vote.Round, "type", vote.Type)
 				return added, err
 			}
-			// TODO(namdoh): Re-enable this later.
-			cs.logger.Warn("Add vote error to evidence pool later")
+			cs.addEvidence(voteErr.DuplicateVoteEvidence)
 			return added, err
+		} else if pkgerrors.Cause(err) == types.ErrVoteInvalidSignature {
+			// Peers only relay the votes they verified, so the peer forged it.
+			cs.logger.Error("Invalid vote signature", "peer", peerID, "err", err)
+			return added, ErrInvalidVoteSignature
 		} else {
-			// Probably an invalid signature / Bad peer.
 			// Seems this can also err sometimes with "Unexpected step" - perhaps not from a bad peer ?
 			cs.logger.Error("Error attempting to add vote", "err", err)
 			return added, ErrAddingVote
@@ -629,7 +718,7 @@ func (cs *ConsensusState) signVote(type_ types.SignedMsgType, hash cmn.Hash, hea
 }
 
 func (cs *ConsensusState) voteTime() *big.Int {
-	now := time.Now()
+	now := cs.clock.Now()
 	minVoteTime := now.Unix()
 	// TODO: We should remove next line in case we don't vote for v in case cs.ProposalBlock == nil,
 	// even if cs.LockedBlock != nil. See https://github.com/tendermint/spec.
@@ -650,10 +739,17 @@ func (cs *ConsensusState) signAddVote(type_ types.SignedMsgType, hash cmn.Hash,
 	if cs.privValidator == nil || !cs.Validators.HasAddress(cs.privValidator.GetAddress()) {
 		return nil
 	}
+	if cs.byzantine.silentVotes(cs.Height, cs.Round) {
+		cs.logger.Info("Withholding vote", "height", cs.Height, "round", cs.Round, "type", type_)
+		return nil
+	}
 	vote, err := cs.signVote(type_, hash, header)
 	if err == nil {
 		cs.sendInternalMessage(msgInfo{&VoteMessage{vote}, discover.ZeroNodeID()})
 		cs.logger.Info("Signed and pushed vote", "height", cs.Height, "round", cs.Round, "vote", vote, "err", err)
+		if cs.byzantine.equivocates(cs.Height) {
+			cs.equivocateVote(vote)
+		}
 		return vote
 	}
 	//if !cs.replayMode {
@@ -785,7 +881,7 @@ func (cs *ConsensusState) handleTxsAvailable() {
 		}
 
 		// +1ms to ensure RoundStepNewRound timeout always happens after RoundStepNewHeight
-		timeoutCommit := time.Duration(cs.StartTime.Int64()-time.Now().Unix()) + 1*time.Millisecond
+		timeoutCommit := time.Duration(cs.StartTime.Int64()-cs.clock.Now().Unix()) + 1*time.Millisecond
 		cs.scheduleTimeout(timeoutCommit, cs.Height, cmn.NewBigInt32(0), cstypes.RoundStepNewRound)
 	case cstypes.RoundStepNewRound: // after timeoutCommit
 		cs.enterPropose(cs.Height, cmn.NewBigInt32(0))
@@ -806,7 +902,7 @@ func (cs *ConsensusState) enterNewRound(height *cmn.BigInt, round *cmn.BigInt) {
 		return
 	}
 
-	if now := time.Now().Unix(); cs.StartTime.Int64() > now {
+	if now := cs.clock.Now().Unix(); cs.StartTime.Int64() > now {
 		logger.Info("Need to set a buffer and log message here for sanity.", "startTime", time.Unix(cs.StartTime.Int64(), 0), "now", time.Unix(now, 0))
 	}
 
@@ -960,7 +1056,7 @@ func (cs *ConsensusState) doPrevote(height *cmn.BigInt, round *cmn.BigInt) {
 
 	// Validate proposal block
 	// This checks the block contents without executing txs.
-	if err := ValidateBlock(cs.state, cs.ProposalBlock); err != nil {
+	if err := ValidateBlock(cs.blockOperations.Blockchain().Config(), cs.state, cs.ProposalBlock); err != nil {
 		// ProposalBlock is invalid, prevote nil.
 		logger.Error("enterPrevote: ProposalBlock is invalid", "err", err)
 		cs.signAddVote(types.PrevoteType, cmn.Hash{}, types.PartSetHeader{})
@@ -1039,7 +1135,7 @@ func (cs *ConsensusState) enterPrecommit(height *cmn.BigInt, round *cmn.BigInt)
 	// the latest POLRound should be this round.
 	polRound, _ := cs.Votes.POLInfo()
 	if polRound < round.Int32() {
-		cmn.PanicSanity(cmn.Fmt("This POLRound should be %v but got %", round, polRound))
+		cmn.PanicSanity(cmn.Fmt("This POLRound should be %v but got %v", round, polRound))
 	}
 
 	// +2/3 prevoted nil. Unlock and precommit nil.
@@ -1072,7 +1168,7 @@ func (cs *ConsensusState) enterPrecommit(height *cmn.BigInt, round *cmn.BigInt)
 	if cs.ProposalBlock.HashesTo(blockID.Hash) {
 		logger.Info("enterPrecommit: +2/3 prevoted proposal block. Locking", "hash", blockID)
 		// Validate the block.
-		if err := ValidateBlock(cs.state, cs.ProposalBlock); err != nil {
+		if err := ValidateBlock(cs.blockOperations.Blockchain().Config(), cs.state, cs.ProposalBlock); err != nil {
 			cmn.PanicConsensus(cmn.Fmt("enterPrecommit: +2/3 prevoted for an invalid block: %v", err))
 		}
 		cs.LockedRound = round
@@ -1140,7 +1236,7 @@ func (cs *ConsensusState) enterCommit(height *cmn.BigInt, commitRound *cmn.BigIn
 		// keep cs.Round the same, commitRound points to the right Precommits set.
 		cs.updateRoundStep(cs.Round, cstypes.RoundStepCommit)
 		cs.CommitRound = commitRound
-		cs.CommitTime = big.NewInt(time.Now().Unix())
+		cs.CommitTime = big.NewInt(cs.clock.Now().Unix())
 		cs.newStep()
 
 		// Maybe finalize immediately.
@@ -1217,7 +1313,7 @@ func (cs *ConsensusState) finalizeCommit(height *cmn.BigInt) {
 	if !block.HashesTo(blockID.Hash) {
 		cmn.PanicSanity(cmn.Fmt("Cannot finalizeCommit, ProposalBlock does not hash to commit hash"))
 	}
-	if err := ValidateBlock(cs.state, block); err != nil {
+	if err := ValidateBlock(cs.blockOperations.Blockchain().Config(), cs.state, block); err != nil {
 		cmn.PanicConsensus(cmn.Fmt("+2/3 committed an invalid block: %v", err))
 		panic("Block validation failed")
 	}
@@ -1408,9 +1504,16 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 	switch msg := msg.(type) {
 	case *ProposalMessage:
 		err = cs.setProposal(msg.Proposal)
+		if err == ErrInvalidProposalSignature || err == ErrInvalidProposalPOLRound {
+			cs.report(peerID, p2p.MisbehaviorInvalidProposal, err)
+		}
 	case *BlockPartMessage:
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
 		_, err := cs.addProposalBlockPart(msg, peerID)
+		// Peers only send the parts of our proposal, whose proofs they verified.
+		if err == types.ErrPartSetInvalidProof && msg.Round.Equals(cs.Round) {
+			cs.report(peerID, p2p.MisbehaviorInvalidBlockPart, err)
+		}
 		if err != nil && msg.Round != cs.Round {
 			cs.logger.Debug(
 				"Received block part from wrong round",
@@ -1427,9 +1530,8 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
 		cs.logger.Trace("handling AddVote", "VoteMessage", msg.Vote)
 		_, err := cs.tryAddVote(msg.Vote, peerID)
-		if err == ErrAddingVote {
-			cs.logger.Trace("trying to add vote failed", "err", err)
-			cs.logger.Warn("TODO - punish peer.")
+		if err == ErrInvalidVoteSignature {
+			cs.report(peerID, p2p.MisbehaviorInvalidVote, err)
 		}
 
 	default:
@@ -1440,6 +1542,15 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 	}
 }
 
+// report charges the given peer with a misbehavior. Internal messages, which
+// carry no peer ID, are never reported.
+func (cs *ConsensusState) report(peerID discover.NodeID, m p2p.Misbehavior, err error) {
+	if peerID.IsZero() || cs.reportPeer == nil {
+		return
+	}
+	cs.reportPeer(peerID, m, err)
+}
+
 func (cs *ConsensusState) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	cs.logger.Debug("Received tock", "timeout", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
 
@@ -1502,6 +1613,10 @@ type LastestBlockState struct {
 	// Merkle root of the results from executing prev block
 	//namdoh@ LastResultsHash []byte
 
+	// Receipts root and logs bloom of the txs executed in the last block.
+	LastReceiptHash cmn.Hash
+	LastBloom       types.Bloom
+
 	// The latest AppHash we've received from calling abci.Commit()
 	AppHash cmn.Hash
 }
@@ -1527,7 +1642,8 @@ func (state LastestBlockState) Copy() LastestBlockState {
 
 		AppHash: state.AppHash,
 
-		//namdoh@ LastResultsHash: state.LastResultsHash,
+		LastReceiptHash: state.LastReceiptHash,
+		LastBloom:       state.LastBloom,
 	}
 }
 
@@ -0,0 +1,137 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package consensus
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	cstypes "github.com/kardiachain/go-kardia/consensus/types"
+	cmn "github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestAddEvidence(t *testing.T) {
+	cs := &ConsensusState{
+		logger:     log.New(),
+		RoundState: cstypes.RoundState{Height: cmn.NewBigInt64(10)},
+	}
+	evidence := func(height int64, i int) types.MockGoodEvidence {
+		return types.NewMockGoodEvidence(cmn.NewBigInt64(height), 0, cmn.BytesToAddress([]byte{byte(i >> 8), byte(i)}))
+	}
+
+	// Known evidence is recorded once.
+	ev := evidence(10, 0)
+	cs.addEvidence(ev)
+	cs.addEvidence(ev)
+	if got := cs.Evidence(); len(got) != 1 {
+		t.Fatalf("got %d evidence, want 1", len(got))
+	}
+
+	// The evidence is kept for evidenceMaxAge heights.
+	cs.Height = cmn.NewBigInt64(10 + evidenceMaxAge)
+	cs.addEvidence(evidence(10+evidenceMaxAge, 1))
+	if got := cs.Evidence(); len(got) != 2 {
+		t.Fatalf("got %d evidence, want 2", len(got))
+	}
+	cs.Height = cmn.NewBigInt64(11 + evidenceMaxAge)
+	cs.addEvidence(evidence(11+evidenceMaxAge, 2))
+	got := cs.Evidence()
+	if len(got) != 2 || got[0].Height().Int64() != 10+evidenceMaxAge {
+		t.Fatalf("got evidence %v, want the one of height 10 dropped", got)
+	}
+
+	// Beyond maxEvidence, the oldest is dropped.
+	for i := 3; i < maxEvidence+10; i++ {
+		cs.addEvidence(evidence(11+evidenceMaxAge, i))
+	}
+	got = cs.Evidence()
+	if len(got) != maxEvidence {
+		t.Fatalf("got %d evidence, want %d", len(got), maxEvidence)
+	}
+	if first, last := got[0].Address(), got[len(got)-1].Address(); first != evidence(0, 10).Address() || last != evidence(0, maxEvidence+9).Address() {
+		t.Errorf("kept evidence from %x to %x", first, last)
+	}
+}
+
+// Tests that peers are only reported for the votes and block parts they must
+// have forged, the other failures being possible for honest peers.
+func TestHandleMsgReports(t *testing.T) {
+	const chainID = "test"
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	val := types.NewValidator(key.PublicKey, 100)
+	height := cmn.NewBigInt64(1)
+
+	cs := &ConsensusState{
+		logger: log.New(),
+		RoundState: cstypes.RoundState{
+			Height: height,
+			Round:  cmn.NewBigInt32(0),
+			Votes:  cstypes.NewHeightVoteSet(log.New(), chainID, height, types.NewValidatorSet([]*types.Validator{val}, 1, 100)),
+		},
+	}
+	var reports []p2p.Misbehavior
+	cs.reportPeer = func(peerID discover.NodeID, m p2p.Misbehavior, err error) {
+		reports = append(reports, m)
+	}
+	peer := discover.NodeID{1}
+	check := func(name string, msg ConsensusMessage, want ...p2p.Misbehavior) {
+		t.Helper()
+		reports = nil
+		cs.handleMsg(msgInfo{msg, peer})
+		if len(reports) != len(want) || (len(want) > 0 && reports[0] != want[0]) {
+			t.Errorf("%s: reports %v, want %v", name, reports, want)
+		}
+	}
+	vote := func(round int, addr cmn.Address, signer *types.PrivValidator) *VoteMessage {
+		v := &types.Vote{
+			ValidatorAddress: addr,
+			ValidatorIndex:   cmn.NewBigInt32(0),
+			Height:           height,
+			Round:            cmn.NewBigInt32(round),
+			Timestamp:        big.NewInt(0),
+			Type:             types.PrevoteType,
+		}
+		if err := signer.SignVote(chainID, v); err != nil {
+			t.Fatal(err)
+		}
+		return &VoteMessage{v}
+	}
+
+	check("forged signature", vote(0, val.Address, types.NewPrivValidator(other)), p2p.MisbehaviorInvalidVote)
+	check("unknown validator", vote(0, crypto.PubkeyToAddress(other.PublicKey), types.NewPrivValidator(other)))
+	check("forged vote of catchup round 1", vote(1, val.Address, types.NewPrivValidator(other)), p2p.MisbehaviorInvalidVote)
+	check("forged vote of catchup round 2", vote(2, val.Address, types.NewPrivValidator(other)), p2p.MisbehaviorInvalidVote)
+	check("unwanted round", vote(3, val.Address, types.NewPrivValidator(other)))
+
+	parts := types.NewPartSetFromData(make([]byte, 100), 10)
+	forged := types.NewPartSetFromData(bytes.Repeat([]byte{1}, 100), 10)
+	cs.ProposalBlockParts = types.NewPartSetFromHeader(parts.Header())
+	check("invalid proof", &BlockPartMessage{height, cs.Round, forged.GetPart(0)}, p2p.MisbehaviorInvalidBlockPart)
+	check("valid part", &BlockPartMessage{height, cs.Round, parts.GetPart(0)})
+	part := *parts.GetPart(1)
+	part.Index = cmn.NewBigInt32(10)
+	check("unexpected index", &BlockPartMessage{height, cs.Round, &part})
+}
@@ -19,8 +19,6 @@
 package consensus
 
 import (
-	"time"
-
 	"github.com/kardiachain/go-kardia/lib/log"
 )
 
@@ -40,23 +38,29 @@ type TimeoutTicker interface {
 	SetLogger(log.Logger)
 }
 
-// timeoutTicker wraps time.Timer,
+// timeoutTicker wraps a Timer,
 // scheduling timeouts only for greater height/round/step
 // than what it's already seen.
 // Timeouts are scheduled along the tickChan,
 // and fired on the tockChan.
 type timeoutTicker struct {
-	timer    *time.Timer
+	timer    Timer
 	tickChan chan timeoutInfo // for scheduling timeouts
 	tockChan chan timeoutInfo // for notifying about them
 
 	Logger log.Logger
 }
 
-// NewTimeoutTicker returns a new TimeoutTicker.
+// NewTimeoutTicker returns a new TimeoutTicker on the system clock.
 func NewTimeoutTicker() TimeoutTicker {
+	return NewTimeoutTickerWithClock(SystemClock)
+}
+
+// NewTimeoutTickerWithClock returns a new TimeoutTicker whose timeouts expire
+// by the given clock.
+func NewTimeoutTickerWithClock(clock Clock) TimeoutTicker {
 	tt := &timeoutTicker{
-		timer:    time.NewTimer(0),
+		timer:    clock.NewTimer(0),
 		tickChan: make(chan timeoutInfo, tickTockBufferSize),
 		tockChan: make(chan timeoutInfo, tickTockBufferSize),
 	}
@@ -100,7 +104,7 @@ func (t *timeoutTicker) stopTimer() {
 	// Stop() returns false if it was already fired or was stopped
 	if !t.timer.Stop() {
 		select {
-		case <-t.timer.C:
+		case <-t.timer.C():
 		default:
 			t.Logger.Debug("Timer already stopped")
 		}
@@ -139,7 +143,7 @@ func (t *timeoutTicker) timeoutRoutine() {
 			ti = newti
 			t.timer.Reset(ti.Duration)
 			t.Logger.Debug("Scheduled timeout", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
-		case <-t.timer.C:
+		case <-t.timer.C():
 			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
 			// go routine here guarantees timeoutRoutine doesn't block.
 			// Determinism comes from playback in the receiveRoutine.
@@ -22,10 +22,11 @@ import (
 	"errors"
 	"fmt"
 
+	cmn "github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/types"
 )
 
-func validateBlock(state LastestBlockState, block *types.Block) error {
+func validateBlock(config *types.ChainConfig, state LastestBlockState, block *types.Block) error {
 	// validate internal consistency
 	if err := block.ValidateBasic(); err != nil {
 		return err
@@ -91,6 +92,10 @@ func validateBlock(state LastestBlockState, block *types.Block) error {
 		)
 	}
 
+	if err := validateHeaderCommitments(config, state, block); err != nil {
+		return err
+	}
+
 	// TODO: Each check requires loading an old validator set.
 	// We should cap the amount of evidence per block
 	// to prevent potential proposer DoS.
@@ -103,3 +108,34 @@ func validateBlock(state LastestBlockState, block *types.Block) error {
 
 	return nil
 }
+
+// validateHeaderCommitments checks the receipts root, logs bloom and evidence hash
+// of the block header. Like AppHash, receipts and bloom commit to the txs of the
+// previous block since a block is only executed after it's committed.
+func validateHeaderCommitments(config *types.ChainConfig, state LastestBlockState, block *types.Block) error {
+	header := block.Header()
+	if !config.IsHeaderCommitments(header.Height) {
+		if header.HasCommitments() {
+			return fmt.Errorf("unexpected header commitments before activation at height %v", header.Height)
+		}
+		return nil
+	}
+
+	if !header.ReceiptHash.Equal(state.LastReceiptHash) {
+		return fmt.Errorf("Wrong Block.Header.ReceiptHash.  Expected %s, got %s",
+			state.LastReceiptHash.String(),
+			header.ReceiptHash.String(),
+		)
+	}
+	if header.Bloom != state.LastBloom {
+		return fmt.Errorf("Wrong Block.Header.Bloom.  Expected %x, got %x", state.LastBloom, header.Bloom)
+	}
+	// TODO(namdoh): Blocks don't carry evidence yet, so the hash must be the one of an empty list.
+	if evidenceHash := cmn.BytesToHash(types.EvidenceList{}.Hash()); !header.EvidenceHash.Equal(evidenceHash) {
+		return fmt.Errorf("Wrong Block.Header.EvidenceHash.  Expected %s, got %s",
+			evidenceHash.String(),
+			header.EvidenceHash.String(),
+		)
+	}
+	return nil
+}
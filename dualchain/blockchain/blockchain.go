@@ -29,6 +29,7 @@ import (
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/kardiachain/go-kardia/kai/events"
+	"github.com/kardiachain/go-kardia/kai/kaidb"
 	"github.com/kardiachain/go-kardia/kai/state"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/event"
@@ -336,6 +337,13 @@ func (dbc *DualBlockChain) SetHead(head uint64) error {
 	dbc.hc.SetHead(head, delFn)
 	currentHeader := dbc.hc.CurrentHeader()
 
+	// Drop the frozen blocks above the new head as well
+	if ancients, ok := dbc.db.DB().(kaidb.AncientStore); ok {
+		if err := ancients.TruncateAncients(head + 1); err != nil {
+			return err
+		}
+	}
+
 	// Clear out any stale content from the caches
 	dbc.blockCache.Purge()
 	dbc.futureBlocks.Purge()
@@ -84,6 +84,11 @@ func (dbo *DualBlockOperations) CreateProposalBlock(
 
 	header := dbo.newHeader(height, uint64(len(events)), lastState.LastBlockID, proposerAddr, lastState.LastValidators.Hash())
 	header.AppHash = lastState.AppHash
+	if dbo.blockchain.Config().IsHeaderCommitments(header.Height) {
+		header.ReceiptHash = lastState.LastReceiptHash
+		header.Bloom = lastState.LastBloom
+		header.EvidenceHash = common.BytesToHash(types.EvidenceList{}.Hash())
+	}
 
 	if height > 0 {
 		previousBlock := dbo.blockchain.GetBlockByHeight(uint64(height) - 1)
@@ -110,13 +115,14 @@ func (dbo *DualBlockOperations) CreateProposalBlock(
 
 // Executes and commits the new state from events in the given block.
 // This also validate the new state root against the block root.
-func (dbo *DualBlockOperations) CommitAndValidateBlockTxs(block *types.Block) (common.Hash, error) {
+// Dual blocks don't produce receipts, so the returned receipts are always empty.
+func (dbo *DualBlockOperations) CommitAndValidateBlockTxs(block *types.Block) (common.Hash, types.Receipts, error) {
 	root, err := dbo.commitDualEvents(block.DualEvents())
 	if err != nil {
-		return common.Hash{}, err
+		return common.Hash{}, nil, err
 	}
 	dbo.blockchain.DB().WriteAppHash(block.Height(), root)
-	return root, nil
+	return root, nil, nil
 }
 
 // Persists the given block, blockParts, and seenCommit to the underlying db.
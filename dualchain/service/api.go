@@ -23,6 +23,7 @@ import (
 	"math/big"
 
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
 )
 
@@ -41,7 +42,8 @@ type DualBlockJSON struct {
 	Validator      string             `json:"validator"`
 	AppHash        string             `json:"app_hash"`     // state root
 	ReceiptHash    string             `json:"receiptsRoot"` // receipt root
-	Bloom          int64              `json:"logsBloom"`
+	Bloom          string             `json:"logsBloom"`
+	EvidenceHash   string             `json:"evidence_hash"`
 	ValidatorsHash string             `json:"validators_hash"` // validators for the current block
 	ConsensusHash  string             `json:"consensus_hash"`
 }
@@ -76,6 +78,9 @@ func NewDualBlockJSON(block *types.Block) *DualBlockJSON {
 		GasLimit:       block.Header().GasLimit,
 		Validator:      block.Header().Validator.Hex(),
 		AppHash:        block.Header().AppHash.Hex(),
+		ReceiptHash:    block.Header().ReceiptHash.Hex(),
+		Bloom:          common.Encode(block.Header().Bloom.Bytes()),
+		EvidenceHash:   block.Header().EvidenceHash.Hex(),
 		ValidatorsHash: block.Header().ValidatorsHash.Hex(),
 		ConsensusHash:  block.Header().ConsensusHash.Hex(),
 	}
@@ -96,9 +101,16 @@ func (s *PublicDualAPI) GetBlockByHash(blockHash string) *DualBlockJSON {
 	return NewDualBlockJSON(block)
 }
 
-// GetBlockByNumber returns block by block number.
-func (s *PublicDualAPI) GetBlockByNumber(blockNumber uint64) *DualBlockJSON {
-	block := s.dualService.blockchain.GetBlockByHeight(blockNumber)
+// GetBlockByNumber returns block by block number. The latest and pending tags
+// select the head of the dual chain.
+func (s *PublicDualAPI) GetBlockByNumber(blockNumber rpc.BlockNumber) *DualBlockJSON {
+	var block *types.Block
+	switch blockNumber {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		block = s.dualService.blockchain.CurrentBlock()
+	default:
+		block = s.dualService.blockchain.GetBlockByHeight(uint64(blockNumber))
+	}
 	if block == nil {
 		return nil
 	}
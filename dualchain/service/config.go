@@ -19,6 +19,7 @@
 package service
 
 import (
+	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/dualchain/event_pool"
 	"github.com/kardiachain/go-kardia/kai/storage"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
@@ -48,4 +49,8 @@ type DualConfig struct {
 
 	// BaseAccount defines account which is used to execute internal smart contracts
 	BaseAccount *types.BaseAccount
+
+	// Consensus holds the consensus timing and empty-block policy. If nil, the
+	// default consensus config is used.
+	Consensus *configs.ConsensusConfig `toml:",omitempty"`
 }
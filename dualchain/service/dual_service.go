@@ -29,6 +29,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/mainchain/permissioned"
 	"github.com/kardiachain/go-kardia/node"
 	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
@@ -96,7 +97,13 @@ func newDualService(ctx *node.ServiceContext, config *DualConfig) (*DualService,
 		return nil, err
 	}
 
-	consensusConfig := configs.DefaultConsensusConfig()
+	consensusConfig := config.Consensus
+	if consensusConfig == nil {
+		consensusConfig = configs.DefaultConsensusConfig()
+	}
+	if timing := consensusConfig.Timing(); dualService.chainConfig.ConsensusTiming != nil && !dualService.chainConfig.ConsensusTiming.Equal(timing) {
+		logger.Warn("Consensus timing differs from the chain config", "local", timing, "chain", dualService.chainConfig.ConsensusTiming)
+	}
 
 	dualService.eventPool = event_pool.NewPool(logger, config.DualEventPool, dualService.blockchain)
 
@@ -114,6 +121,7 @@ func newDualService(ctx *node.ServiceContext, config *DualConfig) (*DualService,
 		logger.Error("Cannot get validator from indices", "indices", ctx.Config.DualChainConfig.ValidatorIndexes, "err", err)
 		return nil, err
 	}
+	validatorSet.ReplayProposer(int64(block.Height()))
 
 	blockID := types.BlockID{
 		Hash:        block.Hash(),
@@ -128,6 +136,7 @@ func newDualService(ctx *node.ServiceContext, config *DualConfig) (*DualService,
 		LastValidators:              validatorSet,
 		LastHeightValidatorsChanged: cmn.NewBigInt32(-1),
 		AppHash:                     dualService.blockchain.ReadAppHash(block.Height()),
+		LastReceiptHash:             types.EmptyRootHash,
 	}
 	dualService.dualBlockOperations = blockchain.NewDualBlockOperations(dualService.logger, dualService.blockchain, dualService.eventPool)
 	consensusState := consensus.NewConsensusState(
@@ -154,6 +163,14 @@ func newDualService(ctx *node.ServiceContext, config *DualConfig) (*DualService,
 		return nil, err
 	}
 	//namdoh@ dualService.protocolManager.acceptTxs = config.AcceptTxs
+	if config.IsPrivate {
+		// Peers of the dual protocol are checked against the permission contract of the dual chain.
+		nodeChecker, err := permissioned.NewNodeChecker(dualService.logger, dualService.blockchain)
+		if err != nil {
+			return nil, err
+		}
+		dualService.protocolManager.SetPeerChecker(nodeChecker)
+	}
 	dualService.csManager.SetProtocol(dualService.protocolManager)
 	return dualService, nil
 }
@@ -170,6 +187,7 @@ func NewDualService(ctx *node.ServiceContext) (node.Service, error) {
 		DualGenesis:   chainConfig.DualGenesis,
 		IsPrivate:     chainConfig.IsPrivate,
 		BaseAccount:   chainConfig.BaseAccount,
+		Consensus:     chainConfig.Consensus,
 	})
 
 	if err != nil {
@@ -0,0 +1,360 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package boltdb implements the key-value database layer based on bbolt.
+package boltdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+)
+
+const (
+	// iteratorChunk is the number of key/value pairs an iterator loads per read
+	// transaction. Iterators don't hold a read transaction between chunks, as an
+	// open one blocks writers that need to grow the memory map.
+	iteratorChunk = 1024
+
+	// openTimeout is the time to wait for the file lock of the database.
+	openTimeout = time.Second
+)
+
+var (
+	// bucket is the single bucket holding the whole keyspace.
+	bucket = []byte("kai")
+
+	// errNotFound is returned if a key is requested that is not found in the
+	// database.
+	errNotFound = errors.New("not found")
+)
+
+// Database is a persistent key-value store backed by a single bbolt file.
+// Apart from basic data storage functionality it also supports batch writes
+// and iterating over the keyspace in binary-alphabetical order.
+type Database struct {
+	fn string   // filename for reporting
+	db *bolt.DB // bbolt instance
+
+	log log.Logger // Contextual logger tracking the database path
+}
+
+// New returns a wrapped bbolt database stored in the given file.
+func New(file string) (*Database, error) {
+	logger := log.New("database", file)
+
+	db, err := bolt.Open(file, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	logger.Info("Opened bolt database")
+	return &Database{
+		fn:  file,
+		db:  db,
+		log: logger,
+	}, nil
+}
+
+// Close flushes any pending data to disk and closes all io accesses to the
+// underlying key-value store.
+func (db *Database) Close() error {
+	return db.db.Close()
+}
+
+// Has retrieves if a key is present in the key-value store.
+func (db *Database) Has(key []byte) (bool, error) {
+	var has bool
+	err := db.db.View(func(tx *bolt.Tx) error {
+		has = tx.Bucket(bucket).Get(key) != nil
+		return nil
+	})
+	return has, err
+}
+
+// Get retrieves the given key if it's present in the key-value store.
+func (db *Database) Get(key []byte) ([]byte, error) {
+	var dat []byte
+	err := db.db.View(func(tx *bolt.Tx) error {
+		// Values are only valid for the life of the transaction
+		if v := tx.Bucket(bucket).Get(key); v != nil {
+			dat = common.CopyBytes(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dat == nil {
+		return nil, errNotFound
+	}
+	return dat, nil
+}
+
+// Put inserts the given value into the key-value store.
+func (db *Database) Put(key []byte, value []byte) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, value)
+	})
+}
+
+// Delete removes the key from the key-value store.
+func (db *Database) Delete(key []byte) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete(key)
+	})
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (db *Database) NewBatch() kaidb.Batch {
+	return &batch{
+		db: db,
+	}
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the bolt database.
+func (db *Database) NewIterator() kaidb.Iterator {
+	return db.newIterator(nil, nil)
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (db *Database) NewIteratorWithStart(start []byte) kaidb.Iterator {
+	return db.newIterator(start, nil)
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (db *Database) NewIteratorWithPrefix(prefix []byte) kaidb.Iterator {
+	return db.newIterator(prefix, prefix)
+}
+
+func (db *Database) newIterator(start []byte, prefix []byte) *iterator {
+	return &iterator{
+		db:     db.db,
+		next:   common.CopyBytes(start),
+		prefix: common.CopyBytes(prefix),
+	}
+}
+
+// Stat returns a particular internal stat of the database. The only supported
+// property is "stats", the bbolt database statistics.
+func (db *Database) Stat(property string) (string, error) {
+	if property != "stats" {
+		return "", errors.New("unknown property")
+	}
+	stats := db.db.Stats()
+	return fmt.Sprintf("%+v", stats), nil
+}
+
+// Compact is not supported on a bolt database: bbolt reuses freed pages in
+// place and can only be compacted by copying it into a new file.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+// keyvalue is a key-value tuple tagged with a deletion field to allow creating
+// bolt write batches.
+type keyvalue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// batch is a write-only bolt batch that commits changes to its host database
+// in a single transaction when Write is called. A batch cannot be used
+// concurrently.
+type batch struct {
+	db     *Database
+	writes []keyvalue
+	size   int
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyvalue{common.CopyBytes(key), common.CopyBytes(value), false})
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyvalue{common.CopyBytes(key), nil, true})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	return b.db.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(bucket)
+		for _, keyvalue := range b.writes {
+			if keyvalue.delete {
+				if err := bkt.Delete(keyvalue.key); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := bkt.Put(keyvalue.key, keyvalue.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.writes = b.writes[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w kaidb.KeyValueWriter) error {
+	for _, keyvalue := range b.writes {
+		if keyvalue.delete {
+			if err := w.Delete(keyvalue.key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterator walks over the (potentially partial) keyspace of a bolt database.
+// It loads the pairs in chunks, each read in its own transaction, so writes
+// made while iterating may or may not be seen.
+type iterator struct {
+	db     *bolt.DB
+	next   []byte // key to resume from on the next chunk, nil to start at the first key
+	prefix []byte // only keys with this prefix are iterated
+
+	keys   [][]byte
+	values [][]byte
+	inited bool
+	done   bool // no more chunks to load
+	err    error
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	// Advance past the current pair, unless the iterator was not yet initialized
+	if it.inited && len(it.keys) > 0 {
+		it.keys = it.keys[1:]
+		it.values = it.values[1:]
+	}
+	it.inited = true
+	if len(it.keys) == 0 && !it.done {
+		it.load()
+	}
+	return len(it.keys) > 0
+}
+
+// load reads the next chunk of pairs.
+func (it *iterator) load() {
+	it.err = it.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+
+		var k, v []byte
+		if it.next == nil {
+			k, v = c.First()
+		} else {
+			k, v = c.Seek(it.next)
+		}
+		for ; k != nil; k, v = c.Next() {
+			if !bytes.HasPrefix(k, it.prefix) {
+				it.done = true
+				return nil
+			}
+			if len(it.keys) == iteratorChunk {
+				// Resume right after the last loaded key
+				it.next = append(common.CopyBytes(it.keys[len(it.keys)-1]), 0)
+				return nil
+			}
+			it.keys = append(it.keys, common.CopyBytes(k))
+			it.values = append(it.values, common.CopyBytes(v))
+		}
+		it.done = true
+		return nil
+	})
+	if it.err != nil {
+		it.keys, it.values = nil, nil
+	}
+}
+
+// Error returns any accumulated error. Exhausting all the key/value pairs
+// is not considered to be an error.
+func (it *iterator) Error() error {
+	return it.err
+}
+
+// Key returns the key of the current key/value pair, or nil if done. The caller
+// should not modify the contents of the returned slice, and its contents may
+// change on the next call to Next.
+func (it *iterator) Key() []byte {
+	if len(it.keys) > 0 {
+		return it.keys[0]
+	}
+	return nil
+}
+
+// Value returns the value of the current key/value pair, or nil if done. The
+// caller should not modify the contents of the returned slice, and its contents
+// may change on the next call to Next.
+func (it *iterator) Value() []byte {
+	if len(it.values) > 0 {
+		return it.values[0]
+	}
+	return nil
+}
+
+// Release releases associated resources. Release should always succeed and can
+// be called multiple times without causing error.
+func (it *iterator) Release() {
+	it.keys, it.values = nil, nil
+	it.done = true
+}
@@ -0,0 +1,108 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package boltdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/kai/kaidb/dbtest"
+)
+
+func newTestDatabase(t *testing.T) *Database {
+	dir, err := ioutil.TempDir("", "boltdb-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := New(filepath.Join(dir, "chain.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll(dir)
+	})
+	return db
+}
+
+func TestBoltDB(t *testing.T) {
+	t.Run("DatabaseSuite", func(t *testing.T) {
+		dbtest.TestDatabaseSuite(t, func() kaidb.KeyValueStore {
+			return newTestDatabase(t)
+		})
+	})
+}
+
+func TestEmptyValue(t *testing.T) {
+	db := newTestDatabase(t)
+	if err := db.Put([]byte("key"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := db.Has([]byte("key")); err != nil || !has {
+		t.Fatalf("Has = %v, %v, want true", has, err)
+	}
+	if v, err := db.Get([]byte("key")); err != nil || len(v) != 0 {
+		t.Fatalf("Get = %x, %v, want empty value", v, err)
+	}
+	if _, err := db.Get([]byte("missing")); err == nil {
+		t.Fatal("Get of a missing key succeeded")
+	}
+}
+
+func TestIteratorChunks(t *testing.T) {
+	db := newTestDatabase(t)
+	n := 2*iteratorChunk + 10
+	b := db.NewBatch()
+	for i := 0; i < n; i++ {
+		b.Put([]byte(fmt.Sprintf("a%05d", i)), []byte{byte(i)})
+	}
+	b.Put([]byte("b"), []byte{})
+	if err := b.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	it := db.NewIteratorWithPrefix([]byte("a"))
+	defer it.Release()
+	i := 0
+	for ; it.Next(); i++ {
+		if want := fmt.Sprintf("a%05d", i); string(it.Key()) != want {
+			t.Fatalf("key %d = %s, want %s", i, it.Key(), want)
+		}
+		if len(it.Value()) != 1 || it.Value()[0] != byte(i) {
+			t.Fatalf("value %d = %x", i, it.Value())
+		}
+		// Writes while iterating must not block on the iterator.
+		if i == iteratorChunk/2 {
+			if err := db.Put([]byte("c"), []byte{1}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if i != n {
+		t.Fatalf("iterated %d keys, want %d", i, n)
+	}
+}
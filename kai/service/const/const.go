@@ -21,13 +21,18 @@ package service
 // Constants to match up protocol versions and messages
 const (
 	kai1 = 1
+	kai2 = 2
 )
 
+// ConsensusTimingVersion is the first protocol version whose status message
+// carries the consensus timing, which peers of older versions can't decode.
+const ConsensusTimingVersion = kai2
+
 // ProtocolVersions are the supported versions of the protocol (first is primary).
-var ProtocolVersions = []uint{kai1}
+var ProtocolVersions = []uint{kai2, kai1}
 
 // ProtocolLengths are the number of implemented message corresponding to different protocol versions.
-var ProtocolLengths = []uint64{19}
+var ProtocolLengths = []uint64{19, 19}
 
 const ProtocolMaxMsgSize = 10 * 1024 * 1024 // Maximum cap on the size of a protocol message
 
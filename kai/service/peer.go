@@ -125,23 +125,29 @@ func (p *peer) Info() *PeerInfo {
 }
 
 // Handshake executes the kardia protocol handshake, negotiating version number,
-// network IDs, head and genesis blocks.
+// network IDs, head and genesis blocks. A peer whose consensus timing differs
+// from ours is accepted with a warning, the timing being only exchanged from
+// protocol version ConsensusTimingVersion.
 // Handshake can return error, or nil error but accept=false when peer is valid but gracefully rejected.
-func (p *peer) Handshake(network uint64, chainID uint64, height uint64, head common.Hash, genesis common.Hash) (accept bool, err error) {
+func (p *peer) Handshake(network uint64, chainID uint64, height uint64, head common.Hash, genesis common.Hash, timing *types.ConsensusTimingConfig) (accept bool, err error) {
 	p.logger.Trace("Handshake starts...")
 	// Send out own handshake in a new thread
 	errc := make(chan error, 2)
 	var status statusData // safe to read after two values have been received from errc
 
 	go func() {
-		errc <- p2p.Send(p.rw, serviceconst.StatusMsg, &statusData{
+		own := &statusData{
 			ProtocolVersion: uint32(p.version),
 			NetworkId:       network,
 			ChainID:         chainID,
 			Height:          height,
 			CurrentBlock:    head,
 			GenesisBlock:    genesis,
-		})
+		}
+		if timing != nil && p.version >= serviceconst.ConsensusTimingVersion {
+			own.ConsensusTiming = []*types.ConsensusTimingConfig{timing}
+		}
+		errc <- p2p.Send(p.rw, serviceconst.StatusMsg, own)
 	}()
 	go func() {
 		errc <- p.readStatus(network, chainID, &status, genesis)
@@ -165,6 +171,9 @@ func (p *peer) Handshake(network uint64, chainID uint64, height uint64, head com
 			return false, p2p.DiscReadTimeout
 		}
 	}
+	if remote := status.consensusTiming(); timing != nil && remote != nil && !timing.Equal(remote) {
+		p.logger.Warn("Peer runs with different consensus timing", "peer", p.Name(), "local", timing, "remote", remote)
+	}
 	return true, nil
 }
 
@@ -282,6 +291,18 @@ func (ps *peerSet) Len() int {
 	return len(ps.peers)
 }
 
+// Peers returns all the registered peers.
+func (ps *peerSet) Peers() []*peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		list = append(list, p)
+	}
+	return list
+}
+
 // Close disconnects all peers.
 // No new peers can be registered after Close has returned.
 func (ps *peerSet) Close() {
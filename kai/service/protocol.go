@@ -24,6 +24,7 @@ import (
 
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/types"
 )
 
 type errCode int
@@ -67,6 +68,19 @@ type statusData struct {
 	Height          uint64
 	CurrentBlock    common.Hash
 	GenesisBlock    common.Hash
+
+	// ConsensusTiming holds the timing of the peer's chain config, if known. It
+	// is a tail list so status messages of older peers still decode, and is
+	// only sent from protocol version ConsensusTimingVersion.
+	ConsensusTiming []*types.ConsensusTimingConfig `rlp:"tail"`
+}
+
+// consensusTiming returns the consensus timing of the status, nil if unknown.
+func (s *statusData) consensusTiming() *types.ConsensusTimingConfig {
+	if len(s.ConsensusTiming) == 0 {
+		return nil
+	}
+	return s.ConsensusTiming[0]
 }
 
 // hashOrNumber is a combined field for specifying an origin block.
@@ -44,6 +44,8 @@ const (
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
 	csChanSize = 4096 // Consensus channel size.
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
 )
 
 // errIncompatibleConfig is returned if the requested protocols and configs are
@@ -54,6 +56,15 @@ func errResp(code errCode, format string, v ...interface{}) error {
 	return fmt.Errorf("%v - %v", code, fmt.Sprintf(format, v...))
 }
 
+// PeerChecker decides which nodes may join a permissioned network.
+type PeerChecker interface {
+	// CheckNode returns a non-nil error if the node may not be a peer.
+	CheckNode(id discover.NodeID) error
+	// Changed reports whether the set of permitted nodes may have changed since
+	// the previous call.
+	Changed() (bool, error)
+}
+
 type receivedTxs struct {
 	peer *peer
 	txs  types.Transactions
@@ -97,6 +108,11 @@ type ProtocolManager struct {
 	//csCh    chan consensus.NewCsEvent
 	csSub event.Subscription
 
+	// Permissioned network stuff
+	peerChecker  PeerChecker
+	chainHeadCh  chan events.ChainHeadEvent
+	chainHeadSub event.Subscription
+
 	// wait group is used for graceful shutdowns during downloading
 	// and processing
 	wg sync.WaitGroup
@@ -217,6 +233,13 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 		go pm.txBroadcastLoop()
 	}
 	//namdoh@ go pm.csBroadcastLoop()
+	if pm.peerChecker != nil {
+		// re-evaluate peers as the permission contract changes
+		pm.chainHeadCh = make(chan events.ChainHeadEvent, chainHeadChanSize)
+		pm.chainHeadSub = pm.blockchain.SubscribeChainHeadEvent(pm.chainHeadCh)
+
+		go pm.permissionLoop()
+	}
 	go syncNetwork(pm)
 	go pm.txsyncLoop()
 }
@@ -227,6 +250,9 @@ func (pm *ProtocolManager) Stop() {
 	if pm.txpool != nil {
 		pm.txsSub.Unsubscribe() // quits txBroadcastLoop
 	}
+	if pm.peerChecker != nil {
+		pm.chainHeadSub.Unsubscribe() // quits permissionLoop
+	}
 
 	// Quit the sync loop.
 	// After this send has completed, no new peers will be accepted.
@@ -256,6 +282,13 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	if pm.peers.Len() >= pm.maxPeers && !p.Peer.Info().Network.Trusted {
 		return p2p.DiscTooManyPeers
 	}
+	// Trusted peers are admitted in a permissioned network too
+	if pm.peerChecker != nil && !p.Peer.Info().Network.Trusted {
+		if err := pm.peerChecker.CheckNode(p.Peer.ID()); err != nil {
+			pm.logger.Info("Rejecting unpermitted peer", "peer", p.Name(), "err", err)
+			return p2p.DiscUselessPeer
+		}
+	}
 	pm.logger.Debug("Kardia peer connected", "name", p.Name())
 
 	var (
@@ -265,7 +298,7 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	)
 
 	// Execute the Kardia handshake
-	accepted, err := p.Handshake(pm.networkID, pm.chainID, height, hash, genesis.Hash())
+	accepted, err := p.Handshake(pm.networkID, pm.chainID, height, hash, genesis.Hash(), pm.chainconfig.ConsensusTiming)
 	if err != nil {
 		return err
 	}
@@ -328,6 +361,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		// Transactions can be processed, parse all of them and deliver to the pool
 		var txs []*types.Transaction
 		if err := msg.Decode(&txs); err != nil {
+			p.Report(p2p.MisbehaviorInvalidMsg, err)
 			return errResp(ErrDecode, "msg %v: %v", msg, err)
 		}
 
@@ -402,7 +436,7 @@ func (pm *ProtocolManager) txBroadcastLoop() {
 
 		case receivedTxs := <-pm.receivedTxsCh:
 			if len(receivedTxs.txs) > 0 {
-				pm.txpool.AddRemotes(receivedTxs.txs)
+				pm.reportInvalidTxs(receivedTxs.peer, pm.txpool.AddRemotes(receivedTxs.txs))
 			}
 		// Err() channel will be closed when unsubscribing.
 		case <-pm.txsSub.Err():
@@ -411,6 +445,63 @@ func (pm *ProtocolManager) txBroadcastLoop() {
 	}
 }
 
+// reportInvalidTxs charges the peer for every transaction it relayed that no
+// honest node would have accepted. Transactions that are merely stale or
+// underpriced are not punished, since they depend on the local pool state.
+func (pm *ProtocolManager) reportInvalidTxs(p *peer, errs []error) {
+	for _, err := range errs {
+		switch err {
+		case tx_pool.ErrInvalidSender, tx_pool.ErrNegativeValue, tx_pool.ErrOversizedData,
+			tx_pool.ErrIntrinsicGas, tx_pool.ErrGasLimit:
+			p.Report(p2p.MisbehaviorInvalidTx, err)
+		}
+	}
+}
+
+// ReportPeer charges the connected peer with the given ID with a misbehavior.
+func (pm *ProtocolManager) ReportPeer(id discover.NodeID, m p2p.Misbehavior, err error) {
+	if p := pm.peers.Peer(fmt.Sprintf("%x", id.Bytes()[:8])); p != nil {
+		p.Report(m, err)
+	}
+}
+
+// SetPeerChecker makes the network permissioned, admitting only the peers passing
+// the given checker. It must be called before Start.
+func (pm *ProtocolManager) SetPeerChecker(checker PeerChecker) {
+	pm.peerChecker = checker
+}
+
+// permissionLoop disconnects the peers which are no longer permitted whenever the
+// permitted nodes change at a new head.
+func (pm *ProtocolManager) permissionLoop() {
+	for {
+		select {
+		case <-pm.chainHeadCh:
+			changed, err := pm.peerChecker.Changed()
+			if err != nil {
+				pm.logger.Error("Failed to check permitted nodes", "err", err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			for _, p := range pm.peers.Peers() {
+				if p.Peer.Info().Network.Trusted {
+					continue
+				}
+				if err := pm.peerChecker.CheckNode(p.Peer.ID()); err != nil {
+					pm.logger.Info("Dropping unpermitted peer", "peer", p.Name(), "err", err)
+					pm.removePeer(p.id)
+				}
+			}
+
+		// Err() channel will be closed when unsubscribing.
+		case <-pm.chainHeadSub.Err():
+			return
+		}
+	}
+}
+
 // A loop for broadcasting consensus events.
 func (pm *ProtocolManager) Broadcast(msg interface{}, msgType uint64) {
 	for _, p := range pm.peers.peers {
@@ -0,0 +1,133 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"sync"
+	"testing"
+
+	serviceconst "github.com/kardiachain/go-kardia/kai/service/const"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// legacyStatusData is the status message of peers predating the consensus timing.
+type legacyStatusData struct {
+	ProtocolVersion uint32
+	NetworkId       uint64
+	ChainID         uint64
+	Height          uint64
+	CurrentBlock    common.Hash
+	GenesisBlock    common.Hash
+}
+
+func TestStatusDataConsensusTiming(t *testing.T) {
+	legacy, err := rlp.EncodeToBytes(&legacyStatusData{ProtocolVersion: 1, NetworkId: 100, Height: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status statusData
+	if err := rlp.DecodeBytes(legacy, &status); err != nil {
+		t.Fatalf("status of an older peer doesn't decode: %v", err)
+	}
+	if status.Height != 7 || status.consensusTiming() != nil {
+		t.Errorf("status of an older peer decoded to %+v", status)
+	}
+
+	timing := &types.ConsensusTimingConfig{TimeoutPropose: 3000, TimeoutCommit: 1000, CreateEmptyBlocks: true}
+	enc, err := rlp.EncodeToBytes(&statusData{ProtocolVersion: 2, Height: 7, ConsensusTiming: []*types.ConsensusTimingConfig{timing}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status = statusData{}
+	if err := rlp.DecodeBytes(enc, &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.consensusTiming().Equal(timing) {
+		t.Errorf("consensus timing = %v, want %v", status.consensusTiming(), timing)
+	}
+	// Older peers can't decode it, hence the timing is only sent from
+	// ConsensusTimingVersion.
+	if err := rlp.DecodeBytes(enc, new(legacyStatusData)); err == nil {
+		t.Errorf("older peers decoded a status carrying the consensus timing")
+	}
+}
+
+func TestHandshakeConsensusTiming(t *testing.T) {
+	timing := &types.ConsensusTimingConfig{TimeoutPropose: 3000, TimeoutCommit: 1000}
+	other := &types.ConsensusTimingConfig{TimeoutPropose: 3000, TimeoutCommit: 500}
+	tests := []struct {
+		version     int
+		remote      *types.ConsensusTimingConfig
+		wantWarning bool
+	}{
+		{serviceconst.ConsensusTimingVersion, timing, false},
+		{serviceconst.ConsensusTimingVersion, other, true},
+		{serviceconst.ConsensusTimingVersion, nil, false},
+		{serviceconst.ConsensusTimingVersion - 1, other, false},
+	}
+	for i, tt := range tests {
+		var (
+			mu       sync.Mutex
+			warnings int
+		)
+		logger := log.New()
+		logger.SetHandler(log.FuncHandler(func(r *log.Record) error {
+			if r.Lvl == log.LvlWarn && r.Msg == "Peer runs with different consensus timing" {
+				mu.Lock()
+				warnings++
+				mu.Unlock()
+			}
+			return nil
+		}))
+		rw1, rw2 := p2p.MsgPipe()
+		p1 := &peer{logger: logger, Peer: p2p.NewPeer(discover.NodeID{1}, "p1", nil), rw: rw1, version: tt.version}
+		p2 := &peer{logger: logger, Peer: p2p.NewPeer(discover.NodeID{2}, "p2", nil), rw: rw2, version: tt.version}
+		genesis := common.Hash{1}
+
+		errc := make(chan error, 2)
+		handshake := func(p *peer, timing *types.ConsensusTimingConfig) {
+			accept, err := p.Handshake(100, 1, 0, common.Hash{}, genesis, timing)
+			if err == nil && !accept {
+				t.Errorf("test %d: peer %s rejected", i, p.Name())
+			}
+			errc <- err
+		}
+		go handshake(p1, timing)
+		go handshake(p2, tt.remote)
+		for j := 0; j < 2; j++ {
+			if err := <-errc; err != nil {
+				t.Fatalf("test %d: handshake failed: %v", i, err)
+			}
+		}
+		rw1.Close()
+
+		want := 0
+		if tt.wantWarning {
+			want = 2 // one on each side
+		}
+		if warnings != want {
+			t.Errorf("test %d: got %d warnings, want %d", i, warnings, want)
+		}
+	}
+}
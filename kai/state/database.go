@@ -72,7 +72,7 @@ type Trie interface {
 	Hash() common.Hash
 	NodeIterator(startKey []byte) trie.NodeIterator
 	GetKey([]byte) []byte // TODO(fjl): remove this when SecureTrie is removed
-	//Prove(key []byte, fromLevel uint, proofDb kaidb.Putter) error
+	Prove(key []byte, fromLevel uint, proofDb kaidb.KeyValueWriter) error
 }
 
 // NewDatabase creates a backing store for state. The returned database is safe for
@@ -539,6 +539,45 @@ func (sdb *StateDB) GetState(addr common.Address, bhash common.Hash) common.Hash
 	return common.Hash{}
 }
 
+// GetStorageRoot returns the root hash of the storage trie of the given account.
+func (sdb *StateDB) GetStorageRoot(addr common.Address) common.Hash {
+	stateObject := sdb.getStateObject(addr)
+	if stateObject == nil {
+		return common.Hash{}
+	}
+	return stateObject.data.Root
+}
+
+// StorageTrie returns the storage trie of the given account, nil if the account
+// does not exist. The trie is a copy, its changes don't affect the state.
+func (sdb *StateDB) StorageTrie(addr common.Address) Trie {
+	stateObject := sdb.getStateObject(addr)
+	if stateObject == nil {
+		return nil
+	}
+	cpy := stateObject.deepCopy(sdb)
+	return cpy.updateTrie(sdb.db)
+}
+
+// GetProof returns the Merkle proof of the given account in the state trie.
+func (sdb *StateDB) GetProof(addr common.Address) ([][]byte, error) {
+	var proof trie.ProofList
+	err := sdb.trie.Prove(addr[:], 0, &proof)
+	return [][]byte(proof), err
+}
+
+// GetStorageProof returns the Merkle proof of the given storage slot in the
+// storage trie of the given account.
+func (sdb *StateDB) GetStorageProof(addr common.Address, key common.Hash) ([][]byte, error) {
+	var proof trie.ProofList
+	stateObject := sdb.getStateObject(addr)
+	if stateObject == nil {
+		return proof, fmt.Errorf("storage trie for requested address %v does not exist", addr.Hex())
+	}
+	err := stateObject.getTrie(sdb.db).Prove(key[:], 0, &proof)
+	return [][]byte(proof), err
+}
+
 // Suicide marks the given account as suicided.
 // This clears the account balance.
 //
@@ -177,3 +177,30 @@ func TestCopyOfCopy(t *testing.T) {
 		t.Fatalf("2nd copy fail, expected 42, got %v", got)
 	}
 }
+
+// Tests that the storage trie of an account holds its uncommitted slots, and that
+// changing it doesn't affect the state.
+func TestStorageTrie(t *testing.T) {
+	sdb, _ := New(log.New(), common.Hash{}, NewDatabase(memorydb.New()))
+	addr := common.HexToAddress("aaaa")
+	if st := sdb.StorageTrie(addr); st != nil {
+		t.Fatalf("storage trie of a missing account: got %v, want nil", st)
+	}
+	key, value := common.HexToHash("01"), common.HexToHash("0102")
+	sdb.SetState(addr, key, value)
+
+	st := sdb.StorageTrie(addr)
+	if st == nil {
+		t.Fatal("missing storage trie")
+	}
+	enc, err := st.TryGet(key.Bytes())
+	if err != nil || len(enc) == 0 {
+		t.Fatalf("missing slot in storage trie: %v", err)
+	}
+	if err := st.TryUpdate(key.Bytes(), []byte{0x03}); err != nil {
+		t.Fatal(err)
+	}
+	if got := sdb.GetState(addr, key); got != value {
+		t.Fatalf("state changed through the storage trie: got %x, want %x", got, value)
+	}
+}
@@ -19,6 +19,11 @@
 package storage
 
 import (
+	"os"
+	"path/filepath"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/kai/kaidb/boltdb"
 	"github.com/kardiachain/go-kardia/kai/kaidb/leveldb"
 	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
 	"github.com/kardiachain/go-kardia/kai/storage/mongodb"
@@ -43,6 +48,21 @@ type LevelDbInfo struct {
 	ChainData string
 	DbCaches  int
 	DbHandles int
+
+	// Ancient is the directory of the freezer holding the canonical blocks older
+	// than FreezeThreshold, if not empty.
+	Ancient         string
+	FreezeThreshold uint64
+}
+
+// BoltDbInfo implements DbInfo to start chain using bbolt
+type BoltDbInfo struct {
+	ChainData string // directory holding the database file
+
+	// Ancient is the directory of the freezer holding the canonical blocks older
+	// than FreezeThreshold, if not empty.
+	Ancient         string
+	FreezeThreshold uint64
 }
 
 func NewMongoDbInfo(uri, databaseName string, drop bool) *MongoDbInfo {
@@ -79,6 +99,45 @@ func (info *LevelDbInfo) Start() (types.StoreDB, error) {
 	if err != nil {
 		return nil, err
 	}
+	if info.Ancient != "" {
+		return newStoreDBWithFreezer(db, info.Ancient, info.FreezeThreshold)
+	}
+
+	return kvstore.NewStoreDB(db), nil
+}
+
+// newStoreDBWithFreezer wraps db with the freezer held in the ancient directory,
+// closing db if the freezer cannot be opened.
+func newStoreDBWithFreezer(db kaidb.KeyValueStore, ancient string, threshold uint64) (types.StoreDB, error) {
+	storeDB, err := kvstore.NewStoreDBWithFreezer(db, ancient, threshold)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return storeDB, nil
+}
+
+func NewBoltDbInfo(chainData string) *BoltDbInfo {
+	return &BoltDbInfo{
+		ChainData: chainData,
+	}
+}
+
+func (info *BoltDbInfo) Name() string {
+	return "bolt"
+}
+
+func (info *BoltDbInfo) Start() (types.StoreDB, error) {
+	if err := os.MkdirAll(info.ChainData, 0700); err != nil {
+		return nil, err
+	}
+	db, err := boltdb.New(filepath.Join(info.ChainData, "chain.db"))
+	if err != nil {
+		return nil, err
+	}
+	if info.Ancient != "" {
+		return newStoreDBWithFreezer(db, info.Ancient, info.FreezeThreshold)
+	}
 
 	return kvstore.NewStoreDB(db), nil
 }
@@ -0,0 +1,69 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/kai/storage/storetest"
+)
+
+func TestStoreDBs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storedb-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	newDir := func(t *testing.T) string {
+		file, err := ioutil.TempDir(dir, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	t.Run("LevelDB", func(t *testing.T) {
+		storetest.TestStoreDBSuite(t, func() storage.DbInfo {
+			return storage.NewLevelDbInfo(newDir(t), 0, 0)
+		})
+	})
+	t.Run("LevelDBWithFreezer", func(t *testing.T) {
+		storetest.TestStoreDBSuite(t, func() storage.DbInfo {
+			info := storage.NewLevelDbInfo(newDir(t), 0, 0)
+			info.Ancient = filepath.Join(info.ChainData, "ancient")
+			return info
+		})
+	})
+	t.Run("Bolt", func(t *testing.T) {
+		storetest.TestStoreDBSuite(t, func() storage.DbInfo {
+			return storage.NewBoltDbInfo(newDir(t))
+		})
+	})
+	t.Run("BoltWithFreezer", func(t *testing.T) {
+		storetest.TestStoreDBSuite(t, func() storage.DbInfo {
+			info := storage.NewBoltDbInfo(newDir(t))
+			info.Ancient = filepath.Join(info.ChainData, "ancient")
+			return info
+		})
+	})
+}
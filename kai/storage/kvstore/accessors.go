@@ -45,9 +45,32 @@ type KardiaEvents struct {
 	MasterSmc string
 }
 
+// readAncient retrieves the given kind of data of a block height from the freezer
+// backing db, if any.
+func readAncient(db kaidb.Reader, kind string, height uint64) []byte {
+	ancients, ok := db.(kaidb.AncientReader)
+	if !ok {
+		return nil
+	}
+	data, _ := ancients.Ancient(kind, height)
+	return data
+}
+
+// readAncientOf retrieves the given kind of data of a block from the freezer
+// backing db, if the frozen block at its height has the given hash.
+func readAncientOf(db kaidb.Reader, kind string, hash common.Hash, height uint64) []byte {
+	if data := readAncient(db, freezerHashTable, height); common.BytesToHash(data) != hash {
+		return nil
+	}
+	return readAncient(db, kind, height)
+}
+
 // CommonReadCanonicalHash retrieves the hash assigned to a canonical block height.
 func CommonReadCanonicalHash(db kaidb.Reader, height uint64) common.Hash {
 	data, _ := db.Get(headerHashKey(height))
+	if len(data) == 0 {
+		data = readAncient(db, freezerHashTable, height)
+	}
 	if data == nil || len(data) == 0 {
 		return common.Hash{}
 	}
@@ -204,7 +227,11 @@ func CommonReadBodyRLP(db kaidb.Reader, hash common.Hash, height uint64) rlp.Raw
 
 // CommonReadBody retrieves the block body corresponding to the hash.
 func CommonReadBody(db kaidb.Reader, hash common.Hash, height uint64) *types.Body {
-	return ReadBlock(db, hash, height).Body()
+	block := ReadBlock(db, hash, height)
+	if block == nil {
+		return nil
+	}
+	return block.Body()
 }
 
 // CommonReadHeadBlockHash retrieves the hash of the current canonical head block.
@@ -238,6 +265,9 @@ func CommonReadHeadHeaderHash(db kaidb.Reader) common.Hash {
 // CommonReadCommitRLP retrieves the commit in RLP encoding.
 func CommonReadCommitRLP(db kaidb.Reader, height uint64) rlp.RawValue {
 	data, _ := db.Get(commitKey(height))
+	if len(data) == 0 {
+		data = readAncient(db, freezerCommitTable, height)
+	}
 	return data
 }
 
@@ -282,6 +312,9 @@ func CommonDeleteCanonicalHash(db kaidb.KeyValueWriter, number uint64) {
 func CommonReadReceipts(db kaidb.Reader, hash common.Hash, number uint64) types.Receipts {
 	// Retrieve the flattened receipt slice
 	data, _ := db.Get(blockReceiptsKey(number, hash))
+	if len(data) == 0 {
+		data = readAncientOf(db, freezerReceiptTable, hash, number)
+	}
 	if data == nil || len(data) == 0 {
 		return nil
 	}
@@ -332,6 +365,42 @@ func CommonWriteTxLookupEntries(db kaidb.Writer, block *types.Block) {
 	}
 }
 
+// CommonWriteAddressTxs indexes the transaction at the given position of a block
+// under every given address, enabling address based transaction lookups.
+func CommonWriteAddressTxs(db kaidb.Writer, height uint64, index uint32, hash common.Hash, addresses []common.Address) {
+	for _, address := range addresses {
+		if err := db.Put(addressTxKey(address, height, index), hash.Bytes()); err != nil {
+			log.Crit("Failed to store address transaction index", "err", err)
+		}
+	}
+}
+
+// CommonReadAddressTxs retrieves the hashes of the transactions indexed under the given
+// address between the given heights inclusive, in the order of the chain, skipping
+// the first skip ones and returning at most limit ones.
+func CommonReadAddressTxs(db kaidb.Iteratee, address common.Address, from, to uint64, skip, limit int) []common.Hash {
+	prefix := addressTxPrefixKey(address)
+	it := db.NewIteratorWithStart(addressTxKey(address, from, 0))
+	defer it.Release()
+
+	var hashes []common.Hash
+	for len(hashes) < limit && it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) || len(key) != len(prefix)+12 {
+			break
+		}
+		if binary.BigEndian.Uint64(key[len(prefix):]) > to {
+			break
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		hashes = append(hashes, common.BytesToHash(it.Value()))
+	}
+	return hashes
+}
+
 // CommonDeleteTxLookupEntry removes all transaction data associated with a hash.
 func CommonDeleteTxLookupEntry(db kaidb.KeyValueWriter, hash common.Hash) {
 	db.Delete(txLookupKey(hash))
@@ -536,6 +605,9 @@ func CommonCheckTxHash(db kaidb.Reader, hash *common.Hash) bool {
 func ReadBlockMeta(db kaidb.Reader, hash common.Hash, height uint64) *types.BlockMeta {
 	var blockMeta = new(types.BlockMeta)
 	metaBytes, _ := db.Get(blockMetaKey(hash, height))
+	if len(metaBytes) == 0 {
+		metaBytes = readAncientOf(db, freezerHeaderTable, hash, height)
+	}
 
 	if len(metaBytes) == 0 {
 		return nil
@@ -586,6 +658,9 @@ func ReadBlock(db kaidb.Reader, hash common.Hash, height uint64) *types.Block {
 // CommonReadHeader retrieves the block header corresponding to the hash.
 func CommonReadHeader(db kaidb.Reader, hash common.Hash, height uint64) *types.Header {
 	blockMeta := ReadBlockMeta(db, hash, height)
+	if blockMeta == nil {
+		return nil
+	}
 	return blockMeta.Header
 }
 
@@ -599,6 +674,9 @@ func CommonReadHeaderRLP(db kaidb.Reader, hash common.Hash, height uint64) rlp.R
 func ReadBlockPart(db kaidb.Reader, hash common.Hash, height uint64, index int) *types.Part {
 	part := new(types.Part)
 	partBytes, _ := db.Get(blockPartKey(height, index))
+	if len(partBytes) == 0 {
+		partBytes = readAncientPart(db, hash, height, index)
+	}
 
 	if len(partBytes) == 0 {
 		return nil
@@ -610,6 +688,23 @@ func ReadBlockPart(db kaidb.Reader, hash common.Hash, height uint64, index int)
 	return part
 }
 
+// readAncientPart retrieves a block part in RLP encoding from the list of parts
+// frozen for the block.
+func readAncientPart(db kaidb.Reader, hash common.Hash, height uint64, index int) rlp.RawValue {
+	data := readAncientOf(db, freezerBodiesTable, hash, height)
+	if len(data) == 0 {
+		return nil
+	}
+	var parts []rlp.RawValue
+	if err := rlp.DecodeBytes(data, &parts); err != nil {
+		panic(fmt.Errorf("Decode frozen block parts error: %s", err))
+	}
+	if index < 0 || index >= len(parts) {
+		return nil
+	}
+	return parts[index]
+}
+
 // WriteBlock write block to database
 func WriteBlock(db kaidb.Database, block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 	height := block.Height()
@@ -0,0 +1,439 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// The tables of the freezer, each holding one item per frozen block height.
+const (
+	// freezerHashTable indicates the name of the freezer canonical hash table.
+	freezerHashTable = "hashes"
+
+	// freezerHeaderTable indicates the name of the freezer block meta table.
+	freezerHeaderTable = "headers"
+
+	// freezerBodiesTable indicates the name of the freezer block parts table.
+	freezerBodiesTable = "bodies"
+
+	// freezerCommitTable indicates the name of the freezer commit table.
+	freezerCommitTable = "commits"
+
+	// freezerReceiptTable indicates the name of the freezer receipts table.
+	freezerReceiptTable = "receipts"
+)
+
+// freezerNoSnappy configures whether compression is disabled for the tables.
+// Hashes are random and would not benefit from it.
+var freezerNoSnappy = map[string]bool{
+	freezerHashTable:    true,
+	freezerHeaderTable:  false,
+	freezerBodiesTable:  false,
+	freezerCommitTable:  false,
+	freezerReceiptTable: false,
+}
+
+const (
+	// freezerRecheckInterval is the frequency to check the key-value database for
+	// chain progression that might permit new blocks to be frozen into immutable
+	// storage.
+	freezerRecheckInterval = time.Minute
+
+	// freezerBatchLimit is the maximum number of blocks to freeze in one batch
+	// before doing an fsync and deleting it from the key-value store.
+	freezerBatchLimit = 30000
+
+	// DefaultFreezeThreshold is the number of recent blocks kept in the key-value
+	// store, the older ones being moved into the freezer.
+	DefaultFreezeThreshold = 90000
+)
+
+var (
+	// errUnknownTable is returned if the user attempts to read from a table that is
+	// not tracked by the freezer.
+	errUnknownTable = errors.New("unknown table")
+
+	// emptyReceipts is the RLP encoding of an empty list, frozen for the blocks
+	// without any stored receipts.
+	emptyReceipts = []byte{0xc0}
+)
+
+// freezer is an append-only database to store immutable chain data into flat
+// files. The append only nature ensures that disk writes are minimized, and the
+// files are kept out of the key-value store so they don't bloat its compactions.
+type freezer struct {
+	// WARNING: The `frozen` field is accessed atomically. On 32 bit platforms, only
+	// 64-bit aligned fields can be atomic. The struct is guaranteed to be so aligned,
+	// so take advantage of that (https://golang.org/pkg/sync/atomic/#pkg-note-BUG).
+	frozen uint64 // Number of blocks already frozen
+
+	threshold uint64                   // Number of recent blocks not to freeze
+	tables    map[string]*freezerTable // Data tables for storing everything
+	lock      sync.Mutex               // Lock serializing the appends and truncations
+
+	quit      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// newFreezer creates a chain freezer that moves ancient chain data into
+// append-only flat file containers. A zero threshold uses DefaultFreezeThreshold.
+func newFreezer(datadir string, threshold uint64) (*freezer, error) {
+	if threshold == 0 {
+		threshold = DefaultFreezeThreshold
+	}
+	if err := os.MkdirAll(datadir, 0755); err != nil {
+		return nil, err
+	}
+	freezer := &freezer{
+		threshold: threshold,
+		tables:    make(map[string]*freezerTable),
+		quit:      make(chan struct{}),
+	}
+	for name, disableSnappy := range freezerNoSnappy {
+		table, err := newFreezerTable(datadir, name, disableSnappy)
+		if err != nil {
+			for _, table := range freezer.tables {
+				table.Close()
+			}
+			return nil, err
+		}
+		freezer.tables[name] = table
+	}
+	if err := freezer.repair(); err != nil {
+		freezer.Close()
+		return nil, err
+	}
+	log.Info("Opened ancient database", "database", datadir, "frozen", freezer.frozen)
+	return freezer, nil
+}
+
+// Close terminates the chain freezer, closing all the data files.
+func (f *freezer) Close() error {
+	var errs []error
+	f.closeOnce.Do(func() {
+		close(f.quit)
+		f.wg.Wait()
+		for _, table := range f.tables {
+			if err := table.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	if errs != nil {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// HasAncient returns an indicator whether the specified ancient data exists
+// in the freezer.
+func (f *freezer) HasAncient(kind string, number uint64) (bool, error) {
+	if table := f.tables[kind]; table != nil {
+		return number < table.Items(), nil
+	}
+	return false, nil
+}
+
+// Ancient retrieves an ancient binary blob from the append-only immutable files.
+func (f *freezer) Ancient(kind string, number uint64) ([]byte, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.Retrieve(number)
+	}
+	return nil, errUnknownTable
+}
+
+// Ancients returns the length of the frozen items.
+func (f *freezer) Ancients() (uint64, error) {
+	return atomic.LoadUint64(&f.frozen), nil
+}
+
+// AncientSize returns the ancient size of the specified category.
+func (f *freezer) AncientSize(kind string) (uint64, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.Size()
+	}
+	return 0, errUnknownTable
+}
+
+// AppendAncient injects all binary blobs belonging to a block at the end of the
+// append-only immutable table files.
+//
+// Notably, this function is lock free but kind of thread-safe. All out-of-order
+// injection will be rejected. But if two injections with same number happen at
+// the same time, we can get into the trouble.
+func (f *freezer) AppendAncient(number uint64, hash, meta, parts, commit, receipts []byte) (err error) {
+	// Rollback all inserted data if any insertion below failed to ensure
+	// the tables won't go out of sync.
+	defer func() {
+		if err != nil {
+			rerr := f.repair()
+			if rerr != nil {
+				log.Crit("Failed to repair freezer", "err", rerr)
+			}
+			log.Info("Append ancient failed", "height", number, "err", err)
+		}
+	}()
+	blobs := map[string][]byte{
+		freezerHashTable:    hash,
+		freezerHeaderTable:  meta,
+		freezerBodiesTable:  parts,
+		freezerCommitTable:  commit,
+		freezerReceiptTable: receipts,
+	}
+	for _, name := range []string{freezerHashTable, freezerHeaderTable, freezerBodiesTable, freezerCommitTable, freezerReceiptTable} {
+		if err := f.tables[name].Append(number, blobs[name]); err != nil {
+			log.Error("Failed to append ancient", "table", name, "height", number, "err", err)
+			return err
+		}
+	}
+	atomic.AddUint64(&f.frozen, 1) // Only modify atomically
+	return nil
+}
+
+// TruncateAncients discards any recent data above the provided threshold number.
+func (f *freezer) TruncateAncients(items uint64) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if atomic.LoadUint64(&f.frozen) <= items {
+		return nil
+	}
+	for _, table := range f.tables {
+		if err := table.truncate(items); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&f.frozen, items)
+	return nil
+}
+
+// Sync flushes all data tables to disk.
+func (f *freezer) Sync() error {
+	var errs []error
+	for _, table := range f.tables {
+		if err := table.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// repair truncates all data tables to the same length.
+func (f *freezer) repair() error {
+	min := uint64(0)
+	for i, name := range []string{freezerHashTable, freezerHeaderTable, freezerBodiesTable, freezerCommitTable, freezerReceiptTable} {
+		items := f.tables[name].Items()
+		if i == 0 || items < min {
+			min = items
+		}
+	}
+	for _, table := range f.tables {
+		if err := table.truncate(min); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&f.frozen, min)
+	return nil
+}
+
+// freeze is a background thread that periodically checks the blockchain for any
+// import progress and moves ancient data from the fast database into the freezer.
+// Since the frozen count starts wherever the freezer left off, the first runs on
+// an existing database migrate all its old blocks.
+func (f *freezer) freeze(db kaidb.KeyValueStore) {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case <-f.quit:
+			log.Info("Freezer shutting down")
+			return
+		default:
+		}
+		frozen, err := f.freezeBatch(db)
+		if err != nil {
+			log.Error("Failed to freeze ancient blocks", "err", err)
+		}
+		// Keep migrating without delay while full batches are being frozen
+		if err == nil && frozen == freezerBatchLimit {
+			continue
+		}
+		select {
+		case <-time.After(freezerRecheckInterval):
+		case <-f.quit:
+			log.Info("Freezer shutting down")
+			return
+		}
+	}
+}
+
+// freezeBatch moves the next batch of canonical blocks older than the threshold
+// into the freezer, then deletes them from the key-value store. It returns the
+// number of frozen blocks.
+func (f *freezer) freezeBatch(db kaidb.KeyValueStore) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	hash := CommonReadHeadBlockHash(db)
+	if hash.IsZero() {
+		return 0, nil
+	}
+	head := CommonReadHeaderHeight(db, hash)
+	if head == nil || *head < f.threshold {
+		return 0, nil
+	}
+	first, limit := atomic.LoadUint64(&f.frozen), *head-f.threshold
+	if first > limit {
+		return 0, nil
+	}
+	if limit-first >= freezerBatchLimit {
+		limit = first + freezerBatchLimit - 1
+	}
+	var (
+		start  = time.Now()
+		hashes []common.Hash
+		totals []int
+	)
+	for height := first; height <= limit; height++ {
+		hash := CommonReadCanonicalHash(db, height)
+		if hash.IsZero() {
+			return len(hashes), fmt.Errorf("canonical hash missing, can't freeze block %d", height)
+		}
+		meta, _ := db.Get(blockMetaKey(hash, height))
+		if len(meta) == 0 {
+			return len(hashes), fmt.Errorf("block meta missing, can't freeze block %d", height)
+		}
+		blockMeta := new(types.BlockMeta)
+		if err := rlp.DecodeBytes(meta, blockMeta); err != nil {
+			return len(hashes), err
+		}
+		total := int(blockMeta.BlockID.PartsHeader.Total.Int32())
+		parts := make([]rlp.RawValue, total)
+		for i := 0; i < total; i++ {
+			if parts[i], _ = db.Get(blockPartKey(height, i)); len(parts[i]) == 0 {
+				return len(hashes), fmt.Errorf("block part %d missing, can't freeze block %d", i, height)
+			}
+		}
+		partsBytes, err := rlp.EncodeToBytes(parts)
+		if err != nil {
+			return len(hashes), err
+		}
+		commit, _ := db.Get(commitKey(height))
+		receipts, _ := db.Get(blockReceiptsKey(height, hash))
+		if len(receipts) == 0 {
+			receipts = emptyReceipts
+		}
+		if err := f.AppendAncient(height, hash.Bytes(), meta, partsBytes, commit, receipts); err != nil {
+			return len(hashes), err
+		}
+		hashes = append(hashes, hash)
+		totals = append(totals, total)
+	}
+	// Batch of blocks have been frozen, flush them before wiping from the database
+	if err := f.Sync(); err != nil {
+		log.Crit("Failed to flush frozen tables", "err", err)
+	}
+	batch := db.NewBatch()
+	for i, hash := range hashes {
+		height := first + uint64(i)
+		batch.Delete(headerHashKey(height))
+		batch.Delete(blockMetaKey(hash, height))
+		for j := 0; j < totals[i]; j++ {
+			batch.Delete(blockPartKey(height, j))
+		}
+		batch.Delete(commitKey(height))
+		batch.Delete(blockReceiptsKey(height, hash))
+
+		if batch.ValueSize() > kaidb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed to delete frozen blocks", "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete frozen blocks", "err", err)
+	}
+	log.Info("Froze ancient blocks", "blocks", len(hashes), "from", first, "to", limit, "elapsed", common.PrettyDuration(time.Since(start)))
+	return len(hashes), nil
+}
+
+// freezerdb is a database wrapper that enables freezer data retrievals.
+type freezerdb struct {
+	kaidb.KeyValueStore
+	kaidb.AncientStore
+}
+
+// Close implements io.Closer, closing both the fast key-value store as well as
+// the slow ancient tables.
+func (frdb *freezerdb) Close() error {
+	var errs []error
+	if err := frdb.AncientStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := frdb.KeyValueStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// NewStoreDBWithFreezer creates a StoreDB whose canonical blocks older than the
+// threshold are moved from the given key-value store into a freezer held in the
+// given directory, and read back from it transparently. A zero threshold uses
+// DefaultFreezeThreshold.
+func NewStoreDBWithFreezer(db kaidb.KeyValueStore, freezer string, threshold uint64) (*StoreDB, error) {
+	frdb, err := newFreezer(freezer, threshold)
+	if err != nil {
+		return nil, err
+	}
+	// The freezer must hold the ancient part of the chain stored in db
+	if frozen, _ := frdb.Ancients(); frozen > 0 {
+		hash, _ := frdb.Ancient(freezerHashTable, 0)
+		if kvhash := CommonReadCanonicalHash(db, 0); !kvhash.IsZero() && kvhash != common.BytesToHash(hash) {
+			frdb.Close()
+			return nil, fmt.Errorf("genesis mismatch: %x (key-value store) != %x (ancients)", kvhash, hash)
+		}
+	}
+	frdb.wg.Add(1)
+	go frdb.freeze(db)
+
+	return NewStoreDB(&freezerdb{
+		KeyValueStore: db,
+		AncientStore:  frdb,
+	}), nil
+}
@@ -0,0 +1,278 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+var (
+	// errClosed is returned if an operation attempts to read from or write to the
+	// freezer table after it has already been closed.
+	errClosed = errors.New("closed")
+
+	// errOutOfBounds is returned if the item requested is not contained within the
+	// freezer table.
+	errOutOfBounds = errors.New("out of bounds")
+
+	// errOutOrderInsertion is returned if the user attempts to inject out-of-order
+	// binary blobs into the freezer.
+	errOutOrderInsertion = errors.New("the append operation is out-order")
+)
+
+// indexEntrySize is the size of an entry of the index file of a freezer table.
+const indexEntrySize = 8
+
+// freezerTable is an append-only table of binary blobs stored in a data file,
+// along with an index file holding the end offset of every blob in the data
+// file as a big endian uint64. Blobs are snappy compressed unless disabled.
+type freezerTable struct {
+	lock sync.RWMutex // Mutex protecting the files and counters
+
+	noCompression bool     // if true, disables snappy compression
+	index         *os.File // File descriptor of the index
+	data          *os.File // File descriptor of the data
+
+	items uint64 // Number of items stored in the table
+	size  uint64 // Size of the data file
+}
+
+// newFreezerTable opens the given table in the given directory, creating it if
+// missing, and repairs any inconsistency between its index and data files.
+func newFreezerTable(dir, name string, noCompression bool) (*freezerTable, error) {
+	ext := ".cdat"
+	if noCompression {
+		ext = ".rdat"
+	}
+	index, err := os.OpenFile(filepath.Join(dir, name+".ridx"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.OpenFile(filepath.Join(dir, name+ext), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		index.Close()
+		return nil, err
+	}
+	t := &freezerTable{
+		noCompression: noCompression,
+		index:         index,
+		data:          data,
+	}
+	if err := t.repair(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// repair drops the index entries pointing past the end of the data file, and the
+// data past the end of the last index entry, e.g. left by a crash during an append.
+func (t *freezerTable) repair() error {
+	stat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+	items := uint64(stat.Size()) / indexEntrySize
+	if stat, err = t.data.Stat(); err != nil {
+		return err
+	}
+	dataSize := uint64(stat.Size())
+
+	var size uint64
+	for ; items > 0; items-- {
+		if size, err = t.offset(items - 1); err != nil {
+			return err
+		}
+		if size <= dataSize {
+			break
+		}
+	}
+	if items == 0 {
+		size = 0
+	}
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+	t.items, t.size = items, size
+	return nil
+}
+
+// offset returns the end offset in the data file of the given item.
+func (t *freezerTable) offset(item uint64) (uint64, error) {
+	var buf [indexEntrySize]byte
+	if _, err := t.index.ReadAt(buf[:], int64(item*indexEntrySize)); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// Items returns the number of items stored in the table.
+func (t *freezerTable) Items() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.items
+}
+
+// Size returns the size of the data stored in the table.
+func (t *freezerTable) Size() (uint64, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.index == nil {
+		return 0, errClosed
+	}
+	return t.size, nil
+}
+
+// Append injects a binary blob at the end of the table. The given item number
+// must be the number of items already stored.
+func (t *freezerTable) Append(item uint64, blob []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.index == nil {
+		return errClosed
+	}
+	if item != t.items {
+		return fmt.Errorf("%v: appending item %d, have %d", errOutOrderInsertion, item, t.items)
+	}
+	if !t.noCompression {
+		blob = snappy.Encode(nil, blob)
+	}
+	if _, err := t.data.WriteAt(blob, int64(t.size)); err != nil {
+		return err
+	}
+	var entry [indexEntrySize]byte
+	binary.BigEndian.PutUint64(entry[:], t.size+uint64(len(blob)))
+	if _, err := t.index.WriteAt(entry[:], int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+	t.items++
+	t.size += uint64(len(blob))
+	return nil
+}
+
+// Retrieve looks up the data offsets of the given item and returns its blob.
+func (t *freezerTable) Retrieve(item uint64) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.index == nil {
+		return nil, errClosed
+	}
+	if item >= t.items {
+		return nil, errOutOfBounds
+	}
+	var start uint64
+	if item > 0 {
+		var err error
+		if start, err = t.offset(item - 1); err != nil {
+			return nil, err
+		}
+	}
+	end, err := t.offset(item)
+	if err != nil {
+		return nil, err
+	}
+	blob := make([]byte, end-start)
+	if _, err := t.data.ReadAt(blob, int64(start)); err != nil {
+		return nil, err
+	}
+	if t.noCompression {
+		return blob, nil
+	}
+	return snappy.Decode(nil, blob)
+}
+
+// truncate discards any recent data above the provided threshold number.
+func (t *freezerTable) truncate(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.index == nil {
+		return errClosed
+	}
+	if t.items <= items {
+		return nil
+	}
+	var size uint64
+	if items > 0 {
+		var err error
+		if size, err = t.offset(items - 1); err != nil {
+			return err
+		}
+	}
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+	t.items, t.size = items, size
+	return nil
+}
+
+// Sync pushes any pending data from memory out to disk.
+func (t *freezerTable) Sync() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.index == nil {
+		return errClosed
+	}
+	if err := t.index.Sync(); err != nil {
+		return err
+	}
+	return t.data.Sync()
+}
+
+// Close closes all opened files.
+func (t *freezerTable) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var errs []error
+	if t.index != nil {
+		if err := t.index.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		t.index = nil
+	}
+	if t.data != nil {
+		if err := t.data.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		t.data = nil
+	}
+	if errs != nil {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvstore
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+func TestFreezerTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer-table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, noCompression := range []bool{false, true} {
+		name := fmt.Sprintf("table-%v", noCompression)
+		table, err := newFreezerTable(dir, name, noCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := uint64(0); i < 10; i++ {
+			if err := table.Append(i, bytes.Repeat([]byte{byte(i)}, int(i))); err != nil {
+				t.Fatalf("%s: failed to append item %d: %v", name, i, err)
+			}
+		}
+		if err := table.Append(11, []byte{0x01}); err == nil {
+			t.Errorf("%s: out of order append succeeded", name)
+		}
+		table.Close()
+
+		// Reopen the table, chopping the last item from its data file
+		ext := ".cdat"
+		if noCompression {
+			ext = ".rdat"
+		}
+		path := filepath.Join(dir, name+ext)
+		stat, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Truncate(path, stat.Size()-1); err != nil {
+			t.Fatal(err)
+		}
+		if table, err = newFreezerTable(dir, name, noCompression); err != nil {
+			t.Fatal(err)
+		}
+		if items := table.Items(); items != 9 {
+			t.Errorf("%s: repaired items mismatch: have %d, want 9", name, items)
+		}
+		for i := uint64(0); i < 9; i++ {
+			have, err := table.Retrieve(i)
+			if err != nil || !bytes.Equal(have, bytes.Repeat([]byte{byte(i)}, int(i))) {
+				t.Errorf("%s: item %d mismatch: have %x, err %v", name, i, have, err)
+			}
+		}
+		if err := table.truncate(5); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := table.Retrieve(5); err != errOutOfBounds {
+			t.Errorf("%s: truncated item retrieved: err %v", name, err)
+		}
+		if err := table.Append(5, []byte{0x05}); err != nil {
+			t.Errorf("%s: failed to append after truncation: %v", name, err)
+		}
+		table.Close()
+	}
+}
+
+func TestFreezer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Store a chain of 10 blocks along with their receipts
+	kvdb := memorydb.New()
+	var blocks []*types.Block
+	for height := uint64(0); height < 10; height++ {
+		block := newTestBlock(height)
+		WriteBlock(kvdb, block, block.MakePartSet(types.BlockPartSizeBytes), block.LastCommit())
+		CommonWriteCanonicalHash(kvdb, block.Hash(), height)
+		CommonWriteReceipts(kvdb, block.Hash(), height, types.Receipts{
+			{TxHash: common.Hash{byte(height)}, GasUsed: height, Logs: []*types.Log{}},
+		})
+		CommonWriteHeadBlockHash(kvdb, block.Hash())
+		blocks = append(blocks, block)
+	}
+	f, err := newFreezer(dir, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if frozen, err := f.freezeBatch(kvdb); err != nil || frozen != 7 {
+		t.Fatalf("frozen blocks mismatch: have %d, want 7, err %v", frozen, err)
+	}
+	if has, _ := kvdb.Has(blockMetaKey(blocks[6].Hash(), 6)); has {
+		t.Errorf("frozen block meta still in key-value store")
+	}
+	if has, _ := kvdb.Has(blockMetaKey(blocks[7].Hash(), 7)); !has {
+		t.Errorf("recent block meta missing from key-value store")
+	}
+
+	// Frozen blocks must be read back transparently
+	db := NewStoreDB(&freezerdb{KeyValueStore: kvdb, AncientStore: f})
+	for height, block := range blocks {
+		if have := db.ReadCanonicalHash(uint64(height)); have != block.Hash() {
+			t.Errorf("block %d: canonical hash mismatch: have %x, want %x", height, have, block.Hash())
+		}
+		if have := db.ReadBlock(block.Hash(), uint64(height)); have == nil || have.Hash() != block.Hash() {
+			t.Errorf("block %d mismatch: have %v", height, have)
+		}
+		if have := db.ReadReceipts(block.Hash(), uint64(height)); len(have) != 1 || have[0].GasUsed != uint64(height) {
+			t.Errorf("block %d: receipts mismatch: have %v", height, have)
+		}
+		if height < len(blocks)-1 {
+			if have := db.ReadCommit(uint64(height)); have == nil {
+				t.Errorf("block %d: commit missing", height)
+			}
+		}
+	}
+	if have := db.ReadBlock(common.Hash{0x01}, 3); have != nil {
+		t.Errorf("frozen block found by unknown hash: %v", have)
+	}
+
+	// Truncating the freezer must drop the blocks above the new head
+	if err := f.TruncateAncients(4); err != nil {
+		t.Fatal(err)
+	}
+	if frozen, _ := f.Ancients(); frozen != 4 {
+		t.Errorf("frozen blocks mismatch after truncation: have %d, want 4", frozen)
+	}
+	if have := db.ReadBlock(blocks[5].Hash(), 5); have != nil {
+		t.Errorf("truncated block found: %v", have)
+	}
+}
+
+// newTestBlock creates an empty block at the given height.
+func newTestBlock(height uint64) *types.Block {
+	vote := &types.Vote{
+		ValidatorIndex: common.NewBigInt64(0),
+		Height:         common.NewBigInt64(int64(height)),
+		Round:          common.NewBigInt64(0),
+		Timestamp:      big.NewInt(100),
+		Type:           types.PrecommitType,
+	}
+	commit := types.NewCommit(types.NewZeroBlockID(), []*types.CommitSig{vote.CommitSig()})
+	header := &types.Header{Height: height, Time: big.NewInt(int64(100 + height))}
+	return types.NewBlock(header, nil, commit)
+}
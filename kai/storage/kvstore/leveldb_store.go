@@ -30,6 +30,8 @@ import (
 	"github.com/kardiachain/go-kardia/lib/log"
 )
 
+var _ types.StoreDB = (*StoreDB)(nil)
+
 type StoreDB struct {
 	fn string         // filename for reporting
 	db kaidb.Database // LevelDB instance
@@ -104,6 +106,12 @@ func (s *StoreDB) WriteTxLookupEntries(block *types.Block) {
 	CommonWriteTxLookupEntries(s.db, block)
 }
 
+// WriteAddressTxs indexes the transaction at the given position of a block under
+// every given address.
+func (s *StoreDB) WriteAddressTxs(height uint64, index uint32, hash common.Hash, addresses []common.Address) {
+	CommonWriteAddressTxs(s.db, height, index, hash, addresses)
+}
+
 // Stores a hash into the database.
 func (s *StoreDB) StoreHash(hash *common.Hash) {
 	CommonStoreHash(s.db, hash)
@@ -216,6 +224,13 @@ func (s *StoreDB) ReadReceipts(hash common.Hash, number uint64) types.Receipts {
 	return CommonReadReceipts(s.db, hash, number)
 }
 
+// ReadAddressTxs retrieves the hashes of the transactions indexed under the given
+// address between the given heights, skipping the first skip ones and returning
+// at most limit ones.
+func (s *StoreDB) ReadAddressTxs(address common.Address, from, to uint64, skip, limit int) []common.Hash {
+	return CommonReadAddressTxs(s.db, address, from, to, skip, limit)
+}
+
 // ReadTxLookupEntry retrieves the positional metadata associated with a transaction
 // hash to allow retrieving the transaction or receipt by hash.
 func (s *StoreDB) ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64) {
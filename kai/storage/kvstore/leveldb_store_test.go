@@ -1 +1,52 @@
 package kvstore
+
+import (
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+func TestAddressTxs(t *testing.T) {
+	db := NewStoreDB(memorydb.New())
+	addr, other := common.Address{0x01}, common.Address{0x02}
+
+	// Index two transactions per block, the second one also involving another address
+	var hashes []common.Hash
+	for height := uint64(1); height <= 5; height++ {
+		for index := uint32(0); index < 2; index++ {
+			hash := common.Hash{byte(height), byte(index)}
+			addresses := []common.Address{addr}
+			if index == 1 {
+				addresses = append(addresses, other)
+			}
+			db.WriteAddressTxs(height, index, hash, addresses)
+			hashes = append(hashes, hash)
+		}
+	}
+	tests := []struct {
+		address     common.Address
+		from, to    uint64
+		skip, limit int
+		want        []common.Hash
+	}{
+		{addr, 1, 5, 0, 100, hashes},
+		{addr, 2, 3, 0, 100, hashes[2:6]},
+		{addr, 1, 5, 3, 4, hashes[3:7]},
+		{addr, 6, 10, 0, 100, nil},
+		{other, 1, 2, 0, 100, []common.Hash{hashes[1], hashes[3]}},
+		{common.Address{0x03}, 1, 5, 0, 100, nil},
+	}
+	for i, tt := range tests {
+		have := db.ReadAddressTxs(tt.address, tt.from, tt.to, tt.skip, tt.limit)
+		if len(have) != len(tt.want) {
+			t.Errorf("test %d: transactions mismatch: have %d, want %d", i, len(have), len(tt.want))
+			continue
+		}
+		for j := range have {
+			if have[j] != tt.want[j] {
+				t.Errorf("test %d: transaction %d mismatch: have %x, want %x", i, j, have[j], tt.want[j])
+			}
+		}
+	}
+}
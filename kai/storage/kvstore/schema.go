@@ -55,6 +55,7 @@ var (
 
 	configPrefix          = []byte("kardia-config-") // config prefix for the db
 	txLookupPrefix        = []byte("l")              // txLookupPrefix + hash -> transaction/receipt lookup metadata
+	addressTxPrefix       = []byte("at")             // addressTxPrefix + address + num (uint64 big endian) + index (uint32 big endian) -> transaction hash
 	dualEventLookupPrefix = []byte("de")             // dualEventLookupPrefix + hash -> dual's event lookup metadata
 	bloomBitsPrefix       = []byte("B")              // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 
@@ -158,6 +159,16 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// addressTxPrefixKey = addressTxPrefix + address
+func addressTxPrefixKey(address common.Address) []byte {
+	return append(addressTxPrefix, address.Bytes()...)
+}
+
+// addressTxKey = addressTxPrefix + address + num (uint64 big endian) + index (uint32 big endian)
+func addressTxKey(address common.Address, height uint64, index uint32) []byte {
+	return append(append(addressTxPrefixKey(address), encodeBlockHeight(height)...), encodeIndex(index)...)
+}
+
 // dualEventLookupKey = dualEventLookupPrefix + hash
 func dualEventLookupKey(hash common.Hash) []byte {
 	return append(dualEventLookupPrefix, hash.Bytes()...)
@@ -38,6 +38,8 @@ import (
 
 var client *mongo.Client
 
+var _ types.StoreDB = (*Store)(nil)
+
 type Store struct {
 	uri    string
 	dbName string
@@ -448,6 +450,17 @@ func (db *Store) WriteTxLookupEntries(block *types.Block) {
 	}
 }
 
+// WriteAddressTxs is not supported, transactions are queried by address from their collection.
+func (db *Store) WriteAddressTxs(height uint64, index uint32, hash common.Hash, addresses []common.Address) {
+	log.Warn("WriteAddressTxs has not implemented yet")
+}
+
+// ReadAddressTxs is not supported, transactions are queried by address from their collection.
+func (db *Store) ReadAddressTxs(address common.Address, from, to uint64, skip, limit int) []common.Hash {
+	log.Warn("ReadAddressTxs has not implemented yet")
+	return nil
+}
+
 // Stores a hash into the database.
 func (db *Store) StoreHash(hash *common.Hash) {
 	log.Warn("StoreHash has not implemented yet")
@@ -72,6 +72,7 @@ type (
 		AppHash        string `json:"app_hash"           bson:"app_hash"`        // state root
 		ReceiptHash    string `json:"receiptsRoot"        bson:"receiptsRoot"`   // receipt root
 		Bloom          string `json:"logsBloom"           bson:"logsBloom"`
+		EvidenceHash   string `json:"evidenceHash"        bson:"evidenceHash"`
 
 		Validator      string `json:"validator"           bson:"validator"`
 		ValidatorsHash string `json:"validators_hash"` // validators for the current block
@@ -173,10 +174,14 @@ type (
 		Hash string `json:"hash"    bson:"hash"`
 	}
 	ChainConfig struct {
-		Hash        string `json:"hash"     bson:"hash"`
-		Period      uint64 `json:"period"   bson:"period"`
-		Epoch       uint64 `json:"epoch"    bson:"epoch"`
-		BaseAccount `json:"baseAccount,omitempty"`
+		Hash                    string                       `json:"hash"     bson:"hash"`
+		Period                  uint64                       `json:"period"   bson:"period"`
+		Epoch                   uint64                       `json:"epoch"    bson:"epoch"`
+		HeaderCommitmentsHeight *uint64                      `json:"headerCommitmentsHeight,omitempty" bson:"headerCommitmentsHeight,omitempty"`
+		SystemPhasesHeight      *uint64                      `json:"systemPhasesHeight,omitempty" bson:"systemPhasesHeight,omitempty"`
+		PrecompilesV1Height     *uint64                      `json:"precompilesV1Height,omitempty" bson:"precompilesV1Height,omitempty"`
+		ConsensusTiming         *types.ConsensusTimingConfig `json:"consensusTiming,omitempty" bson:"consensusTiming,omitempty"`
+		BaseAccount             `json:"baseAccount,omitempty"`
 	}
 	Caching struct {
 		Key   string `json:"key"       bson:"key"`
@@ -217,6 +222,9 @@ func NewBlock(block *types.Block) *Block {
 		NumDualEvents:  block.Header().NumDualEvents,
 		Validator:      block.Header().Validator.Hex(),
 		ValidatorsHash: block.Header().ValidatorsHash.Hex(),
+		ReceiptHash:    block.Header().ReceiptHash.Hex(),
+		Bloom:          common.Bytes2Hex(block.Header().Bloom.Bytes()),
+		EvidenceHash:   block.Header().EvidenceHash.Hex(),
 	}
 	if block.Header().Time != nil {
 		header.Time = block.Header().Time.Uint64()
@@ -243,6 +251,9 @@ func (block *Block) ToHeader() *types.Header {
 		AppHash:        common.HexToHash(block.Header.AppHash),
 		Validator:      common.HexToAddress(block.Header.Validator),
 		ValidatorsHash: common.HexToHash(block.Header.ValidatorsHash),
+		ReceiptHash:    common.HexToHash(block.Header.ReceiptHash),
+		Bloom:          types.BytesToBloom(common.Hex2Bytes(block.Header.Bloom)),
+		EvidenceHash:   common.HexToHash(block.Header.EvidenceHash),
 	}
 	return &header
 }
@@ -485,9 +496,13 @@ func (commit *Commit) ToCommit() *types.Commit {
 
 func NewChainConfig(config *types.ChainConfig, hash common.Hash) *ChainConfig {
 	return &ChainConfig{
-		Hash:   hash.Hex(),
-		Epoch:  config.Kaicon.Epoch,
-		Period: config.Kaicon.Period,
+		Hash:                    hash.Hex(),
+		Epoch:                   config.Kaicon.Epoch,
+		Period:                  config.Kaicon.Period,
+		HeaderCommitmentsHeight: config.HeaderCommitmentsHeight,
+		SystemPhasesHeight:      config.SystemPhasesHeight,
+		PrecompilesV1Height:     config.PrecompilesV1Height,
+		ConsensusTiming:         config.ConsensusTiming,
 		BaseAccount: BaseAccount{
 			Address:    config.BaseAccount.Address.Hex(),
 			PrivateKey: common.Bytes2Hex(config.PrivateKey.D.Bytes()),
@@ -504,7 +519,14 @@ func (config *ChainConfig) ToChainConfig() *types.ChainConfig {
 		Epoch:  config.Epoch,
 		Period: config.Period,
 	}
-	return &types.ChainConfig{Kaicon: &kaiCon, BaseAccount: &types.BaseAccount{PrivateKey: *pk, Address: common.HexToAddress(config.BaseAccount.Address)}}
+	return &types.ChainConfig{
+		Kaicon:                  &kaiCon,
+		BaseAccount:             &types.BaseAccount{PrivateKey: *pk, Address: common.HexToAddress(config.BaseAccount.Address)},
+		HeaderCommitmentsHeight: config.HeaderCommitmentsHeight,
+		SystemPhasesHeight:      config.SystemPhasesHeight,
+		PrecompilesV1Height:     config.PrecompilesV1Height,
+		ConsensusTiming:         config.ConsensusTiming,
+	}
 }
 
 func toBlockID(blockID string, partSetHeader PartSetHeader) types.BlockID {
@@ -0,0 +1,262 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package storetest provides a conformance test suite for the types.StoreDB
+// implementations started by a storage.DbInfo.
+package storetest
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/storage"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// TestStoreDBSuite runs a suite of tests against the StoreDB started by the given
+// DbInfo. Every call of New must return the info of a new empty database.
+func TestStoreDBSuite(t *testing.T, New func() storage.DbInfo) {
+	start := func(t *testing.T) types.StoreDB {
+		db, err := New().Start()
+		if err != nil {
+			t.Fatalf("failed to start %T: %v", db, err)
+		}
+		return db
+	}
+
+	t.Run("Block", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		block, parts, seenCommit := newBlock(t, 1)
+		hash := block.Hash()
+		db.WriteBlock(block, parts, seenCommit)
+
+		if have := db.ReadBlock(hash, 1); have == nil || have.Hash() != hash {
+			t.Errorf("block mismatch: have %v, want %x", have, hash)
+		}
+		if have := db.ReadHeader(hash, 1); have == nil || have.Hash() != hash {
+			t.Errorf("header mismatch: have %v, want %x", have, hash)
+		}
+		if have := db.ReadBody(hash, 1); have == nil || len(have.Transactions) != len(block.Transactions()) {
+			t.Errorf("body mismatch: have %v, want %d transactions", have, len(block.Transactions()))
+		}
+		if have := db.ReadBlockMeta(hash, 1); have == nil || have.Header.Hash() != hash {
+			t.Errorf("block meta mismatch: have %v", have)
+		}
+		for i := 0; i < parts.Total(); i++ {
+			if have := db.ReadBlockPart(hash, 1, i); have == nil || !bytes.Equal(have.Bytes, parts.GetPart(i).Bytes) {
+				t.Errorf("block part %d mismatch", i)
+			}
+		}
+		if have := db.ReadHeaderNumber(hash); have == nil || *have != 1 {
+			t.Errorf("header number mismatch: have %v, want 1", have)
+		}
+		if have := db.ReadCommit(0); have == nil || len(have.Precommits) != len(block.LastCommit().Precommits) {
+			t.Errorf("last commit mismatch: have %v", have)
+		}
+		if have := db.ReadSeenCommit(1); have == nil || len(have.Precommits) != len(seenCommit.Precommits) {
+			t.Errorf("seen commit mismatch: have %v", have)
+		}
+
+		// Unknown blocks must read as missing
+		unknown := common.Hash{0x01}
+		if have := db.ReadBlock(unknown, 2); have != nil {
+			t.Errorf("unknown block found: %v", have)
+		}
+		if have := db.ReadHeader(unknown, 2); have != nil {
+			t.Errorf("unknown header found: %v", have)
+		}
+		if have := db.ReadBody(unknown, 2); have != nil {
+			t.Errorf("unknown body found: %v", have)
+		}
+		if have := db.ReadHeaderNumber(unknown); have != nil {
+			t.Errorf("unknown header number found: %d", *have)
+		}
+	})
+
+	t.Run("CanonicalChain", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		hash := common.Hash{0x01}
+		if have := db.ReadCanonicalHash(1); !have.IsZero() {
+			t.Errorf("canonical hash found in empty database: %x", have)
+		}
+		db.WriteCanonicalHash(hash, 1)
+		if have := db.ReadCanonicalHash(1); have != hash {
+			t.Errorf("canonical hash mismatch: have %x, want %x", have, hash)
+		}
+		db.DeleteCanonicalHash(1)
+		if have := db.ReadCanonicalHash(1); !have.IsZero() {
+			t.Errorf("deleted canonical hash found: %x", have)
+		}
+
+		db.WriteHeadBlockHash(hash)
+		if have := db.ReadHeadBlockHash(); have != hash {
+			t.Errorf("head block hash mismatch: have %x, want %x", have, hash)
+		}
+		db.WriteHeadHeaderHash(hash)
+		if have := db.ReadHeadHeaderHash(); have != hash {
+			t.Errorf("head header hash mismatch: have %x, want %x", have, hash)
+		}
+	})
+
+	t.Run("Transactions", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		block, parts, seenCommit := newBlock(t, 1)
+		db.WriteBlock(block, parts, seenCommit)
+		db.WriteTxLookupEntries(block)
+
+		for i, tx := range block.Transactions() {
+			blockHash, height, index := db.ReadTxLookupEntry(tx.Hash())
+			if blockHash != block.Hash() || height != 1 || index != uint64(i) {
+				t.Errorf("transaction %d: lookup entry mismatch: have (%x, %d, %d)", i, blockHash, height, index)
+			}
+			have, blockHash, height, index := db.ReadTransaction(tx.Hash())
+			if have == nil || have.Hash() != tx.Hash() || blockHash != block.Hash() || height != 1 || index != uint64(i) {
+				t.Errorf("transaction %d mismatch: have %v", i, have)
+			}
+		}
+		if have, blockHash, _, _ := db.ReadTransaction(common.Hash{0x01}); have != nil || !blockHash.IsZero() {
+			t.Errorf("unknown transaction found: %v", have)
+		}
+	})
+
+	t.Run("Receipts", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		hash := common.Hash{0x01}
+		receipts := types.Receipts{
+			{TxHash: common.Hash{0x02}, CumulativeGasUsed: 21000, GasUsed: 21000, Logs: []*types.Log{}},
+			{TxHash: common.Hash{0x03}, CumulativeGasUsed: 63000, GasUsed: 42000, Logs: []*types.Log{}, ContractAddress: common.Address{0x04}},
+		}
+		db.WriteReceipts(hash, 1, receipts)
+
+		have := db.ReadReceipts(hash, 1)
+		if len(have) != len(receipts) {
+			t.Fatalf("receipts mismatch: have %d, want %d", len(have), len(receipts))
+		}
+		for i, receipt := range receipts {
+			if have[i].TxHash != receipt.TxHash || have[i].CumulativeGasUsed != receipt.CumulativeGasUsed ||
+				have[i].GasUsed != receipt.GasUsed || have[i].ContractAddress != receipt.ContractAddress {
+				t.Errorf("receipt %d mismatch: have %+v, want %+v", i, have[i], receipt)
+			}
+		}
+		if have := db.ReadReceipts(common.Hash{0x02}, 1); len(have) != 0 {
+			t.Errorf("unknown receipts found: %v", have)
+		}
+	})
+
+	t.Run("ChainConfig", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		hash, height := common.Hash{0x01}, uint64(10)
+		timing := &types.ConsensusTimingConfig{TimeoutPropose: 3000, TimeoutCommit: 500, CreateEmptyBlocksInterval: 1000}
+		db.WriteChainConfig(hash, &types.ChainConfig{HeaderCommitmentsHeight: &height, ConsensusTiming: timing})
+		if have := db.ReadChainConfig(hash); have == nil || have.HeaderCommitmentsHeight == nil || *have.HeaderCommitmentsHeight != height {
+			t.Errorf("chain config mismatch: have %v", have)
+		} else if !have.ConsensusTiming.Equal(timing) {
+			t.Errorf("consensus timing mismatch: have %v, want %v", have.ConsensusTiming, timing)
+		}
+		if have := db.ReadChainConfig(common.Hash{0x02}); have != nil {
+			t.Errorf("unknown chain config found: %v", have)
+		}
+	})
+
+	t.Run("AppHash", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		hash := common.Hash{0x01}
+		db.WriteAppHash(1, hash)
+		if have := db.ReadAppHash(1); have != hash {
+			t.Errorf("app hash mismatch: have %x, want %x", have, hash)
+		}
+		if have := db.ReadAppHash(2); !have.IsZero() {
+			t.Errorf("unknown app hash found: %x", have)
+		}
+	})
+
+	t.Run("Hashes", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		hash, txHash := common.Hash{0x01}, common.Hash{0x02}
+		if db.CheckHash(&hash) || db.CheckTxHash(&txHash) {
+			t.Errorf("hashes found in empty database")
+		}
+		db.StoreHash(&hash)
+		db.StoreTxHash(&txHash)
+		if !db.CheckHash(&hash) {
+			t.Errorf("stored hash not found")
+		}
+		if !db.CheckTxHash(&txHash) {
+			t.Errorf("stored transaction hash not found")
+		}
+	})
+
+	t.Run("AddressTxs", func(t *testing.T) {
+		db := start(t)
+		defer db.DB().Close()
+
+		addr := common.Address{0x01}
+		hashes := []common.Hash{{0x01}, {0x02}, {0x03}}
+		for i, hash := range hashes {
+			db.WriteAddressTxs(uint64(i+1), 0, hash, []common.Address{addr})
+		}
+		have := db.ReadAddressTxs(addr, 2, 3, 0, 10)
+		if len(have) != 2 || have[0] != hashes[1] || have[1] != hashes[2] {
+			t.Errorf("address transactions mismatch: have %x, want %x", have, hashes[1:])
+		}
+		if have := db.ReadAddressTxs(common.Address{0x02}, 1, 3, 0, 10); len(have) != 0 {
+			t.Errorf("unknown address transactions found: %x", have)
+		}
+	})
+}
+
+// newBlock creates a block at the given height holding a signed transaction,
+// along with its parts and a commit for it.
+func newBlock(t *testing.T, height uint64) (*types.Block, *types.PartSet, *types.Commit) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), nil), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote := &types.Vote{
+		ValidatorIndex: common.NewBigInt64(0),
+		Height:         common.NewBigInt64(int64(height)),
+		Round:          common.NewBigInt64(0),
+		Timestamp:      big.NewInt(100),
+		Type:           types.PrecommitType,
+	}
+	commit := types.NewCommit(types.NewZeroBlockID(), []*types.CommitSig{vote.CommitSig()})
+	header := &types.Header{Height: height, Time: big.NewInt(100)}
+	block := types.NewBlock(header, types.Transactions{tx}, commit)
+	return block, block.MakePartSet(types.BlockPartSizeBytes), commit
+}
@@ -153,6 +153,7 @@ func convertOutputToNative(o reflect.Value, outputs abi.Arguments) ([]interface{
 }
 
 // ConvertParams gets data from message based on CEL and then convert returned values based on abi argument types.
+// The values of the pattern of a tuple argument are its fields, in order.
 func ConvertParams(p *Parser, arguments abi.Arguments, patterns []string) ([]interface{}, error) {
 	if len(arguments) != len(patterns) {
 		return nil, paramsArgumentsNotMatch
@@ -165,131 +166,168 @@ func ConvertParams(p *Parser, arguments abi.Arguments, patterns []string) ([]int
 			return nil, err
 		}
 
-		// vals is an []interface{}. the elements are any types if they are get from params (could be output of calling smc)
-		// or string if they are get from message.params (a list of string)
-		// if we use argument's types to cast the element. panic might happen and lead to crash.
-		// therefore the solution is: if element is string then we check arg's type and cast element to that type based on strconv
-		// otherwise add the element to abiInputs without doing anything.
-
 		arg := arguments[i]
-		t := arg.Type.Kind
-		for _, val := range vals {
-			v, err := InterfaceToString(val)
+		if arg.Type.T == abi.TupleTy {
+			tuple, err := convertTuple(arg.Type, vals)
 			if err != nil {
 				return nil, err
 			}
-			// handle special case: bigInt in arg
-			if arg.Type.Type.String() == "*big.Int" {
-				result, _ := big.NewInt(0).SetString(v, 10)
-				abiInputs = append(abiInputs, result)
-				continue
+			abiInputs = append(abiInputs, tuple)
+			continue
+		}
+		for _, val := range vals {
+			v, err := convertParam(arg.Type, val)
+			if err != nil {
+				return nil, err
 			}
+			abiInputs = append(abiInputs, v)
+		}
+	}
+	return abiInputs, nil
+}
 
-			if reflect.TypeOf(val).Kind() != reflect.String {
-				abiInputs = append(abiInputs, val)
-				continue
+// convertTuple converts vals to the struct of the tuple type typ, field by
+// field. A single list value holds the fields.
+func convertTuple(typ abi.Type, vals []interface{}) (interface{}, error) {
+	if len(vals) == 1 {
+		if kind := reflect.TypeOf(vals[0]).Kind(); kind == reflect.Slice || kind == reflect.Array {
+			fields, err := interfaceToSlice(vals[0])
+			if err != nil {
+				return nil, err
 			}
-			switch t {
-			case reflect.String:
-				abiInputs = append(abiInputs, val)
-			case reflect.Int8:
-				// convert val to int based with bitSize = 8
-				result, err := strconv.ParseInt(v, 10, 8)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, int8(result))
-			case reflect.Int16:
-				// convert val to int with bitSize = 16
-				result, err := strconv.ParseInt(v, 10, 16)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, int16(result))
-			case reflect.Int32:
-				// convert val to int with bitSize = 32
-				result, err := strconv.ParseInt(v, 10, 32)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, int32(result))
-			case reflect.Int64:
-				// convert val to int with bitSize = 64
-				result, err := strconv.ParseInt(v, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, result)
-			case reflect.Uint8:
-				// convert val to uint based with bitSize = 8
-				result, err := strconv.ParseUint(v, 10, 8)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, uint8(result))
-			case reflect.Uint16:
-				// convert val to int with bitSize = 16
-				result, err := strconv.ParseUint(v, 10, 16)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, uint16(result))
-			case reflect.Uint32:
-				// convert val to int with bitSize = 32
-				result, err := strconv.ParseUint(v, 10, 32)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, uint32(result))
-			case reflect.Uint64:
-				// convert val to int with bitSize = 64
-				result, err := strconv.ParseUint(v, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				abiInputs = append(abiInputs, result)
-			case reflect.Bool:
-				result, err := strconv.ParseBool(v)
+			vals = fields
+		}
+	}
+	if len(vals) != len(typ.TupleElems) {
+		return nil, paramsArgumentsNotMatch
+	}
+	tuple := reflect.New(typ.Type).Elem()
+	for i, elem := range typ.TupleElems {
+		var (
+			v   interface{}
+			err error
+		)
+		if elem.T == abi.TupleTy {
+			v, err = convertTuple(*elem, vals[i:i+1])
+		} else {
+			v, err = convertParam(*elem, vals[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		field, value := tuple.Field(i), reflect.ValueOf(v)
+		switch {
+		case value.Type().AssignableTo(field.Type()):
+			field.Set(value)
+		case field.Kind() == reflect.Array && value.Kind() == reflect.Slice && field.Len() == value.Len():
+			// fixed bytes
+			reflect.Copy(field, value)
+		default:
+			return nil, paramValueNotCorrect
+		}
+	}
+	return tuple.Interface(), nil
+}
+
+// convertParam converts val to the go type of the abi type typ.
+func convertParam(typ abi.Type, val interface{}) (interface{}, error) {
+	// val is an interface{}. it is any type if it is got from params (could be output of calling smc)
+	// or string if it is got from message.params (a list of string)
+	// if we use argument's types to cast the element. panic might happen and lead to crash.
+	// therefore the solution is: if element is string then we check arg's type and cast element to that type based on strconv
+	// otherwise return the element without doing anything.
+	v, err := InterfaceToString(val)
+	if err != nil {
+		return nil, err
+	}
+	// handle special case: bigInt in arg
+	if typ.Type.String() == "*big.Int" {
+		result, _ := big.NewInt(0).SetString(v, 10)
+		return result, nil
+	}
+
+	if reflect.TypeOf(val).Kind() != reflect.String {
+		return val, nil
+	}
+	switch typ.Kind {
+	case reflect.String:
+		return val, nil
+	case reflect.Int8:
+		// convert val to int based with bitSize = 8
+		result, err := strconv.ParseInt(v, 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		return int8(result), nil
+	case reflect.Int16:
+		// convert val to int with bitSize = 16
+		result, err := strconv.ParseInt(v, 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		return int16(result), nil
+	case reflect.Int32:
+		// convert val to int with bitSize = 32
+		result, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return int32(result), nil
+	case reflect.Int64:
+		// convert val to int with bitSize = 64
+		return strconv.ParseInt(v, 10, 64)
+	case reflect.Uint8:
+		// convert val to uint based with bitSize = 8
+		result, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		return uint8(result), nil
+	case reflect.Uint16:
+		// convert val to int with bitSize = 16
+		result, err := strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		return uint16(result), nil
+	case reflect.Uint32:
+		// convert val to int with bitSize = 32
+		result, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return uint32(result), nil
+	case reflect.Uint64:
+		// convert val to int with bitSize = 64
+		return strconv.ParseUint(v, 10, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(v)
+	case reflect.Array, reflect.Slice, reflect.Ptr:
+		t := typ.Type.String()
+		switch {
+		case strings.Contains(t, "uint8") && strings.HasPrefix(t, "[") && strings.Count(t, "]") == 1:
+			// val is bytes.
+			// convert val to bytes.
+			bytesValue := []byte(val.(string))
+			// get len of bytes by getting the number between "[" and "]"
+			lbrace := strings.Index(t, "[")
+			rbrace := strings.Index(t, "]")
+			if t[lbrace+1:rbrace] != "" { // val can be an array. get the length and validate val.
+				lenOfByte, err := strconv.ParseInt(t[lbrace+1:rbrace], 10, 32)
 				if err != nil {
 					return nil, err
 				}
-				abiInputs = append(abiInputs, result)
-			case reflect.Array, reflect.Slice, reflect.Ptr:
-				typ := arg.Type.Type.String()
-				switch {
-				case strings.Contains(typ, "uint8") && strings.HasPrefix(typ, "[") && strings.Count(typ, "]") == 1:
-					// val is bytes.
-					// convert val to bytes.
-					bytesValue := []byte(val.(string))
-					// get len of bytes by getting the number between "[" and "]"
-					lbrace := strings.Index(typ, "[")
-					rbrace := strings.Index(typ, "]")
-					if typ[lbrace+1:rbrace] != "" { // val can be an array. get the length and validate val.
-						lenOfByte, err := strconv.ParseInt(typ[lbrace+1:rbrace], 10, 32)
-						if err != nil {
-							return nil, err
-						}
-						// compare the length with bytesValue.
-						if int(lenOfByte) != len(bytesValue) {
-							return nil, paramValueNotCorrect
-						}
-					}
-					abiInputs = append(abiInputs, bytesValue)
-				case typ == "common.Address":
-					abiInputs = append(abiInputs, common.HexToAddress(v))
-				case typ == "*big.Int":
-					result, _ := big.NewInt(0).SetString(v, 10)
-					abiInputs = append(abiInputs, result)
-					continue
-				default:
-					return nil, unsupportedType
+				// compare the length with bytesValue.
+				if int(lenOfByte) != len(bytesValue) {
+					return nil, paramValueNotCorrect
 				}
-			default:
-				return nil, unsupportedType
 			}
+			return bytesValue, nil
+		case t == "common.Address":
+			return common.HexToAddress(v), nil
 		}
 	}
-	return abiInputs, nil
+	return nil, unsupportedType
 }
 
 func getPackedInput(p *Parser, kaiAbi *abi.ABI, method string, patterns []string) ([]byte, error) {
@@ -328,8 +366,9 @@ func EstimateGas(from common.Address, to common.Address, currentHeader *types.He
 	// Create a new context to be used in the KVM environment
 	vmContext := vm.NewKVMContext(msg, currentHeader, chain)
 	// Create a new environment which holds all relevant information
-	// about the transaction and calling mechanisms.
-	kaiVm := kvm.NewKVM(vmContext, stateDb, kvm.Config{
+	// about the transaction and calling mechanisms. The message runs on a copy
+	// of the state, which is the one of the tx pool.
+	kaiVm := kvm.NewKVM(vmContext, stateDb.Copy(), kvm.Config{
 		IsZeroFee: chain.ZeroFee(),
 	})
 	defer kaiVm.Cancel()
@@ -411,15 +450,6 @@ func findOutputs(smcABI abi.ABI, method string) abi.Arguments {
 	return nil
 }
 
-func getInputs(smcABI abi.ABI, method string) *abi.Arguments {
-	for k, v := range smcABI.Methods {
-		if k == method {
-			return &v.Inputs
-		}
-	}
-	return nil
-}
-
 // GenerateInputStructs creates structs for all methods from theirs inputs
 func GenerateInputStruct(smcABI abi.ABI, input []byte) (*abi.Method, interface{}, error) {
 	method, err := smcABI.MethodById(input)
@@ -434,13 +464,13 @@ func GenerateInputStruct(smcABI abi.ABI, input []byte) (*abi.Method, interface{}
 	return nil, nil, fmt.Errorf("method not found")
 }
 
+// GetMethodAndParams returns the method called by input and its params in string.
 func GetMethodAndParams(smcABI abi.ABI, input []byte) (string, []string, error) {
 	if len(input) == 0 {
 		return "", nil, nil
 	}
-	args := make([]string, 0)
-	method, str, err := GenerateInputStruct(smcABI, input)
-	if err != nil || method == nil {
+	method, err := smcABI.MethodById(input)
+	if err != nil {
 		return "", nil, err
 	}
 
@@ -448,13 +478,13 @@ func GetMethodAndParams(smcABI abi.ABI, input []byte) (string, []string, error)
 		return "", nil, err
 	}
 
-	if err := method.Inputs.Unpack(str, input[4:]); err != nil {
+	values, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
 		return "", nil, err
 	}
-	obj := reflect.ValueOf(str)
-	inputs := getInputs(smcABI, method.Name)
-	for i, _ := range *inputs {
-		v, err := InterfaceToString(obj.Elem().Field(i).Interface())
+	args := make([]string, 0, len(values))
+	for _, value := range values {
+		v, err := abiValueToString(value)
 		if err != nil {
 			return "", nil, err
 		}
@@ -1288,3 +1288,25 @@ func TestReplaceFunction(t *testing.T) {
 	require.Equal(t, expectedResult, parser.UserDefinedVariables["testReplace"])
 }
 
+
+func TestGetMethodAndParams_tuple(t *testing.T) {
+	kaiAbi, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"register","constant":false,"outputs":[],
+		"inputs":[{"name":"info","type":"tuple","components":[{"name":"id","type":"uint256"},{"name":"name","type":"string"}]},{"name":"owners","type":"tuple[]","components":[{"name":"owner","type":"address"},{"name":"share","type":"uint8"}]}]}]`))
+	require.NoError(t, err)
+	type info struct {
+		Id   *big.Int
+		Name string
+	}
+	type owner struct {
+		Owner common.Address
+		Share uint8
+	}
+	address := common.HexToAddress("0x0000000000000000000000000000000000000042")
+	input, err := kaiAbi.Pack("register", info{big.NewInt(1), "ab"}, []owner{{address, 2}})
+	require.NoError(t, err)
+
+	method, params, err := ksml.GetMethodAndParams(kaiAbi, input)
+	require.NoError(t, err)
+	require.Equal(t, "register", method)
+	require.Equal(t, []string{"(1,ab)", "[(" + address.Hex() + ",2)]"}, params)
+}
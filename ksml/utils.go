@@ -26,6 +26,9 @@ import (
 	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/common"
 )
 
 const (
@@ -365,6 +368,43 @@ func InterfaceToString(val interface{}) (string, error) {
 	return "", unsupportedType
 }
 
+// abiValueToString converts an unpacked abi value to string: addresses to hex,
+// bytes to string, tuples to (field1,field2,...) and lists to [elem1,elem2,...].
+func abiValueToString(val interface{}) (string, error) {
+	if address, ok := val.(common.Address); ok {
+		return address.Hex(), nil
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			field, err := abiValueToString(v.Field(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			fields[i] = field
+		}
+		return "(" + strings.Join(fields, ",") + ")", nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := reflect.MakeSlice(reflect.TypeOf([]byte{}), v.Len(), v.Len())
+			reflect.Copy(bytes, v)
+			return string(bytes.Bytes()), nil
+		}
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elem, err := abiValueToString(v.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			elems[i] = elem
+		}
+		return "[" + strings.Join(elems, ",") + "]", nil
+	}
+	return InterfaceToString(val)
+}
+
 func convertToNative(val reflect.Value) (interface{}, error) {
 	if val.Type().String() == "*big.Int" {
 		return val.Interface().(*big.Int), nil
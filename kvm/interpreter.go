@@ -19,6 +19,7 @@ package kvm
 import (
 	"fmt"
 	"hash"
+	"math/big"
 	"sync/atomic"
 
 	"github.com/kardiachain/go-kardia/lib/common"
@@ -37,6 +38,10 @@ type Config struct {
 
 	// IsZeroFee is true then sender will be refunded all gas spent for a transaction
 	IsZeroFee bool
+
+	// OnTransfer, if set, is called on every transfer of value between accounts,
+	// including the ones made by internal calls and contract creations.
+	OnTransfer func(from, to common.Address, value *big.Int)
 }
 
 // keccakState wraps sha3.state. In addition to the usual hash methods, it also supports
@@ -48,7 +48,7 @@ type (
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(kvm *KVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsV0
+		precompiles := kvm.precompiles()
 		if p := precompiles[*contract.CodeAddr]; p != nil {
 			return RunPrecompiledContract(p, input, contract, kvm.Context, kvm.StateDB)
 		}
@@ -113,6 +113,13 @@ type KVM struct {
 // NewKVM returns a new KVM. The returned KVM is not thread safe and should
 // only ever be used *once*.
 func NewKVM(ctx Context, statedb base.StateDB, vmConfig Config) *KVM {
+	if onTransfer := vmConfig.OnTransfer; onTransfer != nil {
+		transfer := ctx.Transfer
+		ctx.Transfer = func(db base.StateDB, sender, recipient common.Address, amount *big.Int) {
+			transfer(db, sender, recipient, amount)
+			onTransfer(sender, recipient, amount)
+		}
+	}
 	kvm := &KVM{
 		Context:  ctx,
 		StateDB:  statedb,
@@ -134,6 +141,14 @@ func (kvm *KVM) Cancelled() bool {
 	return atomic.LoadInt32(&kvm.abort) == 1
 }
 
+// precompiles returns the precompiled contracts of the block the KVM runs in.
+func (kvm *KVM) precompiles() map[common.Address]PrecompiledContract {
+	if kvm.Chain != nil && kvm.BlockHeight != nil && kvm.Chain.Config().IsPrecompilesV1(kvm.BlockHeight.Uint64()) {
+		return PrecompiledContractsV1
+	}
+	return PrecompiledContractsV0
+}
+
 func (kvm *KVM) IsZeroFee() bool {
 	return kvm.vmConfig.IsZeroFee
 }
@@ -161,7 +176,7 @@ func (kvm *KVM) Call(caller base.ContractRef, addr common.Address, input []byte,
 		snapshot = kvm.GetStateDB().Snapshot()
 	)
 	if !kvm.GetStateDB().Exist(addr) {
-		precompiles := PrecompiledContractsV0
+		precompiles := kvm.precompiles()
 		if precompiles[addr] == nil && value.Sign() == 0 {
 			/* TODO(huny@): Add tracer later
 			// Calling a non existing account, don't do antything, but ping the tracer
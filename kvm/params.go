@@ -73,6 +73,12 @@ const (
 	IdentityBaseGas     uint64 = 15   // Base price for a data copy operation
 	IdentityPerWordGas  uint64 = 3    // Per-work price for a data copy operation
 
+	ModExpQuadCoeffDiv      uint64 = 20    // Divisor for the quadratic particle of the big int modular exponentiation
+	Bn256AddGas             uint64 = 150   // Gas needed for an elliptic curve addition
+	Bn256ScalarMulGas       uint64 = 6000  // Gas needed for an elliptic curve scalar multiplication
+	Bn256PairingBaseGas     uint64 = 45000 // Base price for an elliptic curve pairing check
+	Bn256PairingPerPointGas uint64 = 34000 // Per-point price for an elliptic curve pairing check
+
 	// BlockPartSizeBytes is the size of one block part.
 	BlockPartSizeBytes = 65536 // 64kB
 )
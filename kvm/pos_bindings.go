@@ -0,0 +1,59 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kardiachain/go-kardia/kai/base"
+	"github.com/kardiachain/go-kardia/kvm/smc/bindings"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// vmCaller implements bind.ContractCaller with static calls on an internal KVM,
+// letting the generated bindings of the PoS contracts read the state it runs on.
+type vmCaller struct {
+	vm *KVM
+}
+
+// CallContract implements bind.ContractCaller. The block height is ignored as
+// the call always runs against the state of the KVM.
+func (c vmCaller) CallContract(ctx context.Context, call bind.CallMsg, blockHeight uint64) ([]byte, error) {
+	if call.To == nil {
+		return nil, errors.New("internal call without contract address")
+	}
+	return StaticCall(c.vm, *call.To, call.Data)
+}
+
+// newMasterCaller binds the PoS master contract of the chain to the state of vm.
+func newMasterCaller(vm *KVM, bc base.BaseBlockChain) (*bindings.MasterCaller, error) {
+	return bindings.NewMasterCaller(bc.GetConsensusMasterSmartContract().Address, vmCaller{vm})
+}
+
+// newNodeCaller binds the PoS node contract at the given address to the state of vm.
+func newNodeCaller(vm *KVM, node common.Address) (*bindings.NodeCaller, error) {
+	return bindings.NewNodeCaller(node, vmCaller{vm})
+}
+
+// newStakerCaller binds the PoS staker contract at the given address to the state of vm.
+func newStakerCaller(vm *KVM, staker common.Address) (*bindings.StakerCaller, error) {
+	return bindings.NewStakerCaller(staker, vmCaller{vm})
+}
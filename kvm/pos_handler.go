@@ -21,6 +21,7 @@ package kvm
 import (
 	"fmt"
 	"github.com/kardiachain/go-kardia/kai/base"
+	"github.com/kardiachain/go-kardia/kvm/smc/bindings"
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
@@ -121,20 +122,11 @@ const (
 	}
 ]`
 	methodSetRewarded = "setRewarded"
-	methodIsRewarded = "isRewarded"
 	methodSaveReward = "saveReward"
-	methodGetNodeInfo = "getNodeInfo"
-	methodGetNodeAddressFromOwner = "getNodeAddressFromOwner"
 	methodClaimReward = "claimReward"
-	methodGetAvailableNodeIndex = "getAvailableNodeIndex"
-	methodGetAvailableNode = "getAvailableNode"
-	methodGetStakerInfo = "getStakerInfo"
 	methodNewConsensusPeriod = "newConsensusPeriod"
-	methodGetLatestValidatorsInfo = "getLatestValidatorsInfo"
-	methodGetLatestValidatorByIndex = "getLatestValidatorByIndex"
 	methodCollectValidators = "collectValidators"
 	methodIsViolatedNode = "isViolatedNode"
-	methodGetRejectedValidatedInfo = "getRejectedValidatedInfo"
 	methodAddNode = "addNode"
 	methodAddStaker = "addStaker"
 	methodCreateNode = "createNode"
@@ -147,16 +139,6 @@ var (
 )
 
 type (
-	availableNode struct {
-		NodeAddress common.Address `abi:"nodeAddress"`
-		Owner common.Address `abi:"owner"`
-		Stakes *big.Int `abi:"stakes"`
-		TotalStaker uint64 `abi:"totalStaker"`
-	}
-	stakerInfo struct {
-		Staker common.Address `abi:"staker"`
-		Amount *big.Int `abi:"amount"`
-	}
 	nodeInfo struct {
 		Owner common.Address `abi:"owner"`
 		NodeId string `abi:"nodeId"`
@@ -168,29 +150,12 @@ type (
 		Node common.Address `abi:"node"`
 		BlockHeight uint64  `abi:"blockHeight"`
 	}
-	validatorsInfo struct {
-		TotalNodes uint64 `abi:"totalNodes"`
-		StartAtBlock uint64 `abi:"startAtBlock"`
-		EndAtBlock uint64 `abi:"endAtBlock"`
-	}
-	nodeAddressFromOwner struct {
-		Node common.Address `abi:"node"`
-	}
 	validator struct {
 		Node common.Address `abi:"node"`
 		Owner common.Address `abi:"owner"`
 		Stakes *big.Int `abi:"stakes"`
 		TotalStaker uint64 `abi:"totalStaker"`
 	}
-	latestValidatorsInfo struct {
-		TotalNodes uint64 `abi:"totalNodes"`
-		StartAtBlock uint64 `abi:"startAtBlock"`
-		EndAtBlock uint64 `abi:"endAtBlock"`
-	}
-	rejectedValidatedInfo struct {
-		RejectedBlocks  *big.Int `abi:"rejectedBlocks"`
-		ValidatedBlocks *big.Int `abi:"validatedBlocks"`
-	}
 	createNodeStruct struct {
 		PublicKey        string  `abi:"publicKey"`
 		NodeName         string  `abi:"nodeName"`
@@ -218,6 +183,9 @@ func (p *posHandler) Run(in []byte, contract *Contract, ctx Context, state base.
 	if method, _, err = abi.GenerateInputStruct(pAbi, in); err != nil {
 		return nil, err
 	}
+	if err = checkSystemPhases(method, in, ctx); err != nil {
+		return nil, err
+	}
 	switch method.Name {
 	case methodClaimReward:
 		return in, handleClaimReward(method, in, contract, ctx, state)
@@ -233,13 +201,45 @@ func (p *posHandler) Run(in []byte, contract *Contract, ctx Context, state base.
 	return in, nil
 }
 
+// checkSystemPhases rejects the claimReward and newConsensusPeriod transactions of
+// the blocks from the activation of the system phases, whose begin and end block
+// phases apply them. Those of the previous blocks are still accepted, the one of
+// the block preceding the activation being included in a block after it.
+func checkSystemPhases(method *abi.Method, in []byte, ctx Context) error {
+	if method.Name != methodClaimReward && method.Name != methodNewConsensusPeriod {
+		return nil
+	}
+	if ctx.Chain == nil || !ctx.Chain.Config().IsSystemPhases(ctx.BlockHeight.Uint64()) {
+		return nil
+	}
+	var blockHeight uint64
+	if method.Name == methodClaimReward {
+		var n node
+		if err := method.Inputs.Unpack(&n, in[4:]); err != nil {
+			return err
+		}
+		blockHeight = n.BlockHeight
+	} else {
+		var is struct {
+			BlockHeight uint64 `abi:"blockHeight"`
+		}
+		if err := method.Inputs.Unpack(&is, in[4:]); err != nil {
+			return err
+		}
+		blockHeight = is.BlockHeight
+	}
+	if ctx.Chain.Config().IsSystemPhases(blockHeight) {
+		return fmt.Errorf("%v of block %v is applied by the system phases from block %v", method.Name, blockHeight, *ctx.Chain.Config().SystemPhasesHeight)
+	}
+	return nil
+}
+
 // handleClaimReward handles claimReward transaction sent from last proposer
 func handleClaimReward(method *abi.Method, input []byte, contract *Contract, ctx Context, state base.StateDB) error {
 	var (
 		owner common.Address
 		stakeAmount *big.Int
 		stakers map[common.Address]*big.Int
-		nInfo *nodeInfo
 		err error
 		n node
 	)
@@ -266,24 +266,34 @@ func handleClaimReward(method *abi.Method, input []byte, contract *Contract, ctx
 		return fmt.Errorf(fmt.Sprintf("caller:%v is not block:%v validator, expected:%v", contract.Caller().Hex(), n.BlockHeight, claimedBlock.Header().Validator.Hex()))
 	}
 
-	// get reward from previous block gasUsed + blockReward
+	return distributeReward(n.Node, owner, stakeAmount, stakers, n.BlockHeight, claimedBlock.Header().GasUsed, ctx, state)
+}
+
+// distributeReward pays the block reward plus the gas used by the block at the
+// given height to the node proposing it and to its stakers.
+func distributeReward(nodeAddress, owner common.Address, stakeAmount *big.Int, stakers map[common.Address]*big.Int, blockHeight, gasUsed uint64, ctx Context, state base.StateDB) error {
+	var (
+		nInfo *nodeInfo
+		err   error
+	)
+	// get reward from block gasUsed + blockReward
 	blockReward, _ := big.NewInt(0).SetString(ctx.Chain.GetBlockReward().String(), 10)
 
-	if claimedBlock.Header().GasUsed > 0 {
-		blockReward = big.NewInt(0).Add(blockReward, big.NewInt(0).SetUint64(claimedBlock.Header().GasUsed))
+	if gasUsed > 0 {
+		blockReward = big.NewInt(0).Add(blockReward, big.NewInt(0).SetUint64(gasUsed))
 	}
-	if nInfo, err = getNodeInfo(ctx.Chain, state, owner, n.Node); err != nil {
+	if nInfo, err = getNodeInfo(ctx.Chain, state, owner, nodeAddress); err != nil {
 		return err
 	}
 	stakersReward := big.NewInt(0).Mul(blockReward, big.NewInt(int64(nInfo.RewardPercentage)))
 	stakersReward = big.NewInt(0).Div(stakersReward, big.NewInt(100))
 	nodeReward := big.NewInt(0).Sub(blockReward, stakersReward)
 	// reward to node
-	if err = rewardToNode(n.Node, n.BlockHeight, nodeReward, ctx, state); err != nil {
+	if err = rewardToNode(nodeAddress, blockHeight, nodeReward, ctx, state); err != nil {
 		return err
 	}
 	// reward to stakers
-	return rewardToStakers(n.Node, stakeAmount, stakers, stakersReward, n.BlockHeight, ctx, state)
+	return rewardToStakers(nodeAddress, stakeAmount, stakers, stakersReward, blockHeight, ctx, state)
 }
 
 func handleNewConsensusPeriod(method *abi.Method, input []byte, contract *Contract, ctx Context, state base.StateDB) error {
@@ -331,27 +341,20 @@ func handleIsViolatedNode(method *abi.Method, input []byte, contract *Contract,
 		MaxViolatePercentage uint64         `abi:"maxViolatePercentage"`
 	}
 	var (
-		nodeAbi abi.ABI
-		err error
-		is inputStruct
-		rejectedValidatedInfo rejectedValidatedInfo
-		output []byte
+		nodeContract *bindings.NodeCaller
+		err          error
+		is           inputStruct
 	)
 	result := make([]byte, 32)
 	vm := newInternalKVM(contract.CallerAddress, ctx.Chain, state)
-	if nodeAbi, err = abi.JSON(strings.NewReader(ctx.Chain.GetConsensusNodeAbi())); err != nil {
-		return result, err
-	}
 	if err = method.Inputs.Unpack(&is, input[4:]); err != nil {
 		return result, err
 	}
-	if input, err = nodeAbi.Pack(methodGetRejectedValidatedInfo); err != nil {
-		return result, err
-	}
-	if output, err = StaticCall(vm, is.Node, input); err != nil {
+	if nodeContract, err = newNodeCaller(vm, is.Node); err != nil {
 		return result, err
 	}
-	if err = nodeAbi.Unpack(&rejectedValidatedInfo, methodGetRejectedValidatedInfo, output); err != nil {
+	rejectedValidatedInfo, err := nodeContract.GetRejectedValidatedInfo(nil)
+	if err != nil {
 		return result, err
 	}
 
@@ -0,0 +1,78 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvm
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// TestCheckSystemPhases crosses the activation of the system phases: the claimReward
+// and newConsensusPeriod transactions of the blocks before it are still accepted in
+// the blocks after it, those of the following blocks being rejected.
+func TestCheckSystemPhases(t *testing.T) {
+	pAbi, err := abi.JSON(strings.NewReader(PosHandlerAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	activation := uint64(10)
+	chain := &configChain{config: &types.ChainConfig{SystemPhasesHeight: &activation}}
+	node := common.HexToAddress("0x01")
+
+	tests := []struct {
+		method      string
+		blockHeight uint64 // height of the block claimed or starting the period
+		height      uint64 // height of the block including the transaction
+		ok          bool
+	}{
+		{methodClaimReward, 8, 9, true},
+		{methodClaimReward, 9, 10, true},
+		{methodClaimReward, 9, 12, true},
+		{methodClaimReward, 10, 11, false},
+		{methodNewConsensusPeriod, 9, 10, true},
+		{methodNewConsensusPeriod, 10, 11, false},
+	}
+	for _, tt := range tests {
+		var in []byte
+		if tt.method == methodClaimReward {
+			in, err = pAbi.Pack(tt.method, node, tt.blockHeight)
+		} else {
+			in, err = pAbi.Pack(tt.method, tt.blockHeight)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := Context{Chain: chain, BlockHeight: new(big.Int).SetUint64(tt.height)}
+		method := pAbi.Methods[tt.method]
+		err = checkSystemPhases(&method, in, ctx)
+		if (err == nil) != tt.ok {
+			t.Errorf("%v of block %d in block %d: err = %v, want accepted %v", tt.method, tt.blockHeight, tt.height, err, tt.ok)
+		}
+		if !tt.ok {
+			if _, err := (&posHandler{}).Run(in, nil, ctx, nil); err == nil {
+				t.Errorf("Run %v of block %d in block %d: expected error", tt.method, tt.blockHeight, tt.height)
+			}
+		}
+	}
+}
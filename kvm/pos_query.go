@@ -0,0 +1,309 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvm
+
+import (
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/kai/base"
+	"github.com/kardiachain/go-kardia/kvm/smc/bindings"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+// StakingNode is an available node of the PoS master contract.
+type StakingNode struct {
+	Address          common.Address // address of the node contract
+	Owner            common.Address
+	Validator        common.Address // address of the node key signing blocks
+	Name             string
+	RewardPercentage uint16 // share of the block rewards paid to the stakers
+	LockedPeriod     uint64 // number of blocks a stake is locked for
+	MinimumStakes    *big.Int
+	Balance          *big.Int
+	Stakes           *big.Int
+	Stakers          []*StakingStaker
+	RejectedBlocks   *big.Int
+	ValidatedBlocks  *big.Int
+}
+
+// StakingStaker is the stake of a staker contract in a node.
+type StakingStaker struct {
+	Address common.Address // address of the staker contract
+	Amount  *big.Int
+}
+
+// StakingValidators is the validator set of a consensus period.
+type StakingValidators struct {
+	StartHeight uint64
+	EndHeight   uint64
+	Nodes       []common.Address
+}
+
+// Delegation is a stake of a staker contract in a node.
+type Delegation struct {
+	Node         common.Address
+	Staker       common.Address
+	Amount       *big.Int
+	StartedAt    uint64
+	UnlockHeight uint64 // first height at which the stake can be withdrawn
+}
+
+// PendingNode is a node waiting for the votes of the available nodes to join them.
+type PendingNode struct {
+	Index  uint64
+	Node   common.Address
+	Stakes *big.Int
+	Votes  uint64
+}
+
+// DeleteRequest is a request to remove an available node, waiting for the votes
+// of the available nodes.
+type DeleteRequest struct {
+	Index     uint64
+	NodeIndex uint64 // index of the node among the available nodes
+	Node      common.Address
+	Stakes    *big.Int
+	Votes     uint64
+}
+
+// stakingReader reads the PoS contracts in a given state.
+type stakingReader struct {
+	vm     *KVM
+	master *bindings.MasterCaller
+}
+
+func newStakingReader(bc base.BaseBlockChain, st base.StateDB) (*stakingReader, error) {
+	vm := newInternalKVM(posHandlerAddress, bc, st)
+	master, err := newMasterCaller(vm, bc)
+	if err != nil {
+		return nil, err
+	}
+	return &stakingReader{vm: vm, master: master}, nil
+}
+
+func (r *stakingReader) availableNodes() ([]*StakingNode, error) {
+	total, err := r.master.GetTotalAvailableNodes(nil)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*StakingNode, 0, total.Uint64())
+	for i := uint64(1); i <= total.Uint64(); i++ {
+		node, err := r.availableNode(new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// availableNode returns the available node at the given index, starting at 1.
+func (r *stakingReader) availableNode(index *big.Int) (*StakingNode, error) {
+	available, err := r.master.GetAvailableNode(nil, index)
+	if err != nil {
+		return nil, err
+	}
+	nodeContract, err := newNodeCaller(r.vm, available.NodeAddress)
+	if err != nil {
+		return nil, err
+	}
+	details, err := nodeContract.GetNodeInfo(nil)
+	if err != nil {
+		return nil, err
+	}
+	violations, err := nodeContract.GetRejectedValidatedInfo(nil)
+	if err != nil {
+		return nil, err
+	}
+	node := &StakingNode{
+		Address:          available.NodeAddress,
+		Owner:            available.Owner,
+		Name:             details.NodeName,
+		RewardPercentage: details.RewardPercentage,
+		LockedPeriod:     details.LockedPeriod,
+		MinimumStakes:    details.MinimumStakes,
+		Balance:          details.Balance,
+		Stakes:           available.Stakes,
+		RejectedBlocks:   violations.RejectedBlocks,
+		ValidatedBlocks:  violations.ValidatedBlocks,
+	}
+	if pubKey, err := crypto.StringToPublicKey(details.NodeId); err == nil {
+		node.Validator = crypto.PubkeyToAddress(*pubKey)
+	}
+	// stakers are indexed from 1 to totalStaker-1
+	for i := uint64(1); i < available.TotalStaker; i++ {
+		info, err := r.master.GetStakerInfo(nil, available.NodeAddress, i)
+		if err != nil {
+			return nil, err
+		}
+		node.Stakers = append(node.Stakers, &StakingStaker{Address: info.Staker, Amount: info.Amount})
+	}
+	return node, nil
+}
+
+// GetAvailableNodes returns the available nodes of the PoS master contract in the
+// given state, the candidates of the next validator sets.
+func GetAvailableNodes(bc base.BaseBlockChain, st base.StateDB) ([]*StakingNode, error) {
+	r, err := newStakingReader(bc, st)
+	if err != nil {
+		return nil, err
+	}
+	return r.availableNodes()
+}
+
+// GetAvailableNode returns the available node of the given address in the given
+// state, nil if there is none.
+func GetAvailableNode(bc base.BaseBlockChain, st base.StateDB, node common.Address) (*StakingNode, error) {
+	r, err := newStakingReader(bc, st)
+	if err != nil {
+		return nil, err
+	}
+	index, err := r.master.GetAvailableNodeIndex(nil, node)
+	if err != nil {
+		return nil, err
+	}
+	if index.Sign() == 0 {
+		return nil, nil
+	}
+	return r.availableNode(index)
+}
+
+// GetLatestValidators returns the validator set of the latest consensus period
+// started by the PoS master contract in the given state. It is the set of the
+// next period once the validators of that period are collected.
+func GetLatestValidators(bc base.BaseBlockChain, st base.StateDB) (*StakingValidators, error) {
+	r, err := newStakingReader(bc, st)
+	if err != nil {
+		return nil, err
+	}
+	info, err := r.master.GetLatestValidatorsInfo(nil)
+	if err != nil {
+		return nil, err
+	}
+	vals := &StakingValidators{StartHeight: info.StartAtBlock, EndHeight: info.EndAtBlock}
+	for i := uint64(1); i <= info.TotalNodes; i++ {
+		val, err := r.master.GetLatestValidatorByIndex(nil, i)
+		if err != nil {
+			return nil, err
+		}
+		vals.Nodes = append(vals.Nodes, val.Node)
+	}
+	return vals, nil
+}
+
+// GetDelegations returns the stakes of the given delegator in the given state,
+// the delegator being either a staker contract or the owner of staker contracts.
+func GetDelegations(bc base.BaseBlockChain, st base.StateDB, delegator common.Address) ([]*Delegation, error) {
+	r, err := newStakingReader(bc, st)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := r.availableNodes()
+	if err != nil {
+		return nil, err
+	}
+	delegations := make([]*Delegation, 0)
+	owners := make(map[common.Address]common.Address)
+	for _, node := range nodes {
+		for _, staker := range node.Stakers {
+			stakerContract, err := newStakerCaller(r.vm, staker.Address)
+			if err != nil {
+				return nil, err
+			}
+			owner, ok := owners[staker.Address]
+			if !ok {
+				if owner, err = stakerContract.GetOwner(nil); err != nil {
+					return nil, err
+				}
+				owners[staker.Address] = owner
+			}
+			if staker.Address != delegator && owner != delegator {
+				continue
+			}
+			stake, err := stakerContract.GetStakeAmount(nil, node.Address)
+			if err != nil {
+				return nil, err
+			}
+			if !stake.Valid {
+				continue
+			}
+			// the staker withdraws once more than the locked period passed
+			delegations = append(delegations, &Delegation{
+				Node:         node.Address,
+				Staker:       staker.Address,
+				Amount:       stake.Amount,
+				StartedAt:    stake.StartedAt.Uint64(),
+				UnlockHeight: stake.StartedAt.Uint64() + node.LockedPeriod + 1,
+			})
+		}
+	}
+	return delegations, nil
+}
+
+// GetPendingNodes returns the nodes waiting for votes to join the available nodes
+// in the given state, leaving out the ones which joined already.
+func GetPendingNodes(bc base.BaseBlockChain, st base.StateDB) ([]*PendingNode, error) {
+	r, err := newStakingReader(bc, st)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.master.GetTotalPending(nil)
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]*PendingNode, 0)
+	for i := uint64(1); i <= total.Uint64(); i++ {
+		info, err := r.master.GetPendingNode(nil, i)
+		if err != nil {
+			return nil, err
+		}
+		index, err := r.master.GetAvailableNodeIndex(nil, info.NodeAddress)
+		if err != nil {
+			return nil, err
+		}
+		if index.Sign() > 0 {
+			continue
+		}
+		pending = append(pending, &PendingNode{Index: i, Node: info.NodeAddress, Stakes: info.Stakes, Votes: info.Vote})
+	}
+	return pending, nil
+}
+
+// GetDeleteRequests returns the requests to remove available nodes in the given
+// state along with their votes.
+func GetDeleteRequests(bc base.BaseBlockChain, st base.StateDB) ([]*DeleteRequest, error) {
+	r, err := newStakingReader(bc, st)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.master.GetTotalPendingDelete(nil)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*DeleteRequest, 0)
+	for i := uint64(1); i <= total.Uint64(); i++ {
+		info, err := r.master.GetRequestDeleteNode(nil, i)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, &DeleteRequest{Index: i, NodeIndex: info.NodeIndex, Node: info.NodeAddress, Stakes: info.Stakes, Votes: info.Vote})
+	}
+	return requests, nil
+}
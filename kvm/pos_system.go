@@ -0,0 +1,124 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kvm
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/kai/base"
+	"github.com/kardiachain/go-kardia/kvm/smc/bindings"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// BeginBlock applies the system changes due before the transactions of the block
+// of the given header: when the block is the one fetching the validators of the
+// next consensus period, it starts that period on behalf of the block proposer.
+func BeginBlock(bc base.BaseBlockChain, st base.StateDB, header *types.Header) error {
+	var (
+		masterAbi    abi.ABI
+		masterCaller *bindings.MasterCaller
+		input        []byte
+		endBlock     uint64
+		proposer     *validator
+		err          error
+	)
+	master := bc.GetConsensusMasterSmartContract()
+	if masterAbi, err = abi.JSON(strings.NewReader(master.ABI)); err != nil {
+		return err
+	}
+	vm := NewKVM(NewInternalKVMContext(posHandlerAddress, header, bc), st, Config{})
+	if masterCaller, err = newMasterCaller(vm, bc); err != nil {
+		return err
+	}
+	if _, _, endBlock, err = getLatestValidatorsInfo(masterCaller); err != nil {
+		return err
+	}
+	if endBlock != header.Height+bc.GetFetchNewValidatorsTime() {
+		return nil
+	}
+	if proposer, err = getProposerValidator(bc, st, header); err != nil {
+		return err
+	}
+	// collectValidators is restricted to validators, so it is called by the owner
+	// of the proposer node.
+	if input, err = masterAbi.Pack(methodCollectValidators); err != nil {
+		return err
+	}
+	vm = NewKVM(NewInternalKVMContext(proposer.Owner, header, bc), st, Config{})
+	_, err = InternalCall(vm, master.Address, input, big.NewInt(0))
+	return err
+}
+
+// EndBlock applies the system changes due after the transactions of the block of
+// the given header: it pays the block reward plus the gas used by the block to
+// the node of the block proposer and to its stakers.
+func EndBlock(bc base.BaseBlockChain, st base.StateDB, header *types.Header, gasUsed uint64) error {
+	var (
+		proposer *validator
+		err      error
+	)
+	if proposer, err = getProposerValidator(bc, st, header); err != nil {
+		return err
+	}
+	owner, stakeAmount, stakers, err := getAvailableNodeInfo(bc, st, posHandlerAddress, proposer.Node)
+	if err != nil {
+		return err
+	}
+	ctx := NewInternalKVMContext(posHandlerAddress, header, bc)
+	return distributeReward(proposer.Node, owner, stakeAmount, stakers, header.Height, gasUsed, ctx, st)
+}
+
+// getProposerValidator returns the validator of the latest consensus period whose
+// node key proposed the block of the given header.
+func getProposerValidator(bc base.BaseBlockChain, st base.StateDB, header *types.Header) (*validator, error) {
+	var (
+		master *bindings.MasterCaller
+		length uint64
+		err    error
+	)
+	vm := NewKVM(NewInternalKVMContext(posHandlerAddress, header, bc), st, Config{})
+	if master, err = newMasterCaller(vm, bc); err != nil {
+		return nil, err
+	}
+	if length, _, _, err = getLatestValidatorsInfo(master); err != nil {
+		return nil, err
+	}
+	for i := uint64(1); i <= length; i++ {
+		val, err := master.GetLatestValidatorByIndex(nil, i)
+		if err != nil {
+			return nil, err
+		}
+		n, err := getNodeInfo(bc, st, posHandlerAddress, val.Node)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := crypto.StringToPublicKey(n.NodeId)
+		if err != nil {
+			return nil, err
+		}
+		if crypto.PubkeyToAddress(*pubKey).Equal(header.Validator) {
+			return &validator{Node: val.Node, Owner: val.Owner, Stakes: val.Stakes, TotalStaker: val.TotalStaker}, nil
+		}
+	}
+	return nil, fmt.Errorf("proposer %v of block %v is not a validator", header.Validator.Hex(), header.Height)
+}
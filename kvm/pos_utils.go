@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"github.com/kardiachain/go-kardia/kai/base"
 	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm/smc/bindings"
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/crypto"
@@ -37,10 +38,11 @@ import (
 // ClaimReward is used to create claimReward transaction
 func ClaimReward(height uint64, bc base.BaseBlockChain, state *state.StateDB, txPool *tx_pool.TxPool) (*types.Transaction, error) {
 	var (
-		posAbi, masterAbi abi.ABI
-		err error
-		input, output []byte
-		nodeAddress nodeAddressFromOwner
+		posAbi abi.ABI
+		master *bindings.MasterCaller
+		node   common.Address
+		input  []byte
+		err    error
 	)
 	sender := bc.Config().BaseAccount.Address
 	privateKey := bc.Config().BaseAccount.PrivateKey
@@ -50,25 +52,17 @@ func ClaimReward(height uint64, bc base.BaseBlockChain, state *state.StateDB, tx
 		log.Error("fail to init posAbi", "err", err)
 		return nil, err
 	}
-	masterSmartContract := bc.GetConsensusMasterSmartContract()
-	if masterAbi, err = abi.JSON(strings.NewReader(masterSmartContract.ABI)); err != nil {
-		log.Error("fail to init masterAbi", "err", err)
+	if master, err = newMasterCaller(vm, bc); err != nil {
+		log.Error("fail to init master contract", "err", err)
 		return nil, err
 	}
 	// get node from sender
-	if input, err = masterAbi.Pack(methodGetNodeAddressFromOwner, sender); err != nil {
-		return nil, err
-	}
-	if output, err = StaticCall(vm, masterSmartContract.Address, input); err != nil {
+	if node, err = master.GetNodeAddressFromOwner(nil, sender); err != nil {
 		log.Error("fail to get node from sender", "err", err)
 		return nil, err
 	}
-	if err = masterAbi.Unpack(&nodeAddress, methodGetNodeAddressFromOwner, output); err != nil {
-		log.Error("fail to unpack output to nodeAddress", "err", err, "output", common.Bytes2Hex(output))
-		return nil, err
-	}
 	// create claimReward transaction
-	if input, err = posAbi.Pack(methodClaimReward, nodeAddress.Node, height); err != nil {
+	if input, err = posAbi.Pack(methodClaimReward, node, height); err != nil {
 		return nil, err
 	}
 	return generateTransaction(txPool.Nonce(sender), input, &privateKey)
@@ -77,29 +71,24 @@ func ClaimReward(height uint64, bc base.BaseBlockChain, state *state.StateDB, tx
 // NewConsensusPeriod is created by proposer.
 func NewConsensusPeriod(height uint64, bc base.BaseBlockChain, state *state.StateDB, txPool *tx_pool.TxPool) (*types.Transaction, error) {
 	var (
-		input, output []byte
-		posAbi, masterAbi abi.ABI
-		err error
-		vals validatorsInfo
+		input    []byte
+		posAbi   abi.ABI
+		master   *bindings.MasterCaller
+		endBlock uint64
+		err      error
 	)
 	sender := bc.Config().BaseAccount.Address
 	privateKey := bc.Config().BaseAccount.PrivateKey
 	vm := newInternalKVM(sender, bc, state)
 
-	if masterAbi, err = abi.JSON(strings.NewReader(bc.GetConsensusMasterSmartContract().ABI)); err != nil {
-		return nil, err
-	}
-	if input, err = masterAbi.Pack(methodGetLatestValidatorsInfo); err != nil {
+	if master, err = newMasterCaller(vm, bc); err != nil {
 		return nil, err
 	}
-	if output, err = StaticCall(vm, bc.GetConsensusMasterSmartContract().Address, input); err != nil {
-		return nil, err
-	}
-	if err = masterAbi.Unpack(&vals, methodGetLatestValidatorsInfo, output); err != nil {
+	if _, _, endBlock, err = getLatestValidatorsInfo(master); err != nil {
 		return nil, err
 	}
 	// height must behind EndAtBlock bc.GetFetchNewValidators() blocks.
-	if vals.EndAtBlock != height+bc.GetFetchNewValidatorsTime() {
+	if endBlock != height+bc.GetFetchNewValidatorsTime() {
 		return nil, nil
 	}
 	if posAbi, err = abi.JSON(strings.NewReader(PosHandlerAbi)); err != nil {
@@ -114,14 +103,12 @@ func NewConsensusPeriod(height uint64, bc base.BaseBlockChain, state *state.Stat
 // CollectValidatorSet collects new validators list based on current available nodes and start new consensus period
 func CollectValidatorSet(bc base.BaseBlockChain) (*types.ValidatorSet, error) {
 	var (
-		err error
-		n nodeInfo
-		input, output []byte
-		masterAbi, nodeAbi abi.ABI
+		err                          error
+		n                            *nodeInfo
+		master                       *bindings.MasterCaller
 		length, startBlock, endBlock uint64
-		pubKey *ecdsa.PublicKey
+		pubKey                       *ecdsa.PublicKey
 	)
-	masterAddress := bc.GetConsensusMasterSmartContract().Address
 	st, err := bc.State()
 	if err != nil {
 		return nil, err
@@ -130,25 +117,16 @@ func CollectValidatorSet(bc base.BaseBlockChain) (*types.ValidatorSet, error) {
 	ctx := NewInternalKVMContext(sender, bc.CurrentHeader(), bc)
 	vm := NewKVM(ctx, st, Config{})
 
-	if masterAbi, err = abi.JSON(strings.NewReader(bc.GetConsensusMasterSmartContract().ABI)); err != nil {
+	if master, err = newMasterCaller(vm, bc); err != nil {
 		return nil, err
 	}
-	if nodeAbi, err = abi.JSON(strings.NewReader(bc.GetConsensusNodeAbi())); err != nil {
-		return nil, err
-	}
-	if length, startBlock, endBlock, err = getLatestValidatorsInfo(vm, masterAbi, masterAddress); err != nil {
+	if length, startBlock, endBlock, err = getLatestValidatorsInfo(master); err != nil {
 		return nil, err
 	}
 	validators := make([]*types.Validator, 0)
-	for i:=uint64(1); i <= length; i++ {
-		var val validator
-		if input, err = masterAbi.Pack(methodGetLatestValidatorByIndex, i); err != nil {
-			return nil, err
-		}
-		if output, err = StaticCall(vm, masterAddress, input); err != nil {
-			return nil, err
-		}
-		if err = masterAbi.Unpack(&val, methodGetLatestValidatorByIndex, output); err != nil {
+	for i := uint64(1); i <= length; i++ {
+		val, err := master.GetLatestValidatorByIndex(nil, i)
+		if err != nil {
 			return nil, err
 		}
 		stakes := calculateVotingPower(val.Stakes)
@@ -156,13 +134,7 @@ func CollectValidatorSet(bc base.BaseBlockChain) (*types.ValidatorSet, error) {
 			return nil, fmt.Errorf("invalid stakes")
 		}
 		// get node info from node address
-		if input, err = nodeAbi.Pack(methodGetNodeInfo); err != nil {
-			return nil, err
-		}
-		if output, err = StaticCall(vm, val.Node, input); err != nil {
-			return nil, err
-		}
-		if err = nodeAbi.Unpack(&n, methodGetNodeInfo, output); err != nil {
+		if n, err = getNodeInfo(bc, st, sender, val.Node); err != nil {
 			return nil, err
 		}
 		if pubKey, err = crypto.StringToPublicKey(n.NodeId); err != nil {
@@ -174,20 +146,9 @@ func CollectValidatorSet(bc base.BaseBlockChain) (*types.ValidatorSet, error) {
 }
 
 // getLatestValidatorsInfo is used after collect validators process is done, node calls this function to get new validators set
-func getLatestValidatorsInfo(vm *KVM, masterAbi abi.ABI, masterAddress common.Address) (uint64, uint64, uint64, error) {
-	method := "getLatestValidatorsInfo"
-	var (
-		err error
-		input, output []byte
-		info latestValidatorsInfo
-	)
-	if input, err = masterAbi.Pack(method); err != nil {
-		return 0, 0, 0, err
-	}
-	if output, err = StaticCall(vm, masterAddress, input); err != nil {
-		return 0, 0, 0, err
-	}
-	if err = masterAbi.Unpack(&info, method, output); err != nil {
+func getLatestValidatorsInfo(master *bindings.MasterCaller) (uint64, uint64, uint64, error) {
+	info, err := master.GetLatestValidatorsInfo(nil)
+	if err != nil {
 		return 0, 0, 0, err
 	}
 	return info.TotalNodes, info.StartAtBlock, info.EndAtBlock, nil
@@ -247,9 +208,10 @@ func addLog(vm *KVM, rewardedAddress common.Address, rewardedAmount *big.Int, bl
 
 func rewardToNode(nodeAddress common.Address, blockHeight uint64, nodeReward *big.Int, ctx Context, state base.StateDB) error {
 	var (
-		masterABI abi.ABI
-		err error
-		input, output []byte
+		masterABI  abi.ABI
+		master     *bindings.MasterCaller
+		err        error
+		input      []byte
 		isRewarded bool
 	)
 	masterAddress := ctx.Chain.GetConsensusMasterSmartContract().Address
@@ -257,14 +219,11 @@ func rewardToNode(nodeAddress common.Address, blockHeight uint64, nodeReward *bi
 	if masterABI, err = abi.JSON(strings.NewReader(ctx.Chain.GetConsensusMasterSmartContract().ABI)); err != nil {
 		return err
 	}
-	// check if node has been rewarded in this blockHeight or not
-	if input, err = masterABI.Pack(methodIsRewarded, nodeAddress, blockHeight); err != nil {
-		return err
-	}
-	if output, err = StaticCall(vm, masterAddress, input); err != nil {
+	if master, err = newMasterCaller(vm, ctx.Chain); err != nil {
 		return err
 	}
-	if err = masterABI.Unpack(&isRewarded, methodIsRewarded, output); err != nil {
+	// check if node has been rewarded in this blockHeight or not
+	if isRewarded, err = master.IsRewarded(nil, nodeAddress, blockHeight); err != nil {
 		return err
 	}
 	if isRewarded {
@@ -310,53 +269,32 @@ func rewardToStakers(nodeAddress common.Address, totalStakes *big.Int, stakers m
 }
 
 func getAvailableNodeInfo(bc base.BaseBlockChain, st base.StateDB, sender, node common.Address) (common.Address, *big.Int, map[common.Address]*big.Int, error) {
-	master := bc.GetConsensusMasterSmartContract()
 	var (
-		err error
-		input []byte
-		output []byte
+		err    error
 		stakes *big.Int
-		index *big.Int
-		nodeInfo availableNode
-		masterAbi abi.ABI
+		index  *big.Int
+		master *bindings.MasterCaller
 	)
 	owner := common.Address{}
 	stakers := make(map[common.Address]*big.Int)
 	vm := newInternalKVM(sender, bc, st)
-	if masterAbi, err = abi.JSON(strings.NewReader(master.ABI)); err != nil {
+	if master, err = newMasterCaller(vm, bc); err != nil {
 		return owner, stakes, stakers, err
 	}
 	// get nodeIndex
-	if input, err = masterAbi.Pack(methodGetAvailableNodeIndex, node); err != nil {
-		return owner, stakes, stakers, err
-	}
-	if output, err = StaticCall(vm, master.Address, input); err != nil {
-		return owner, stakes, stakers, err
-	}
-	if err = masterAbi.Unpack(&index, methodGetAvailableNodeIndex, output); err != nil {
+	if index, err = master.GetAvailableNodeIndex(nil, node); err != nil {
 		return owner, stakes, stakers, err
 	}
 	if index.Uint64() == 0 {
 		return owner, stakes, stakers, fmt.Errorf(fmt.Sprintf("cannot find node:%v info", node.Hex()))
 	}
-	if input, err = masterAbi.Pack(methodGetAvailableNode, index); err != nil {
-		return owner, stakes, stakers, err
-	}
-	if output, err = StaticCall(vm, master.Address, input); err != nil {
-		return owner, stakes, stakers, err
-	}
-	if err = masterAbi.Unpack(&nodeInfo, methodGetAvailableNode, output); err != nil {
+	nodeInfo, err := master.GetAvailableNode(nil, index)
+	if err != nil {
 		return owner, stakes, stakers, err
 	}
 	for i := uint64(1); i < nodeInfo.TotalStaker; i++ {
-		var info stakerInfo
-		if input, err = masterAbi.Pack(methodGetStakerInfo, node, i); err != nil {
-			return owner, stakes, stakers, err
-		}
-		if output, err = StaticCall(vm, master.Address, input); err != nil {
-			return owner, stakes, stakers, err
-		}
-		if err = masterAbi.Unpack(&info, methodGetStakerInfo, output); err != nil {
+		info, err := master.GetStakerInfo(nil, node, i)
+		if err != nil {
 			return owner, stakes, stakers, err
 		}
 		stakers[info.Staker] = info.Amount
@@ -365,24 +303,19 @@ func getAvailableNodeInfo(bc base.BaseBlockChain, st base.StateDB, sender, node
 }
 
 func getNodeInfo(bc base.BaseBlockChain, st base.StateDB, sender, node common.Address) (*nodeInfo, error) {
-	var (
-		input, output []byte
-		nodeAbi abi.ABI
-		nInfo nodeInfo
-		err error
-	)
-	vm := newInternalKVM(sender, bc, st)
-	if nodeAbi, err = abi.JSON(strings.NewReader(bc.GetConsensusNodeAbi())); err != nil {
-		return nil, err
-	}
-	if input, err = nodeAbi.Pack(methodGetNodeInfo); err != nil {
-		return nil, err
-	}
-	if output, err = StaticCall(vm, node, input); err != nil {
+	nodeContract, err := newNodeCaller(newInternalKVM(sender, bc, st), node)
+	if err != nil {
 		return nil, err
 	}
-	if err = nodeAbi.Unpack(&nInfo, methodGetNodeInfo, output); err != nil {
+	info, err := nodeContract.GetNodeInfo(nil)
+	if err != nil {
 		return nil, err
 	}
-	return &nInfo, nil
+	return &nodeInfo{
+		Owner:            info.Owner,
+		NodeId:           info.NodeId,
+		NodeName:         info.NodeName,
+		RewardPercentage: info.RewardPercentage,
+		Balance:          info.Balance,
+	}, nil
 }
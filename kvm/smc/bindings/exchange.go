@@ -0,0 +1,302 @@
+// Code generated by abigen. DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.BytesToAddress
+	_ = types.NewTransaction
+)
+
+// ExchangeABI is the input ABI used to generate the binding from.
+const ExchangeABI = "[{\"constant\":false,\"inputs\":[{\"name\":\"fromType\",\"type\":\"string\"},{\"name\":\"toType\",\"type\":\"string\"},{\"name\":\"fromAddress\",\"type\":\"string\"},{\"name\":\"receiver\",\"type\":\"string\"},{\"name\":\"txid\",\"type\":\"string\"},{\"name\":\"amount\",\"type\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"addOrder\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"setOwner\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"fromType\",\"type\":\"string\"},{\"name\":\"toType\",\"type\":\"string\"},{\"name\":\"fromAmount\",\"type\":\"uint256\"},{\"name\":\"receivedAmount\",\"type\":\"uint256\"}],\"name\":\"updateRate\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"txid\",\"type\":\"string\"}],\"name\":\"getMatchingResult\",\"outputs\":[{\"name\":\"results\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"user\",\"type\":\"address\"}],\"name\":\"deAuthorizedUser\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"_type\",\"type\":\"string\"}],\"name\":\"getAddressFromType\",\"outputs\":[{\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"_address\",\"type\":\"string\"},{\"name\":\"fromType\",\"type\":\"string\"},{\"name\":\"toType\",\"type\":\"string\"}],\"name\":\"getAllOrders\",\"outputs\":[{\"name\":\"orders\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getOwner\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"fromType\",\"type\":\"string\"},{\"name\":\"toType\",\"type\":\"string\"}],\"name\":\"getPendingOrders\",\"outputs\":[{\"name\":\"orders\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"user\",\"type\":\"address\"}],\"name\":\"authorizedUser\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"txId\",\"type\":\"string\"}],\"name\":\"getOrderByTxIdPublic\",\"outputs\":[{\"name\":\"strOrder\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"fromType\",\"type\":\"string\"},{\"name\":\"toType\",\"type\":\"string\"}],\"name\":\"getRate\",\"outputs\":[{\"name\":\"fromAmount\",\"type\":\"uint256\"},{\"name\":\"receivedAmount\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"txId\",\"type\":\"string\"}],\"name\":\"hasTxId\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"txId\",\"type\":\"string\"},{\"name\":\"kardiaTxId\",\"type\":\"string\"}],\"name\":\"updateKardiaTx\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"txId\",\"type\":\"string\"},{\"name\":\"targetTxId\",\"type\":\"string\"}],\"name\":\"updateTargetTx\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_type\",\"type\":\"string\"},{\"name\":\"_address\",\"type\":\"string\"}],\"name\":\"updateAvailableType\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"user\",\"type\":\"address\"}],\"name\":\"isAuthorized\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Exchange is an auto generated Go binding around a Kardia contract.
+type Exchange struct {
+	ExchangeCaller     // Read-only binding to the contract
+	ExchangeTransactor // Write-only binding to the contract
+	ExchangeFilterer   // Log filterer for contract events
+}
+
+// ExchangeCaller is an auto generated read-only Go binding around a Kardia contract.
+type ExchangeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ExchangeTransactor is an auto generated write-only Go binding around a Kardia contract.
+type ExchangeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ExchangeFilterer is an auto generated log filtering Go binding around a Kardia contract events.
+type ExchangeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NewExchange creates a new instance of Exchange, bound to a specific deployed contract.
+func NewExchange(address common.Address, backend bind.ContractBackend) (*Exchange, error) {
+	contract, err := bindExchange(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Exchange{ExchangeCaller: ExchangeCaller{contract: contract}, ExchangeTransactor: ExchangeTransactor{contract: contract}, ExchangeFilterer: ExchangeFilterer{contract: contract}}, nil
+}
+
+// NewExchangeCaller creates a new read-only instance of Exchange, bound to a specific deployed contract.
+func NewExchangeCaller(address common.Address, caller bind.ContractCaller) (*ExchangeCaller, error) {
+	contract, err := bindExchange(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeCaller{contract: contract}, nil
+}
+
+// NewExchangeTransactor creates a new write-only instance of Exchange, bound to a specific deployed contract.
+func NewExchangeTransactor(address common.Address, transactor bind.ContractTransactor) (*ExchangeTransactor, error) {
+	contract, err := bindExchange(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeTransactor{contract: contract}, nil
+}
+
+// NewExchangeFilterer creates a new log filterer instance of Exchange, bound to a specific deployed contract.
+func NewExchangeFilterer(address common.Address, filterer bind.ContractFilterer) (*ExchangeFilterer, error) {
+	contract, err := bindExchange(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeFilterer{contract: contract}, nil
+}
+
+// bindExchange binds a generic wrapper to an already deployed contract.
+func bindExchange(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ExchangeABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// GetAddressFromType is a free data retrieval call binding the contract method 0x4db0315a.
+//
+// Solidity: function getAddressFromType(_type string) constant returns(string)
+func (_Exchange *ExchangeCaller) GetAddressFromType(opts *bind.CallOpts, arg0 string) (string, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getAddressFromType", arg0)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, nil
+}
+
+// GetAllOrders is a free data retrieval call binding the contract method 0x60c76dbc.
+//
+// Solidity: function getAllOrders(_address string, fromType string, toType string) constant returns(orders string)
+func (_Exchange *ExchangeCaller) GetAllOrders(opts *bind.CallOpts, arg0 string, fromType string, toType string) (string, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getAllOrders", arg0, fromType, toType)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, nil
+}
+
+// GetMatchingResult is a free data retrieval call binding the contract method 0x42d4d52a.
+//
+// Solidity: function getMatchingResult(txid string) constant returns(results string)
+func (_Exchange *ExchangeCaller) GetMatchingResult(opts *bind.CallOpts, txid string) (string, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getMatchingResult", txid)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, nil
+}
+
+// GetOrderByTxIdPublic is a free data retrieval call binding the contract method 0xc109a06e.
+//
+// Solidity: function getOrderByTxIdPublic(txId string) constant returns(strOrder string)
+func (_Exchange *ExchangeCaller) GetOrderByTxIdPublic(opts *bind.CallOpts, txId string) (string, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getOrderByTxIdPublic", txId)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, nil
+}
+
+// GetOwner is a free data retrieval call binding the contract method 0x893d20e8.
+//
+// Solidity: function getOwner() constant returns(address)
+func (_Exchange *ExchangeCaller) GetOwner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getOwner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, nil
+}
+
+// GetPendingOrders is a free data retrieval call binding the contract method 0x97175ddb.
+//
+// Solidity: function getPendingOrders(fromType string, toType string) constant returns(orders string)
+func (_Exchange *ExchangeCaller) GetPendingOrders(opts *bind.CallOpts, fromType string, toType string) (string, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getPendingOrders", fromType, toType)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, nil
+}
+
+// GetRate is a free data retrieval call binding the contract method 0xe3f2804f.
+//
+// Solidity: function getRate(fromType string, toType string) constant returns(fromAmount uint256, receivedAmount uint256)
+func (_Exchange *ExchangeCaller) GetRate(opts *bind.CallOpts, fromType string, toType string) (struct {
+	FromAmount     *big.Int
+	ReceivedAmount *big.Int
+}, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "getRate", fromType, toType)
+
+	outstruct := new(struct {
+		FromAmount     *big.Int
+		ReceivedAmount *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.FromAmount = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.ReceivedAmount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, nil
+}
+
+// HasTxId is a free data retrieval call binding the contract method 0xe439799b.
+//
+// Solidity: function hasTxId(txId string) constant returns(bool)
+func (_Exchange *ExchangeCaller) HasTxId(opts *bind.CallOpts, txId string) (bool, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "hasTxId", txId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// IsAuthorized is a free data retrieval call binding the contract method 0xfe9fbb80.
+//
+// Solidity: function isAuthorized(user address) constant returns(bool)
+func (_Exchange *ExchangeCaller) IsAuthorized(opts *bind.CallOpts, user common.Address) (bool, error) {
+	var out []interface{}
+	err := _Exchange.contract.Call(opts, &out, "isAuthorized", user)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// AddOrder is a paid mutator transaction binding the contract method 0x1ccae936.
+//
+// Solidity: function addOrder(fromType string, toType string, fromAddress string, receiver string, txid string, amount uint256, timestamp uint256) returns()
+func (_Exchange *ExchangeTransactor) AddOrder(opts *bind.TransactOpts, fromType string, toType string, fromAddress string, receiver string, txid string, amount *big.Int, timestamp *big.Int) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "addOrder", fromType, toType, fromAddress, receiver, txid, amount, timestamp)
+}
+
+// AuthorizedUser is a paid mutator transaction binding the contract method 0xa6498032.
+//
+// Solidity: function authorizedUser(user address) returns()
+func (_Exchange *ExchangeTransactor) AuthorizedUser(opts *bind.TransactOpts, user common.Address) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "authorizedUser", user)
+}
+
+// DeAuthorizedUser is a paid mutator transaction binding the contract method 0x45ea12aa.
+//
+// Solidity: function deAuthorizedUser(user address) returns()
+func (_Exchange *ExchangeTransactor) DeAuthorizedUser(opts *bind.TransactOpts, user common.Address) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "deAuthorizedUser", user)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x40caae06.
+//
+// Solidity: function setOwner() returns()
+func (_Exchange *ExchangeTransactor) SetOwner(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "setOwner")
+}
+
+// UpdateAvailableType is a paid mutator transaction binding the contract method 0xfe40a890.
+//
+// Solidity: function updateAvailableType(_type string, _address string) returns()
+func (_Exchange *ExchangeTransactor) UpdateAvailableType(opts *bind.TransactOpts, arg0 string, arg1 string) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "updateAvailableType", arg0, arg1)
+}
+
+// UpdateKardiaTx is a paid mutator transaction binding the contract method 0xf147cf57.
+//
+// Solidity: function updateKardiaTx(txId string, kardiaTxId string) returns()
+func (_Exchange *ExchangeTransactor) UpdateKardiaTx(opts *bind.TransactOpts, txId string, kardiaTxId string) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "updateKardiaTx", txId, kardiaTxId)
+}
+
+// UpdateRate is a paid mutator transaction binding the contract method 0x414aff2b.
+//
+// Solidity: function updateRate(fromType string, toType string, fromAmount uint256, receivedAmount uint256) returns()
+func (_Exchange *ExchangeTransactor) UpdateRate(opts *bind.TransactOpts, fromType string, toType string, fromAmount *big.Int, receivedAmount *big.Int) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "updateRate", fromType, toType, fromAmount, receivedAmount)
+}
+
+// UpdateTargetTx is a paid mutator transaction binding the contract method 0xfd368651.
+//
+// Solidity: function updateTargetTx(txId string, targetTxId string) returns()
+func (_Exchange *ExchangeTransactor) UpdateTargetTx(opts *bind.TransactOpts, txId string, targetTxId string) (*types.Transaction, error) {
+	return _Exchange.contract.Transact(opts, "updateTargetTx", txId, targetTxId)
+}
@@ -0,0 +1,27 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package bindings contains the Go bindings of the system contracts, generated
+// from the ABIs in kvm/smc.
+package bindings
+
+//go:generate go run ../../../tool/abigen --abi ../Master.abi --pkg bindings --type Master --out master.go
+//go:generate go run ../../../tool/abigen --abi ../Node.abi --pkg bindings --type Node --out node.go
+//go:generate go run ../../../tool/abigen --abi ../Staker.abi --pkg bindings --type Staker --out staker.go
+//go:generate go run ../../../tool/abigen --abi ../Permission.abi --pkg bindings --type Permission --out permission.go
+//go:generate go run ../../../tool/abigen --abi ../Exchange.abi --pkg bindings --type Exchange --out exchange.go
@@ -0,0 +1,569 @@
+// Code generated by abigen. DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.BytesToAddress
+	_ = types.NewTransaction
+)
+
+// MasterABI is the input ABI used to generate the binding from.
+const MasterABI = "[{\"constant\":false,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"blockHeight\",\"type\":\"uint64\"}],\"name\":\"setRewarded\",\"outputs\":[{\"name\":\"success\",\"type\":\"bool\"},{\"name\":\"result\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"}],\"name\":\"getTotalStakes\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"}],\"name\":\"getAvailableNodeIndex\",\"outputs\":[{\"name\":\"index\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"hasPendingVoted\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"blockHeight\",\"type\":\"uint64\"}],\"name\":\"rejectBlockValidation\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"collectValidators\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"consensusPeriod\",\"type\":\"uint64\"}],\"name\":\"changeConsensusPeriod\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"votePending\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"getRequestDeleteNode\",\"outputs\":[{\"name\":\"nodeIndex\",\"type\":\"uint64\"},{\"name\":\"nodeAddress\",\"type\":\"address\"},{\"name\":\"stakes\",\"type\":\"uint256\"},{\"name\":\"vote\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"staker\",\"type\":\"address\"}],\"name\":\"addStaker\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"newMasterVersion\",\"type\":\"address\"}],\"name\":\"migrateBalance\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"nodeAddress\",\"type\":\"address\"}],\"name\":\"addPendingNode\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"blockHeight\",\"type\":\"uint64\"}],\"name\":\"isRewarded\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getTotalPending\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getTotalAvailableNodes\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"}],\"name\":\"isAvailableNodes\",\"outputs\":[{\"name\":\"\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"staker\",\"type\":\"address\"}],\"name\":\"isStaker\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"getStakerInfo\",\"outputs\":[{\"name\":\"staker\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"blockHeight\",\"type\":\"uint64\"}],\"name\":\"getRejectedStatus\",\"outputs\":[{\"name\":\"totalVoted\",\"type\":\"uint64\"},{\"name\":\"status\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"}],\"name\":\"addNode\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"getNodeAddressFromOwner\",\"outputs\":[{\"name\":\"node\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"getLatestValidatorByIndex\",\"outputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"owner\",\"type\":\"address\"},{\"name\":\"stakes\",\"type\":\"uint256\"},{\"name\":\"totalStaker\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"nodeAddress\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"stake\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"getAvailableNode\",\"outputs\":[{\"name\":\"nodeAddress\",\"type\":\"address\"},{\"name\":\"owner\",\"type\":\"address\"},{\"name\":\"stakes\",\"type\":\"uint256\"},{\"name\":\"totalStaker\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"GetTotalPendingDelete\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"requestDelete\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"voteDeleting\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"node\",\"type\":\"address\"},{\"name\":\"blockHeight\",\"type\":\"uint64\"}],\"name\":\"hasRejectedVote\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"getPendingNode\",\"outputs\":[{\"name\":\"nodeAddress\",\"type\":\"address\"},{\"name\":\"stakes\",\"type\":\"uint256\"},{\"name\":\"vote\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getLatestValidatorsInfo\",\"outputs\":[{\"name\":\"totalNodes\",\"type\":\"uint64\"},{\"name\":\"startAtBlock\",\"type\":\"uint64\"},{\"name\":\"endAtBlock\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"nodeAddress\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"withdraw\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"isValidator\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"consensusPeriod\",\"type\":\"uint64\"},{\"name\":\"maxValidators\",\"type\":\"uint64\"},{\"name\":\"maxViolatePercentage\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"}]"
+
+// Master is an auto generated Go binding around a Kardia contract.
+type Master struct {
+	MasterCaller     // Read-only binding to the contract
+	MasterTransactor // Write-only binding to the contract
+	MasterFilterer   // Log filterer for contract events
+}
+
+// MasterCaller is an auto generated read-only Go binding around a Kardia contract.
+type MasterCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MasterTransactor is an auto generated write-only Go binding around a Kardia contract.
+type MasterTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MasterFilterer is an auto generated log filtering Go binding around a Kardia contract events.
+type MasterFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NewMaster creates a new instance of Master, bound to a specific deployed contract.
+func NewMaster(address common.Address, backend bind.ContractBackend) (*Master, error) {
+	contract, err := bindMaster(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Master{MasterCaller: MasterCaller{contract: contract}, MasterTransactor: MasterTransactor{contract: contract}, MasterFilterer: MasterFilterer{contract: contract}}, nil
+}
+
+// NewMasterCaller creates a new read-only instance of Master, bound to a specific deployed contract.
+func NewMasterCaller(address common.Address, caller bind.ContractCaller) (*MasterCaller, error) {
+	contract, err := bindMaster(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MasterCaller{contract: contract}, nil
+}
+
+// NewMasterTransactor creates a new write-only instance of Master, bound to a specific deployed contract.
+func NewMasterTransactor(address common.Address, transactor bind.ContractTransactor) (*MasterTransactor, error) {
+	contract, err := bindMaster(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MasterTransactor{contract: contract}, nil
+}
+
+// NewMasterFilterer creates a new log filterer instance of Master, bound to a specific deployed contract.
+func NewMasterFilterer(address common.Address, filterer bind.ContractFilterer) (*MasterFilterer, error) {
+	contract, err := bindMaster(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &MasterFilterer{contract: contract}, nil
+}
+
+// bindMaster binds a generic wrapper to an already deployed contract.
+func bindMaster(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(MasterABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// GetTotalPendingDelete is a free data retrieval call binding the contract method 0xc349bf42.
+//
+// Solidity: function GetTotalPendingDelete() constant returns(uint256)
+func (_Master *MasterCaller) GetTotalPendingDelete(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "GetTotalPendingDelete")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetAvailableNode is a free data retrieval call binding the contract method 0xc1098bfc.
+//
+// Solidity: function getAvailableNode(index uint256) constant returns(nodeAddress address, owner address, stakes uint256, totalStaker uint64)
+func (_Master *MasterCaller) GetAvailableNode(opts *bind.CallOpts, index *big.Int) (struct {
+	NodeAddress common.Address
+	Owner       common.Address
+	Stakes      *big.Int
+	TotalStaker uint64
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getAvailableNode", index)
+
+	outstruct := new(struct {
+		NodeAddress common.Address
+		Owner       common.Address
+		Stakes      *big.Int
+		TotalStaker uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.NodeAddress = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Owner = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Stakes = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.TotalStaker = *abi.ConvertType(out[3], new(uint64)).(*uint64)
+
+	return *outstruct, nil
+}
+
+// GetAvailableNodeIndex is a free data retrieval call binding the contract method 0x089ebafc.
+//
+// Solidity: function getAvailableNodeIndex(node address) constant returns(index uint256)
+func (_Master *MasterCaller) GetAvailableNodeIndex(opts *bind.CallOpts, node common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getAvailableNodeIndex", node)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetLatestValidatorByIndex is a free data retrieval call binding the contract method 0xabceb07e.
+//
+// Solidity: function getLatestValidatorByIndex(index uint64) constant returns(node address, owner address, stakes uint256, totalStaker uint64)
+func (_Master *MasterCaller) GetLatestValidatorByIndex(opts *bind.CallOpts, index uint64) (struct {
+	Node        common.Address
+	Owner       common.Address
+	Stakes      *big.Int
+	TotalStaker uint64
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getLatestValidatorByIndex", index)
+
+	outstruct := new(struct {
+		Node        common.Address
+		Owner       common.Address
+		Stakes      *big.Int
+		TotalStaker uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Node = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Owner = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Stakes = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.TotalStaker = *abi.ConvertType(out[3], new(uint64)).(*uint64)
+
+	return *outstruct, nil
+}
+
+// GetLatestValidatorsInfo is a free data retrieval call binding the contract method 0xf0e08863.
+//
+// Solidity: function getLatestValidatorsInfo() constant returns(totalNodes uint64, startAtBlock uint64, endAtBlock uint64)
+func (_Master *MasterCaller) GetLatestValidatorsInfo(opts *bind.CallOpts) (struct {
+	TotalNodes   uint64
+	StartAtBlock uint64
+	EndAtBlock   uint64
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getLatestValidatorsInfo")
+
+	outstruct := new(struct {
+		TotalNodes   uint64
+		StartAtBlock uint64
+		EndAtBlock   uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TotalNodes = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	outstruct.StartAtBlock = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.EndAtBlock = *abi.ConvertType(out[2], new(uint64)).(*uint64)
+
+	return *outstruct, nil
+}
+
+// GetNodeAddressFromOwner is a free data retrieval call binding the contract method 0xa4509dd0.
+//
+// Solidity: function getNodeAddressFromOwner(owner address) constant returns(node address)
+func (_Master *MasterCaller) GetNodeAddressFromOwner(opts *bind.CallOpts, owner common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getNodeAddressFromOwner", owner)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, nil
+}
+
+// GetPendingNode is a free data retrieval call binding the contract method 0xedada194.
+//
+// Solidity: function getPendingNode(index uint64) constant returns(nodeAddress address, stakes uint256, vote uint64)
+func (_Master *MasterCaller) GetPendingNode(opts *bind.CallOpts, index uint64) (struct {
+	NodeAddress common.Address
+	Stakes      *big.Int
+	Vote        uint64
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getPendingNode", index)
+
+	outstruct := new(struct {
+		NodeAddress common.Address
+		Stakes      *big.Int
+		Vote        uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.NodeAddress = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Stakes = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Vote = *abi.ConvertType(out[2], new(uint64)).(*uint64)
+
+	return *outstruct, nil
+}
+
+// GetRejectedStatus is a free data retrieval call binding the contract method 0x82020b29.
+//
+// Solidity: function getRejectedStatus(node address, blockHeight uint64) constant returns(totalVoted uint64, status bool)
+func (_Master *MasterCaller) GetRejectedStatus(opts *bind.CallOpts, node common.Address, blockHeight uint64) (struct {
+	TotalVoted uint64
+	Status     bool
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getRejectedStatus", node, blockHeight)
+
+	outstruct := new(struct {
+		TotalVoted uint64
+		Status     bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TotalVoted = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	outstruct.Status = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, nil
+}
+
+// GetRequestDeleteNode is a free data retrieval call binding the contract method 0x2242e554.
+//
+// Solidity: function getRequestDeleteNode(index uint64) constant returns(nodeIndex uint64, nodeAddress address, stakes uint256, vote uint64)
+func (_Master *MasterCaller) GetRequestDeleteNode(opts *bind.CallOpts, index uint64) (struct {
+	NodeIndex   uint64
+	NodeAddress common.Address
+	Stakes      *big.Int
+	Vote        uint64
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getRequestDeleteNode", index)
+
+	outstruct := new(struct {
+		NodeIndex   uint64
+		NodeAddress common.Address
+		Stakes      *big.Int
+		Vote        uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.NodeIndex = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	outstruct.NodeAddress = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Stakes = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.Vote = *abi.ConvertType(out[3], new(uint64)).(*uint64)
+
+	return *outstruct, nil
+}
+
+// GetStakerInfo is a free data retrieval call binding the contract method 0x78866257.
+//
+// Solidity: function getStakerInfo(node address, index uint64) constant returns(staker address, amount uint256)
+func (_Master *MasterCaller) GetStakerInfo(opts *bind.CallOpts, node common.Address, index uint64) (struct {
+	Staker common.Address
+	Amount *big.Int
+}, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getStakerInfo", node, index)
+
+	outstruct := new(struct {
+		Staker common.Address
+		Amount *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Staker = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Amount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, nil
+}
+
+// GetTotalAvailableNodes is a free data retrieval call binding the contract method 0x478c6b05.
+//
+// Solidity: function getTotalAvailableNodes() constant returns(uint256)
+func (_Master *MasterCaller) GetTotalAvailableNodes(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getTotalAvailableNodes")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetTotalPending is a free data retrieval call binding the contract method 0x3bd05400.
+//
+// Solidity: function getTotalPending() constant returns(uint256)
+func (_Master *MasterCaller) GetTotalPending(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getTotalPending")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetTotalStakes is a free data retrieval call binding the contract method 0x05dcd6f9.
+//
+// Solidity: function getTotalStakes(node address) constant returns(uint256)
+func (_Master *MasterCaller) GetTotalStakes(opts *bind.CallOpts, node common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "getTotalStakes", node)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// HasPendingVoted is a free data retrieval call binding the contract method 0x096b3364.
+//
+// Solidity: function hasPendingVoted(index uint64) constant returns(bool)
+func (_Master *MasterCaller) HasPendingVoted(opts *bind.CallOpts, index uint64) (bool, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "hasPendingVoted", index)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// HasRejectedVote is a free data retrieval call binding the contract method 0xe977219e.
+//
+// Solidity: function hasRejectedVote(node address, blockHeight uint64) constant returns(bool)
+func (_Master *MasterCaller) HasRejectedVote(opts *bind.CallOpts, node common.Address, blockHeight uint64) (bool, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "hasRejectedVote", node, blockHeight)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// IsAvailableNodes is a free data retrieval call binding the contract method 0x52bbf7fd.
+//
+// Solidity: function isAvailableNodes(node address) constant returns(uint64)
+func (_Master *MasterCaller) IsAvailableNodes(opts *bind.CallOpts, node common.Address) (uint64, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "isAvailableNodes", node)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, nil
+}
+
+// IsRewarded is a free data retrieval call binding the contract method 0x324c60ad.
+//
+// Solidity: function isRewarded(node address, blockHeight uint64) constant returns(bool)
+func (_Master *MasterCaller) IsRewarded(opts *bind.CallOpts, node common.Address, blockHeight uint64) (bool, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "isRewarded", node, blockHeight)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// IsStaker is a free data retrieval call binding the contract method 0x6f1e8533.
+//
+// Solidity: function isStaker(staker address) constant returns(bool)
+func (_Master *MasterCaller) IsStaker(opts *bind.CallOpts, staker common.Address) (bool, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "isStaker", staker)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// IsValidator is a free data retrieval call binding the contract method 0xfacd743b.
+//
+// Solidity: function isValidator(sender address) constant returns(bool)
+func (_Master *MasterCaller) IsValidator(opts *bind.CallOpts, sender common.Address) (bool, error) {
+	var out []interface{}
+	err := _Master.contract.Call(opts, &out, "isValidator", sender)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, nil
+}
+
+// AddNode is a paid mutator transaction binding the contract method 0x9d95f1cc.
+//
+// Solidity: function addNode(node address) returns()
+func (_Master *MasterTransactor) AddNode(opts *bind.TransactOpts, node common.Address) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "addNode", node)
+}
+
+// AddPendingNode is a paid mutator transaction binding the contract method 0x29ac6a43.
+//
+// Solidity: function addPendingNode(nodeAddress address) returns()
+func (_Master *MasterTransactor) AddPendingNode(opts *bind.TransactOpts, nodeAddress common.Address) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "addPendingNode", nodeAddress)
+}
+
+// AddStaker is a paid mutator transaction binding the contract method 0x2466696e.
+//
+// Solidity: function addStaker(staker address) returns()
+func (_Master *MasterTransactor) AddStaker(opts *bind.TransactOpts, staker common.Address) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "addStaker", staker)
+}
+
+// ChangeConsensusPeriod is a paid mutator transaction binding the contract method 0x1f401c77.
+//
+// Solidity: function changeConsensusPeriod(consensusPeriod uint64) returns()
+func (_Master *MasterTransactor) ChangeConsensusPeriod(opts *bind.TransactOpts, consensusPeriod uint64) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "changeConsensusPeriod", consensusPeriod)
+}
+
+// CollectValidators is a paid mutator transaction binding the contract method 0x1e732909.
+//
+// Solidity: function collectValidators() returns()
+func (_Master *MasterTransactor) CollectValidators(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "collectValidators")
+}
+
+// MigrateBalance is a paid mutator transaction binding the contract method 0x2988e36b.
+//
+// Solidity: function migrateBalance(newMasterVersion address) returns()
+func (_Master *MasterTransactor) MigrateBalance(opts *bind.TransactOpts, newMasterVersion common.Address) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "migrateBalance", newMasterVersion)
+}
+
+// RejectBlockValidation is a paid mutator transaction binding the contract method 0x0d6d14c3.
+//
+// Solidity: function rejectBlockValidation(node address, blockHeight uint64) returns()
+func (_Master *MasterTransactor) RejectBlockValidation(opts *bind.TransactOpts, node common.Address, blockHeight uint64) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "rejectBlockValidation", node, blockHeight)
+}
+
+// RequestDelete is a paid mutator transaction binding the contract method 0xc97a7194.
+//
+// Solidity: function requestDelete(index uint64) returns()
+func (_Master *MasterTransactor) RequestDelete(opts *bind.TransactOpts, index uint64) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "requestDelete", index)
+}
+
+// SetRewarded is a paid mutator transaction binding the contract method 0x0089ba62.
+//
+// Solidity: function setRewarded(node address, blockHeight uint64) returns(success bool, result bytes)
+func (_Master *MasterTransactor) SetRewarded(opts *bind.TransactOpts, node common.Address, blockHeight uint64) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "setRewarded", node, blockHeight)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xadc9772e.
+//
+// Solidity: function stake(nodeAddress address, amount uint256) returns()
+func (_Master *MasterTransactor) Stake(opts *bind.TransactOpts, nodeAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "stake", nodeAddress, amount)
+}
+
+// VoteDeleting is a paid mutator transaction binding the contract method 0xd8ee18ad.
+//
+// Solidity: function voteDeleting(index uint64) returns()
+func (_Master *MasterTransactor) VoteDeleting(opts *bind.TransactOpts, index uint64) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "voteDeleting", index)
+}
+
+// VotePending is a paid mutator transaction binding the contract method 0x204a1bea.
+//
+// Solidity: function votePending(index uint64) returns()
+func (_Master *MasterTransactor) VotePending(opts *bind.TransactOpts, index uint64) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "votePending", index)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xf3fef3a3.
+//
+// Solidity: function withdraw(nodeAddress address, amount uint256) returns()
+func (_Master *MasterTransactor) Withdraw(opts *bind.TransactOpts, nodeAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _Master.contract.Transact(opts, "withdraw", nodeAddress, amount)
+}
@@ -0,0 +1,306 @@
+// Code generated by abigen. DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.BytesToAddress
+	_ = types.NewTransaction
+)
+
+// NodeABI is the input ABI used to generate the binding from.
+const NodeABI = "[{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"getRejectedBlockHeightByIndex\",\"outputs\":[{\"name\":\"\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"blockHeight\",\"type\":\"uint64\"},{\"name\":\"rejected\",\"type\":\"bool\"}],\"name\":\"updateBlock\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"nodeName\",\"type\":\"string\"},{\"name\":\"nodeId\",\"type\":\"string\"},{\"name\":\"rewardPercentage\",\"type\":\"uint16\"},{\"name\":\"lockedPeriod\",\"type\":\"uint64\"},{\"name\":\"minimumStakes\",\"type\":\"uint256\"}],\"name\":\"updateNode\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getBalance\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getNumberOfRejectedBlocks\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"withdraw\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint64\"}],\"name\":\"getValidatedBlockHeightByIndex\",\"outputs\":[{\"name\":\"\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getLockedPeriod\",\"outputs\":[{\"name\":\"\",\"type\":\"uint64\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getRejectedValidatedInfo\",\"outputs\":[{\"name\":\"rejectedBlocks\",\"type\":\"uint256\"},{\"name\":\"validatedBlocks\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getOwner\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getNodeInfo\",\"outputs\":[{\"name\":\"owner\",\"type\":\"address\"},{\"name\":\"nodeId\",\"type\":\"string\"},{\"name\":\"nodeName\",\"type\":\"string\"},{\"name\":\"rewardPercentage\",\"type\":\"uint16\"},{\"name\":\"lockedPeriod\",\"type\":\"uint64\"},{\"name\":\"minimumStakes\",\"type\":\"uint256\"},{\"name\":\"balance\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getMinimumStakes\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getNumberOfValidatedBlocks\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"master\",\"type\":\"address\"},{\"name\":\"nodeId\",\"type\":\"string\"},{\"name\":\"nodeName\",\"type\":\"string\"},{\"name\":\"rewardPercentage\",\"type\":\"uint16\"},{\"name\":\"lockedPeriod\",\"type\":\"uint64\"},{\"name\":\"minimumStakes\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"}]"
+
+// Node is an auto generated Go binding around a Kardia contract.
+type Node struct {
+	NodeCaller     // Read-only binding to the contract
+	NodeTransactor // Write-only binding to the contract
+	NodeFilterer   // Log filterer for contract events
+}
+
+// NodeCaller is an auto generated read-only Go binding around a Kardia contract.
+type NodeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NodeTransactor is an auto generated write-only Go binding around a Kardia contract.
+type NodeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NodeFilterer is an auto generated log filtering Go binding around a Kardia contract events.
+type NodeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NewNode creates a new instance of Node, bound to a specific deployed contract.
+func NewNode(address common.Address, backend bind.ContractBackend) (*Node, error) {
+	contract, err := bindNode(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{NodeCaller: NodeCaller{contract: contract}, NodeTransactor: NodeTransactor{contract: contract}, NodeFilterer: NodeFilterer{contract: contract}}, nil
+}
+
+// NewNodeCaller creates a new read-only instance of Node, bound to a specific deployed contract.
+func NewNodeCaller(address common.Address, caller bind.ContractCaller) (*NodeCaller, error) {
+	contract, err := bindNode(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeCaller{contract: contract}, nil
+}
+
+// NewNodeTransactor creates a new write-only instance of Node, bound to a specific deployed contract.
+func NewNodeTransactor(address common.Address, transactor bind.ContractTransactor) (*NodeTransactor, error) {
+	contract, err := bindNode(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeTransactor{contract: contract}, nil
+}
+
+// NewNodeFilterer creates a new log filterer instance of Node, bound to a specific deployed contract.
+func NewNodeFilterer(address common.Address, filterer bind.ContractFilterer) (*NodeFilterer, error) {
+	contract, err := bindNode(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeFilterer{contract: contract}, nil
+}
+
+// bindNode binds a generic wrapper to an already deployed contract.
+func bindNode(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(NodeABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// GetBalance is a free data retrieval call binding the contract method 0x12065fe0.
+//
+// Solidity: function getBalance() constant returns(uint256)
+func (_Node *NodeCaller) GetBalance(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getBalance")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetLockedPeriod is a free data retrieval call binding the contract method 0x439f8044.
+//
+// Solidity: function getLockedPeriod() constant returns(uint64)
+func (_Node *NodeCaller) GetLockedPeriod(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getLockedPeriod")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, nil
+}
+
+// GetMinimumStakes is a free data retrieval call binding the contract method 0xaec205f8.
+//
+// Solidity: function getMinimumStakes() constant returns(uint256)
+func (_Node *NodeCaller) GetMinimumStakes(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getMinimumStakes")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetNodeInfo is a free data retrieval call binding the contract method 0xa2f2112e.
+//
+// Solidity: function getNodeInfo() constant returns(owner address, nodeId string, nodeName string, rewardPercentage uint16, lockedPeriod uint64, minimumStakes uint256, balance uint256)
+func (_Node *NodeCaller) GetNodeInfo(opts *bind.CallOpts) (struct {
+	Owner            common.Address
+	NodeId           string
+	NodeName         string
+	RewardPercentage uint16
+	LockedPeriod     uint64
+	MinimumStakes    *big.Int
+	Balance          *big.Int
+}, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getNodeInfo")
+
+	outstruct := new(struct {
+		Owner            common.Address
+		NodeId           string
+		NodeName         string
+		RewardPercentage uint16
+		LockedPeriod     uint64
+		MinimumStakes    *big.Int
+		Balance          *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Owner = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.NodeId = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.NodeName = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.RewardPercentage = *abi.ConvertType(out[3], new(uint16)).(*uint16)
+	outstruct.LockedPeriod = *abi.ConvertType(out[4], new(uint64)).(*uint64)
+	outstruct.MinimumStakes = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.Balance = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+
+	return *outstruct, nil
+}
+
+// GetNumberOfRejectedBlocks is a free data retrieval call binding the contract method 0x375ecbb9.
+//
+// Solidity: function getNumberOfRejectedBlocks() constant returns(uint256)
+func (_Node *NodeCaller) GetNumberOfRejectedBlocks(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getNumberOfRejectedBlocks")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetNumberOfValidatedBlocks is a free data retrieval call binding the contract method 0xc5c5dd62.
+//
+// Solidity: function getNumberOfValidatedBlocks() constant returns(uint256)
+func (_Node *NodeCaller) GetNumberOfValidatedBlocks(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getNumberOfValidatedBlocks")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, nil
+}
+
+// GetOwner is a free data retrieval call binding the contract method 0x893d20e8.
+//
+// Solidity: function getOwner() constant returns(address)
+func (_Node *NodeCaller) GetOwner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getOwner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, nil
+}
+
+// GetRejectedBlockHeightByIndex is a free data retrieval call binding the contract method 0x0d9fb771.
+//
+// Solidity: function getRejectedBlockHeightByIndex(index uint64) constant returns(uint64)
+func (_Node *NodeCaller) GetRejectedBlockHeightByIndex(opts *bind.CallOpts, index uint64) (uint64, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getRejectedBlockHeightByIndex", index)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, nil
+}
+
+// GetRejectedValidatedInfo is a free data retrieval call binding the contract method 0x5a701358.
+//
+// Solidity: function getRejectedValidatedInfo() constant returns(rejectedBlocks uint256, validatedBlocks uint256)
+func (_Node *NodeCaller) GetRejectedValidatedInfo(opts *bind.CallOpts) (struct {
+	RejectedBlocks  *big.Int
+	ValidatedBlocks *big.Int
+}, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getRejectedValidatedInfo")
+
+	outstruct := new(struct {
+		RejectedBlocks  *big.Int
+		ValidatedBlocks *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RejectedBlocks = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.ValidatedBlocks = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, nil
+}
+
+// GetValidatedBlockHeightByIndex is a free data retrieval call binding the contract method 0x41cf7c29.
+//
+// Solidity: function getValidatedBlockHeightByIndex(index uint64) constant returns(uint64)
+func (_Node *NodeCaller) GetValidatedBlockHeightByIndex(opts *bind.CallOpts, index uint64) (uint64, error) {
+	var out []interface{}
+	err := _Node.contract.Call(opts, &out, "getValidatedBlockHeightByIndex", index)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, nil
+}
+
+// UpdateBlock is a paid mutator transaction binding the contract method 0x0e6ac7ea.
+//
+// Solidity: function updateBlock(blockHeight uint64, rejected bool) returns()
+func (_Node *NodeTransactor) UpdateBlock(opts *bind.TransactOpts, blockHeight uint64, rejected bool) (*types.Transaction, error) {
+	return _Node.contract.Transact(opts, "updateBlock", blockHeight, rejected)
+}
+
+// UpdateNode is a paid mutator transaction binding the contract method 0x1163b8a3.
+//
+// Solidity: function updateNode(nodeName string, nodeId string, rewardPercentage uint16, lockedPeriod uint64, minimumStakes uint256) returns()
+func (_Node *NodeTransactor) UpdateNode(opts *bind.TransactOpts, nodeName string, nodeId string, rewardPercentage uint16, lockedPeriod uint64, minimumStakes *big.Int) (*types.Transaction, error) {
+	return _Node.contract.Transact(opts, "updateNode", nodeName, nodeId, rewardPercentage, lockedPeriod, minimumStakes)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_Node *NodeTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Node.contract.Transact(opts, "withdraw")
+}
@@ -51,12 +51,13 @@ func JSON(reader io.Reader) (ABI, error) {
 // UnmarshalJSON implements json.Unmarshaler interface
 func (abi *ABI) UnmarshalJSON(data []byte) error {
 	var fields []struct {
-		Type      string
-		Name      string
-		Constant  bool
-		Anonymous bool
-		Inputs    []Argument
-		Outputs   []Argument
+		Type            string
+		Name            string
+		Constant        bool
+		StateMutability string
+		Anonymous       bool
+		Inputs          []Argument
+		Outputs         []Argument
 	}
 
 	if err := json.Unmarshal(data, &fields); err != nil {
@@ -75,7 +76,7 @@ func (abi *ABI) UnmarshalJSON(data []byte) error {
 		case "function", "":
 			abi.Methods[field.Name] = Method{
 				Name:    field.Name,
-				Const:   field.Constant,
+				Const:   field.Constant || field.StateMutability == "view" || field.StateMutability == "pure",
 				Inputs:  field.Inputs,
 				Outputs: field.Outputs,
 			}
@@ -138,6 +139,68 @@ func (abi ABI) Unpack(v interface{}, name string, output []byte) (err error) {
 	return fmt.Errorf("abi: could not locate named method or event")
 }
 
+// UnpackIntoMap unpacks the output of the named method or the data of the named
+// event into v, by argument name
+func (abi ABI) UnpackIntoMap(v map[string]interface{}, name string, output []byte) (err error) {
+	if len(output) == 0 {
+		return fmt.Errorf("abi: unmarshalling empty output")
+	}
+	if method, ok := abi.Methods[name]; ok {
+		if len(output)%32 != 0 {
+			return fmt.Errorf("abi: improperly formatted output")
+		}
+		return method.Outputs.UnpackIntoMap(v, output)
+	} else if event, ok := abi.Events[name]; ok {
+		return event.Inputs.UnpackIntoMap(v, output)
+	}
+	return fmt.Errorf("abi: could not locate named method or event")
+}
+
+// UnpackLog unpacks a log of the named event into v: its indexed arguments from
+// the topics and the others from the data
+func (abi ABI) UnpackLog(v interface{}, name string, topics []common.Hash, data []byte) error {
+	event, indexed, err := abi.logEvent(name, topics)
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if err := event.Inputs.Unpack(v, data); err != nil {
+			return err
+		}
+	}
+	return ParseTopics(v, event.Inputs.Indexed(), indexed)
+}
+
+// UnpackLogIntoMap unpacks a log of the named event into v, by argument name
+func (abi ABI) UnpackLogIntoMap(v map[string]interface{}, name string, topics []common.Hash, data []byte) error {
+	event, indexed, err := abi.logEvent(name, topics)
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if err := event.Inputs.UnpackIntoMap(v, data); err != nil {
+			return err
+		}
+	}
+	return ParseTopicsIntoMap(v, event.Inputs.Indexed(), indexed)
+}
+
+// logEvent returns the named event and the topics of its indexed arguments,
+// checking the log topics are the ones of the event
+func (abi ABI) logEvent(name string, topics []common.Hash) (*Event, []common.Hash, error) {
+	event, ok := abi.Events[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("abi: could not locate named event")
+	}
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.Id() {
+			return nil, nil, fmt.Errorf("abi: log is not a %s event", name)
+		}
+		topics = topics[1:]
+	}
+	return &event, topics, nil
+}
+
 // UnpackInput unpacks inputs of Method to v according to the abi specification
 func (abi ABI) UnpackInput(v interface{}, name string, output []byte) (err error) {
 	if len(output) == 0 {
@@ -165,6 +228,17 @@ func (abi *ABI) MethodById(sigdata []byte) (*Method, error) {
 	return nil, fmt.Errorf("no method with id: %#x", sigdata[:4])
 }
 
+// EventById looks up an event by the topic of its signature
+// returns nil if none found
+func (abi *ABI) EventById(topic common.Hash) (*Event, error) {
+	for _, event := range abi.Events {
+		if event.Id() == topic {
+			return &event, nil
+		}
+	}
+	return nil, fmt.Errorf("no event with id: %s", topic.Hex())
+}
+
 //==============================================================================
 // Method
 //==============================================================================
@@ -1093,4 +1093,99 @@ func TestABI_UnmarshalJSON(t *testing.T) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+const tupleJSON = `[
+	{"type":"function","name":"register","constant":false,
+		"inputs":[{"name":"info","type":"tuple","components":[{"name":"id","type":"uint256"},{"name":"name","type":"string"}]},{"name":"owners","type":"tuple[]","components":[{"name":"owner","type":"address"},{"name":"share","type":"uint8"}]}],
+		"outputs":[{"name":"info","type":"tuple","components":[{"name":"id","type":"uint256"},{"name":"name","type":"string"}]},{"name":"count","type":"uint256"}]}
+]`
+
+type tupleInfo struct {
+	Id   *big.Int
+	Name string
+}
+
+type tupleOwner struct {
+	Owner common.Address
+	Share uint8
+}
+
+func TestTupleMethodSignature(t *testing.T) {
+	abi, err := JSON(strings.NewReader(tupleJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig := abi.Methods["register"].Sig(); sig != "register((uint256,string),(address,uint8)[])" {
+		t.Errorf("signature is %s, want register((uint256,string),(address,uint8)[])", sig)
+	}
+}
+
+func TestTuplePack(t *testing.T) {
+	abi, err := JSON(strings.NewReader(tupleJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000042")
+	packed, err := abi.Pack("register", tupleInfo{big.NewInt(1), "ab"}, []tupleOwner{{owner, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000040" + // offset(info)
+		"00000000000000000000000000000000000000000000000000000000000000c0" + // offset(owners)
+		"0000000000000000000000000000000000000000000000000000000000000001" + // info.id
+		"0000000000000000000000000000000000000000000000000000000000000040" + // offset(info.name)
+		"0000000000000000000000000000000000000000000000000000000000000002" + // len(info.name)
+		"6162000000000000000000000000000000000000000000000000000000000000" + // info.name
+		"0000000000000000000000000000000000000000000000000000000000000001" + // len(owners)
+		"0000000000000000000000000000000000000000000000000000000000000042" + // owners[0].owner
+		"0000000000000000000000000000000000000000000000000000000000000002") // owners[0].share
+	if !bytes.Equal(packed[4:], want) {
+		t.Errorf("packed %x, want %x", packed[4:], want)
+	}
+
+	// the inputs unpack back
+	var inputs struct {
+		Info   tupleInfo
+		Owners []tupleOwner
+	}
+	if err := abi.Methods["register"].Inputs.Unpack(&inputs, packed[4:]); err != nil {
+		t.Fatal(err)
+	}
+	if inputs.Info.Id.Int64() != 1 || inputs.Info.Name != "ab" || len(inputs.Owners) != 1 || inputs.Owners[0] != (tupleOwner{owner, 2}) {
+		t.Errorf("unpacked %+v, want the packed inputs", inputs)
+	}
+}
+
+func TestTupleUnpack(t *testing.T) {
+	abi, err := JSON(strings.NewReader(tupleJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := abi.Methods["register"].Outputs.Pack(tupleInfo{big.NewInt(7), "kai"}, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out struct {
+		Info  tupleInfo
+		Count *big.Int
+	}
+	if err := abi.Unpack(&out, "register", output); err != nil {
+		t.Fatal(err)
+	}
+	if out.Info.Id.Int64() != 7 || out.Info.Name != "kai" || out.Count.Int64() != 3 {
+		t.Errorf("unpacked %+v, want info (7, kai) and count 3", out)
+	}
+
+	values := make(map[string]interface{})
+	if err := abi.UnpackIntoMap(values, "register", output); err != nil {
+		t.Fatal(err)
+	}
+	info := reflect.ValueOf(values["info"])
+	if info.Kind() != reflect.Struct || info.FieldByName("Name").String() != "kai" {
+		t.Errorf("info is %+v, want the tuple (7, kai)", values["info"])
+	}
+	if count, ok := values["count"].(*big.Int); !ok || count.Int64() != 3 {
+		t.Errorf("count is %v, want 3", values["count"])
+	}
+}
@@ -33,19 +33,24 @@ type Argument struct {
 
 type Arguments []Argument
 
+// ArgumentMarshaling is the JSON form of an argument, whose components are the
+// fields of a tuple argument.
+type ArgumentMarshaling struct {
+	Name       string
+	Type       string
+	Components []ArgumentMarshaling
+	Indexed    bool
+}
+
 // UnmarshalJSON implements json.Unmarshaler interface
 func (argument *Argument) UnmarshalJSON(data []byte) error {
-	var extarg struct {
-		Name    string
-		Type    string
-		Indexed bool
-	}
+	var extarg ArgumentMarshaling
 	err := json.Unmarshal(data, &extarg)
 	if err != nil {
 		return fmt.Errorf("argument json err: %v", err)
 	}
 
-	argument.Type, err = NewType(extarg.Type)
+	argument.Type, err = newType(extarg.Type, extarg.Components)
 	if err != nil {
 		return err
 	}
@@ -69,11 +74,7 @@ func (arguments Arguments) Pack(args ...interface{}) ([]byte, error) {
 	// input offset is the bytes offset for packed output
 	inputOffset := 0
 	for _, abiArg := range abiArgs {
-		if abiArg.Type.T == ArrayTy {
-			inputOffset += 32 * abiArg.Type.Size
-		} else {
-			inputOffset += 32
-		}
+		inputOffset += getTypeSize(abiArg.Type)
 	}
 	var ret []byte
 	for i, a := range args {
@@ -83,8 +84,8 @@ func (arguments Arguments) Pack(args ...interface{}) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		// check for a slice type (string, bytes, slice)
-		if input.Type.requiresLengthPrefix() {
+		// check for a dynamic type (string, bytes, slice, dynamic array or tuple)
+		if isDynamicType(input.Type) {
 			// calculate the offset
 			offset := inputOffset + len(variableInput)
 			// set the offset
@@ -126,19 +127,15 @@ func (arguments Arguments) NonIndexed() Arguments {
 	return ret
 }
 
-// Computes the full size of an array;
-// i.e. counting nested arrays, which count towards size for unpacking.
-func getArraySize(arr *Type) int {
-	size := arr.Size
-	// Arrays can be nested, with each element being the same size
-	arr = arr.Elem
-	for arr.T == ArrayTy {
-		// Keep multiplying by elem.Size while the elem is an array.
-		size *= arr.Size
-		arr = arr.Elem
+// Indexed returns the indexed arguments, the ones of the topics of events
+func (arguments Arguments) Indexed() Arguments {
+	var ret []Argument
+	for _, arg := range arguments {
+		if arg.Indexed {
+			ret = append(ret, arg)
+		}
 	}
-	// Now we have the full array size, including its children.
-	return size
+	return ret
 }
 
 // isTuple returns true for non-atomic constructs, like (uint,uint) or uint[]
@@ -242,6 +239,19 @@ func (arguments Arguments) unpackAtomic(v interface{}, marshalledValues []interf
 
 }
 
+// UnpackIntoMap performs the operation hexdata -> mapping of argument name to
+// argument value
+func (arguments Arguments) UnpackIntoMap(v map[string]interface{}, data []byte) error {
+	marshalledValues, err := arguments.UnpackValues(data)
+	if err != nil {
+		return err
+	}
+	for i, arg := range arguments.NonIndexed() {
+		v[arg.Name] = marshalledValues[i]
+	}
+	return nil
+}
+
 // UnpackValues can be used to unpack ABI-encoded hexdata according to the ABI-specification,
 // without supplying a struct to unpack into. Instead, this method returns a list containing the
 // values. An atomic argument will be a list with one element.
@@ -250,18 +260,19 @@ func (arguments Arguments) UnpackValues(data []byte) ([]interface{}, error) {
 	virtualArgs := 0
 	for index, arg := range arguments.NonIndexed() {
 		marshalledValue, err := toGoType((index+virtualArgs)*32, arg.Type, data)
-		if arg.Type.T == ArrayTy {
+		if (arg.Type.T == ArrayTy || arg.Type.T == TupleTy) && !isDynamicType(arg.Type) {
 			// If we have a static array, like [3]uint256, these are coded as
 			// just like uint256,uint256,uint256.
 			// This means that we need to add two 'virtual' arguments when
 			// we count the index from now on.
 			//
-			// Array values nested multiple levels deep are also encoded inline:
+			// Array values nested multiple levels deep and static tuples are
+			// also encoded inline:
 			// [2][3]uint256: uint256,uint256,uint256,uint256,uint256,uint256
 			//
-			// Calculate the full array size to get the correct offset for the next argument.
+			// Calculate the full size to get the correct offset for the next argument.
 			// Decrement it by 1, as the normal index increment is still applied.
-			virtualArgs += getArraySize(&arg.Type) - 1
+			virtualArgs += getTypeSize(arg.Type)/32 - 1
 		}
 		if err != nil {
 			return nil, err
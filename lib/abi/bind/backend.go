@@ -0,0 +1,96 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package bind
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+var (
+	// ErrNoCode is returned by call and transact operations for which the
+	// requested recipient contract to operate on has no code or returns nothing.
+	ErrNoCode = errors.New("no contract code at given address")
+
+	// ErrNoTransactor is returned by transact operations of contracts bound
+	// without a transactor, and ErrNoFilterer by their log filters.
+	ErrNoTransactor = errors.New("contract is not bound to a transactor")
+	ErrNoFilterer   = errors.New("contract is not bound to a filterer")
+)
+
+// CallMsg contains the parameters of a contract call.
+type CallMsg struct {
+	From     common.Address  // the sender of the 'transaction'
+	To       *common.Address // the destination contract (nil for contract creation)
+	Gas      uint64          // if 0, the call executes with near-infinite gas
+	GasPrice *big.Int        // wei <-> gas exchange ratio
+	Value    *big.Int        // amount of wei sent along with the call
+	Data     []byte          // input data, usually an ABI-encoded contract method invocation
+}
+
+// FilterQuery contains the options of a log filter.
+type FilterQuery struct {
+	FromBlock uint64 // beginning of the queried range
+	ToBlock   uint64 // end of the range, zero for the latest block
+
+	// Addresses restricts the logs to the ones emitted by these contracts.
+	Addresses []common.Address
+
+	// Topics restricts the logs by topic, position by position: an empty
+	// position matches any topic, otherwise the log topic is one of them.
+	Topics [][]common.Hash
+}
+
+// ContractCaller defines the methods needed to allow operating with contracts
+// on a read only basis.
+type ContractCaller interface {
+	// CallContract executes a contract call on the state after the block of the
+	// given height, or the latest one for zero.
+	CallContract(ctx context.Context, call CallMsg, blockHeight uint64) ([]byte, error)
+}
+
+// ContractTransactor defines the methods needed to allow operating with
+// contracts on a write only basis.
+type ContractTransactor interface {
+	// PendingNonceAt retrieves the next nonce of the account, pending
+	// transactions included.
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	// EstimateGas estimates the gas needed to execute the call as a transaction.
+	EstimateGas(ctx context.Context, call CallMsg) (uint64, error)
+	// SendTransaction submits the signed transaction for execution.
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// ContractFilterer defines the methods needed to access the logs of contracts.
+type ContractFilterer interface {
+	// FilterLogs returns the logs matching the query.
+	FilterLogs(ctx context.Context, query FilterQuery) ([]types.Log, error)
+}
+
+// ContractBackend defines the methods needed to work with contracts on a
+// read-write basis.
+type ContractBackend interface {
+	ContractCaller
+	ContractTransactor
+	ContractFilterer
+}
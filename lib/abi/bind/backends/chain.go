@@ -0,0 +1,209 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package backends implements the contract backends the generated bindings
+// operate through.
+package backends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/kai/base"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// errExecutionReverted is returned when a call or gas estimation is reverted
+// by the contract.
+var errExecutionReverted = errors.New("execution reverted")
+
+// Assert at compile time that ChainBackend implements bind.ContractBackend.
+var _ bind.ContractBackend = (*ChainBackend)(nil)
+
+// ChainBackend implements bind.ContractBackend over an in-process blockchain,
+// submitting transactions to its pool.
+type ChainBackend struct {
+	bc     base.BaseBlockChain
+	txPool *tx_pool.TxPool
+}
+
+// NewChainBackend creates a contract backend over the given blockchain. The
+// transaction pool may be nil for a read only backend.
+func NewChainBackend(bc base.BaseBlockChain, txPool *tx_pool.TxPool) *ChainBackend {
+	return &ChainBackend{bc: bc, txPool: txPool}
+}
+
+// CallContract executes a contract call against the state after the block at
+// the given height, the latest one if 0.
+func (b *ChainBackend) CallContract(ctx context.Context, call bind.CallMsg, blockHeight uint64) ([]byte, error) {
+	var (
+		statedb *state.StateDB
+		header  *types.Header
+		err     error
+	)
+	if blockHeight > 0 {
+		block := b.bc.GetBlockByHeight(blockHeight)
+		if block == nil {
+			return nil, fmt.Errorf("block %d not found", blockHeight)
+		}
+		statedb, err = b.bc.StateAt(blockHeight)
+		header = block.Header()
+	} else {
+		statedb, err = b.bc.State()
+		header = b.bc.CurrentHeader()
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, _, failed, err := callContract(ctx, b.bc, call, header, statedb)
+	if err != nil {
+		return nil, err
+	}
+	if failed {
+		return nil, errExecutionReverted
+	}
+	return res, nil
+}
+
+// PendingNonceAt returns the next nonce of the account, pending transactions
+// of the pool included.
+func (b *ChainBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if b.txPool == nil {
+		statedb, err := b.bc.State()
+		if err != nil {
+			return 0, err
+		}
+		return statedb.GetNonce(account), nil
+	}
+	return b.txPool.Nonce(account), nil
+}
+
+// EstimateGas binary searches the lowest gas limit the call executes with
+// against the latest state.
+func (b *ChainBackend) EstimateGas(ctx context.Context, call bind.CallMsg) (uint64, error) {
+	cap := call.Gas
+	if cap < kvm.TxGas {
+		cap = b.bc.CurrentBlock().GasLimit()
+	}
+	return estimateGas(cap, func(gas uint64) (bool, error) {
+		call.Gas = gas
+		statedb, err := b.bc.State()
+		if err != nil {
+			return false, err
+		}
+		_, _, failed, err := callContract(ctx, b.bc, call, b.bc.CurrentHeader(), statedb)
+		if err != nil {
+			// Out of gas and friends are reported as failures, not as errors
+			return false, nil
+		}
+		return !failed, nil
+	})
+}
+
+// SendTransaction adds the signed transaction to the pool.
+func (b *ChainBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.txPool == nil {
+		return bind.ErrNoTransactor
+	}
+	return b.txPool.AddLocal(tx)
+}
+
+// FilterLogs returns the logs of the canonical chain matching the query.
+func (b *ChainBackend) FilterLogs(ctx context.Context, query bind.FilterQuery) ([]types.Log, error) {
+	to := query.ToBlock
+	if to == 0 {
+		to = b.bc.CurrentBlock().Height()
+	}
+	logs := blockchain.FilterLogs(b.bc.DB(), query.FromBlock, to, query.Addresses, query.Topics)
+	res := make([]types.Log, len(logs))
+	for i, log := range logs {
+		res[i] = *log
+	}
+	return res, nil
+}
+
+// estimateGas binary searches the lowest gas limit up to cap for which the
+// executable callback succeeds.
+func estimateGas(cap uint64, executable func(gas uint64) (bool, error)) (uint64, error) {
+	lo, hi := kvm.TxGas-1, cap
+	for lo+1 < hi {
+		mid := (hi + lo) / 2
+		ok, err := executable(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	if hi == cap {
+		ok, err := executable(hi)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, fmt.Errorf("gas required exceeds allowance (%d) or always failing transaction", cap)
+		}
+	}
+	return hi, nil
+}
+
+// callContract executes the call on the given state of the chain, which it
+// modifies.
+func callContract(ctx context.Context, bc base.BaseBlockChain, call bind.CallMsg, header *types.Header, statedb *state.StateDB) ([]byte, uint64, bool, error) {
+	if call.Gas == 0 {
+		call.Gas = common.MaxInt64 / 2
+	}
+	if call.GasPrice == nil {
+		call.GasPrice = big.NewInt(0)
+	}
+	if call.Value == nil {
+		call.Value = big.NewInt(0)
+	}
+	msg := types.NewMessage(call.From, call.To, 0, call.Value, call.Gas, call.GasPrice, call.Data, false)
+
+	context := vm.NewKVMContext(msg, header, bc)
+	kvm := kvm.NewKVM(context, statedb, kvm.Config{})
+	// Cancel the execution when the caller gives up on it
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			kvm.Cancel()
+		case <-done:
+		}
+	}()
+	gp := new(types.GasPool).AddGas(common.MaxUint64)
+	res, gas, failed, err := blockchain.ApplyMessage(kvm, msg, gp)
+	if err == nil && kvm.Cancelled() {
+		err = ctx.Err()
+	}
+	return res, gas, failed, err
+}
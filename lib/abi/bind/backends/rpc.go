@@ -0,0 +1,240 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backends
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Assert at compile time that RPCBackend implements bind.ContractBackend.
+var _ bind.ContractBackend = (*RPCBackend)(nil)
+
+// RPCBackend implements bind.ContractBackend over the JSON-RPC API of a node
+// served through HTTP.
+type RPCBackend struct {
+	url    string
+	client *http.Client
+	id     uint64
+}
+
+// NewRPCBackend creates a contract backend talking to the node at the given
+// HTTP endpoint. A nil client uses http.DefaultClient.
+func NewRPCBackend(url string, client *http.Client) *RPCBackend {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RPCBackend{url: url, client: client}
+}
+
+// callArgs mirrors the call arguments of kai_kardiaCall and kai_estimateGas.
+type callArgs struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Gas      uint64   `json:"gas"`
+	GasPrice *big.Int `json:"gasPrice"`
+	Value    *big.Int `json:"value"`
+	Data     string   `json:"data"`
+}
+
+func newCallArgs(call bind.CallMsg) callArgs {
+	args := callArgs{
+		From:     call.From.Hex(),
+		Gas:      call.Gas,
+		GasPrice: call.GasPrice,
+		Value:    call.Value,
+		Data:     common.Encode(call.Data),
+	}
+	if call.To != nil {
+		args.To = call.To.Hex()
+	}
+	if args.GasPrice == nil {
+		args.GasPrice = new(big.Int)
+	}
+	if args.Value == nil {
+		args.Value = new(big.Int)
+	}
+	return args
+}
+
+// CallContract executes the call through kai_kardiaCall.
+func (b *RPCBackend) CallContract(ctx context.Context, call bind.CallMsg, blockHeight uint64) ([]byte, error) {
+	blockNr := rpc.LatestBlockNumber
+	if blockHeight > 0 {
+		blockNr = rpc.BlockNumber(blockHeight)
+	}
+	var res string
+	if err := b.call(ctx, &res, "kai_kardiaCall", newCallArgs(call), blockNr); err != nil {
+		return nil, err
+	}
+	return common.FromHex(res), nil
+}
+
+// PendingNonceAt retrieves the pending nonce of the account through account_nonce.
+func (b *RPCBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	err := b.call(ctx, &nonce, "account_nonce", account.Hex(), rpc.PendingBlockNumber)
+	return nonce, err
+}
+
+// EstimateGas estimates the gas of the call through kai_estimateGas.
+func (b *RPCBackend) EstimateGas(ctx context.Context, call bind.CallMsg) (uint64, error) {
+	var gas uint64
+	err := b.call(ctx, &gas, "kai_estimateGas", newCallArgs(call))
+	return gas, err
+}
+
+// SendTransaction submits the signed transaction through tx_sendRawTransaction.
+func (b *RPCBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	var hash string
+	return b.call(ctx, &hash, "tx_sendRawTransaction", common.Encode(data))
+}
+
+// rpcLog mirrors the log representation of the API.
+type rpcLog struct {
+	Address     string   `json:"address"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	BlockHeight uint64   `json:"blockHeight"`
+	TxHash      string   `json:"transactionHash"`
+	TxIndex     uint     `json:"transactionIndex"`
+	BlockHash   string   `json:"blockHash"`
+	Index       uint     `json:"logIndex"`
+	Removed     bool     `json:"removed"`
+}
+
+// FilterLogs retrieves the logs matching the query through kai_getLogs.
+func (b *RPCBackend) FilterLogs(ctx context.Context, query bind.FilterQuery) ([]types.Log, error) {
+	args := struct {
+		FromBlock uint64     `json:"fromBlock"`
+		ToBlock   uint64     `json:"toBlock"`
+		Addresses []string   `json:"addresses"`
+		Topics    [][]string `json:"topics"`
+	}{FromBlock: query.FromBlock, ToBlock: query.ToBlock}
+	for _, address := range query.Addresses {
+		args.Addresses = append(args.Addresses, address.Hex())
+	}
+	for _, sub := range query.Topics {
+		topics := make([]string, len(sub))
+		for i, topic := range sub {
+			topics[i] = topic.Hex()
+		}
+		args.Topics = append(args.Topics, topics)
+	}
+	var res []rpcLog
+	if err := b.call(ctx, &res, "kai_getLogs", args); err != nil {
+		return nil, err
+	}
+	logs := make([]types.Log, len(res))
+	for i, l := range res {
+		topics := make([]common.Hash, len(l.Topics))
+		for j, topic := range l.Topics {
+			topics[j] = common.HexToHash(topic)
+		}
+		logs[i] = types.Log{
+			Address:     common.HexToAddress(l.Address),
+			Topics:      topics,
+			Data:        common.FromHex(l.Data),
+			BlockHeight: l.BlockHeight,
+			TxHash:      common.HexToHash(l.TxHash),
+			TxIndex:     l.TxIndex,
+			BlockHash:   common.HexToHash(l.BlockHash),
+			Index:       l.Index,
+			Removed:     l.Removed,
+		}
+	}
+	return logs, nil
+}
+
+type rpcRequest struct {
+	Version string        `json:"jsonrpc"`
+	Id      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// rpcError is an error returned by the node.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *rpcError) Error() string {
+	if err.Message == "" {
+		return fmt.Sprintf("json-rpc error %d", err.Code)
+	}
+	return err.Message
+}
+
+// call performs a single JSON-RPC request and decodes its result into result.
+func (b *RPCBackend) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	body, err := json.Marshal(rpcRequest{
+		Version: "2.0",
+		Id:      atomic.AddUint64(&b.id, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", method, resp.Status)
+	}
+	var res rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	return json.Unmarshal(res.Result, result)
+}
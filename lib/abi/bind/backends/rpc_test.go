@@ -0,0 +1,139 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backends
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// newTestServer serves the given handlers as JSON-RPC methods over HTTP.
+func newTestServer(t *testing.T, handlers map[string]func(params []json.RawMessage) (interface{}, *rpcError)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Id     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request: %v", err)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.Id}
+		handler, ok := handlers[req.Method]
+		if !ok {
+			resp["error"] = &rpcError{Code: -32601, Message: "the method " + req.Method + " does not exist"}
+		} else if result, err := handler(req.Params); err != nil {
+			resp["error"] = err
+		} else {
+			resp["result"] = result
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestRPCBackend(t *testing.T) {
+	contract := common.HexToAddress("0x1234")
+	var sent *types.Transaction
+	server := newTestServer(t, map[string]func([]json.RawMessage) (interface{}, *rpcError){
+		"kai_kardiaCall": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var (
+				args   callArgs
+				height uint64
+			)
+			json.Unmarshal(params[0], &args)
+			json.Unmarshal(params[1], &height)
+			if common.HexToAddress(args.To) != contract || args.Data != "0x0102" || height != 5 {
+				return nil, &rpcError{Code: -32000, Message: "unexpected call"}
+			}
+			return "0x2a", nil
+		},
+		"account_nonce": func(params []json.RawMessage) (interface{}, *rpcError) {
+			if len(params) != 2 || string(params[1]) != `"pending"` {
+				return nil, &rpcError{Code: -32000, Message: "unexpected block"}
+			}
+			return 3, nil
+		},
+		"kai_estimateGas": func(params []json.RawMessage) (interface{}, *rpcError) {
+			return 21000, nil
+		},
+		"tx_sendRawTransaction": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var raw string
+			json.Unmarshal(params[0], &raw)
+			sent = new(types.Transaction)
+			if err := rlp.DecodeBytes(common.FromHex(raw), sent); err != nil {
+				return nil, &rpcError{Code: -32000, Message: err.Error()}
+			}
+			return sent.Hash().Hex(), nil
+		},
+		"kai_getLogs": func(params []json.RawMessage) (interface{}, *rpcError) {
+			return []rpcLog{{
+				Address:     contract.Hex(),
+				Topics:      []string{common.HexToHash("0x01").Hex()},
+				Data:        "2a",
+				BlockHeight: 9,
+			}}, nil
+		},
+	})
+	defer server.Close()
+
+	backend := NewRPCBackend(server.URL, nil)
+	ctx := context.Background()
+
+	res, err := backend.CallContract(ctx, bind.CallMsg{To: &contract, Data: []byte{1, 2}}, 5)
+	if err != nil || len(res) != 1 || res[0] != 0x2a {
+		t.Errorf("CallContract returned %x, %v", res, err)
+	}
+	if _, err := backend.CallContract(ctx, bind.CallMsg{To: &contract}, 0); err == nil || err.Error() != "unexpected call" {
+		t.Errorf("expected the node error, got %v", err)
+	}
+	if nonce, err := backend.PendingNonceAt(ctx, contract); err != nil || nonce != 3 {
+		t.Errorf("PendingNonceAt returned %d, %v", nonce, err)
+	}
+	if gas, err := backend.EstimateGas(ctx, bind.CallMsg{To: &contract}); err != nil || gas != 21000 {
+		t.Errorf("EstimateGas returned %d, %v", gas, err)
+	}
+
+	key, _ := crypto.GenerateKey()
+	tx, _ := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(1, contract, big.NewInt(1), 21000, big.NewInt(1), nil), key)
+	if err := backend.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if sent == nil || sent.Hash() != tx.Hash() {
+		t.Error("transaction not received by the node")
+	}
+
+	logs, err := backend.FilterLogs(ctx, bind.FilterQuery{Addresses: []common.Address{contract}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].Address != contract || logs[0].Data[0] != 0x2a || logs[0].BlockHeight != 9 {
+		t.Errorf("unexpected logs %+v", logs)
+	}
+}
@@ -0,0 +1,331 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/configs"
+	dualMsg "github.com/kardiachain/go-kardia/dualnode/message"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/ksml"
+	message "github.com/kardiachain/go-kardia/ksml/proto"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// blockInterval is the time between the blocks of the simulated chain.
+const blockInterval = 10 * time.Second
+
+// Assert at compile time that SimulatedBackend implements bind.ContractBackend.
+var _ bind.ContractBackend = (*SimulatedBackend)(nil)
+
+// SimulatedBackend implements bind.ContractBackend over an in-memory blockchain
+// without consensus, for contract unit tests. Sent transactions are executed
+// on a pending state right away and sealed into a block by Commit, along with
+// the transactions triggered by KSML parsers.
+type SimulatedBackend struct {
+	logger     log.Logger
+	db         types.StoreDB
+	blockchain *blockchain.BlockChain
+	chain      *ChainBackend   // Reads of the committed chain
+	txPool     *tx_pool.TxPool // Transactions triggered by KSML, nil until a parser is made
+
+	mu           sync.Mutex
+	pendingTxs   types.Transactions
+	pendingState *state.StateDB
+	pendingGas   *types.GasPool
+	usedGas      uint64
+	header       *types.Header // Header of the pending block
+}
+
+// NewSimulatedBackend creates a simulated blockchain whose genesis allocates
+// the given accounts, its blocks holding up to gasLimit of transactions.
+func NewSimulatedBackend(alloc genesis.GenesisAlloc, gasLimit uint64) (*SimulatedBackend, error) {
+	logger := log.New()
+	config := *configs.TestnetChainConfig
+	db := kvstore.NewStoreDB(memorydb.New())
+	g := &genesis.Genesis{Config: &config, GasLimit: gasLimit, Alloc: alloc}
+	chainConfig, _, err := genesis.SetupGenesisBlock(logger, db, g, nil)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := blockchain.NewBlockChain(logger, db, chainConfig)
+	if err != nil {
+		return nil, err
+	}
+	b := &SimulatedBackend{
+		logger:     logger,
+		db:         db,
+		blockchain: bc,
+		chain:      NewChainBackend(bc, nil),
+	}
+	if err := b.rollback(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Blockchain returns the underlying blockchain.
+func (b *SimulatedBackend) Blockchain() *blockchain.BlockChain {
+	return b.blockchain
+}
+
+// Commit seals the pending transactions into a new block, executed through the
+// state processor, and starts a new pending block on top of it.
+func (b *SimulatedBackend) Commit() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.txPool != nil {
+		if err := b.includePoolTxs(); err != nil {
+			return err
+		}
+	}
+	header := b.header
+	header.NumTxs = uint64(len(b.pendingTxs))
+	block := types.NewBlock(header, b.pendingTxs, &types.Commit{})
+
+	statedb, err := b.blockchain.State()
+	if err != nil {
+		return err
+	}
+	cfg := kvm.Config{IsZeroFee: b.blockchain.IsZeroFee}
+	receipts, _, _, err := b.blockchain.Processor().Process(block, statedb, cfg)
+	if err != nil {
+		return err
+	}
+	root, err := statedb.Commit(true)
+	if err != nil {
+		return err
+	}
+	if err := b.blockchain.CommitTrie(root); err != nil {
+		return err
+	}
+	b.blockchain.WriteAppHash(block.Height(), root)
+	if err := b.blockchain.WriteBlockWithoutState(block, block.MakePartSet(types.BlockPartSizeBytes), &types.Commit{}); err != nil {
+		return err
+	}
+	b.blockchain.WriteReceipts(receipts, block)
+	return b.rollback()
+}
+
+// Rollback drops the pending transactions.
+func (b *SimulatedBackend) Rollback() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.rollback()
+}
+
+// AdjustTime moves the time of the pending block forward. It can't be adjusted
+// once the block holds transactions.
+func (b *SimulatedBackend) AdjustTime(d time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.pendingTxs) > 0 {
+		return errors.New("could not adjust time of a non-empty block")
+	}
+	b.header.Time = new(big.Int).Add(b.header.Time, big.NewInt(int64(d/time.Second)))
+	return nil
+}
+
+// CallContract executes a contract call against the state after the committed
+// block at the given height, the latest one if 0.
+func (b *SimulatedBackend) CallContract(ctx context.Context, call bind.CallMsg, blockHeight uint64) ([]byte, error) {
+	return b.chain.CallContract(ctx, call, blockHeight)
+}
+
+// PendingNonceAt returns the next nonce of the account, pending transactions
+// included.
+func (b *SimulatedBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.pendingState.GetNonce(account), nil
+}
+
+// EstimateGas binary searches the lowest gas limit the call executes with
+// against the pending state.
+func (b *SimulatedBackend) EstimateGas(ctx context.Context, call bind.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cap := call.Gas
+	if cap < kvm.TxGas {
+		cap = b.header.GasLimit
+	}
+	return estimateGas(cap, func(gas uint64) (bool, error) {
+		call.Gas = gas
+		snap := b.pendingState.Snapshot()
+		defer b.pendingState.RevertToSnapshot(snap)
+
+		_, _, failed, err := callContract(ctx, b.blockchain, call, b.header, b.pendingState)
+		if err != nil {
+			// Out of gas and friends are reported as failures, not as errors
+			return false, nil
+		}
+		return !failed, nil
+	})
+}
+
+// SendTransaction executes the signed transaction on the pending state, adding
+// it to the pending block.
+func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.sendTransaction(tx)
+}
+
+// sendTransaction executes the transaction on the pending state. It assumes the
+// mutex is held.
+func (b *SimulatedBackend) sendTransaction(tx *types.Transaction) error {
+	b.pendingState.Prepare(tx.Hash(), common.Hash{}, len(b.pendingTxs))
+	snap := b.pendingState.Snapshot()
+	cfg := kvm.Config{IsZeroFee: b.blockchain.IsZeroFee}
+	if _, _, err := blockchain.ApplyTransaction(b.logger, b.blockchain, b.pendingGas, b.pendingState, b.header, tx, &b.usedGas, cfg); err != nil {
+		b.pendingState.RevertToSnapshot(snap)
+		return err
+	}
+	b.pendingTxs = append(b.pendingTxs, tx)
+	return nil
+}
+
+// FilterLogs returns the logs of the committed blocks matching the query.
+func (b *SimulatedBackend) FilterLogs(ctx context.Context, query bind.FilterQuery) ([]types.Log, error) {
+	return b.chain.FilterLogs(ctx, query)
+}
+
+// TransactionReceipt returns the receipt of a committed transaction, nil if
+// there is none.
+func (b *SimulatedBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	tx, blockHash, height, index := b.db.ReadTransaction(txHash)
+	if tx == nil {
+		return nil, nil
+	}
+	receipts := b.db.ReadReceipts(blockHash, height)
+	if uint64(len(receipts)) <= index {
+		return nil, nil
+	}
+	return receipts[index], nil
+}
+
+// NewKSMLParser returns a parser running the given KSML patterns of the dual
+// actions of proxyName, on the master contract deployed at the given address
+// with the given ABI. Contracts are called on the pending state and transactions
+// triggered from account, which becomes the base account of the chain; the
+// transactions are included in the pending block by Commit.
+func (b *SimulatedBackend) NewKSMLParser(proxyName string, account *types.BaseAccount, master common.Address, masterABI string,
+	patterns []string, msg *message.EventMessage, publish func(endpoint string, topic string, msg dualMsg.TriggerMessage) error) *ksml.Parser {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blockchain.Config().SetBaseAccount(account)
+	b.db.WriteEvent(&types.KardiaSmartcontract{
+		MasterSmc:  master.Hex(),
+		SmcAddress: master.Hex(),
+		MasterAbi:  masterABI,
+		SmcAbi:     masterABI,
+	})
+	if b.txPool == nil {
+		b.txPool = tx_pool.NewTxPool(tx_pool.DefaultTxPoolConfig, b.blockchain.Config(), b.blockchain)
+	}
+	parser := ksml.NewParser(proxyName, "", publish, b.blockchain, b.txPool, &master, patterns, msg, true)
+	// Read the pending block rather than the state of the pool, which catches
+	// up with the committed blocks asynchronously.
+	parser.StateDb = b.pendingState.Copy()
+	parser.Nonce = b.pendingState.GetNonce(account.Address)
+	return parser
+}
+
+// Close stops the transaction pool of the KSML parsers, if any.
+func (b *SimulatedBackend) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.txPool != nil {
+		b.txPool.Stop()
+	}
+}
+
+// includePoolTxs executes the transactions of the pool on the pending state,
+// skipping the ones the chain already holds, the pool catching up with the
+// new blocks asynchronously. It assumes the mutex is held.
+func (b *SimulatedBackend) includePoolTxs() error {
+	pending, err := b.txPool.Pending()
+	if err != nil {
+		return err
+	}
+	for from, txs := range pending {
+		for _, tx := range txs {
+			if tx.Nonce() < b.pendingState.GetNonce(from) {
+				continue
+			}
+			if err := b.sendTransaction(tx); err != nil {
+				return fmt.Errorf("pool transaction %x: %v", tx.Hash(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// rollback starts a new empty pending block on top of the head block. It
+// assumes the mutex is held.
+func (b *SimulatedBackend) rollback() error {
+	parent := b.blockchain.CurrentBlock()
+	statedb, err := b.blockchain.State()
+	if err != nil {
+		return err
+	}
+	meta := b.blockchain.LoadBlockMeta(parent.Height())
+	if meta == nil {
+		return fmt.Errorf("block meta %d not found", parent.Height())
+	}
+	parentTime := parent.Header().Time
+	if parentTime == nil {
+		parentTime = new(big.Int)
+	}
+	b.header = &types.Header{
+		Height:      parent.Height() + 1,
+		Time:        new(big.Int).Add(parentTime, big.NewInt(int64(blockInterval/time.Second))),
+		LastBlockID: meta.BlockID,
+		GasLimit:    parent.GasLimit(),
+		AppHash:     b.blockchain.ReadAppHash(parent.Height()),
+	}
+	b.pendingTxs = nil
+	b.pendingState = statedb
+	b.pendingGas = new(types.GasPool).AddGas(b.header.GasLimit)
+	b.usedGas = 0
+	return nil
+}
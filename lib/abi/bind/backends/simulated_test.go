@@ -0,0 +1,301 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backends
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	message "github.com/kardiachain/go-kardia/ksml/proto"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	counterABI = `[{"constant":false,"inputs":[{"name":"x","type":"uint8"}],"name":"set","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"get","outputs":[{"name":"","type":"uint8"}],"type":"function"}]`
+	counterBin = "608060405234801561001057600080fd5b5060da8061001f6000396000f30060806040526004361060485763ffffffff7c010000000000000000000000000000000000000000000000000000000060003504166324b8ba5f8114604d5780636d4ce63c146067575b600080fd5b348015605857600080fd5b50606560ff60043516608f565b005b348015607257600080fd5b50607960a5565b6040805160ff9092168252519081900360200190f35b6000805460ff191660ff92909216919091179055565b60005460ff16905600a165627a7a723058206cc1a54f543612d04d3f16b0bbb49e9ded9ccf6d47f7789fe3577260346ed44d0029"
+)
+
+// loggerCode is the creation code of a contract logging its call data under
+// the given topic.
+func loggerCode(topic common.Hash) []byte {
+	runtime := append([]byte{0x36, 0x60, 0x00, 0x60, 0x00, 0x37, 0x7f}, topic.Bytes()...)
+	runtime = append(runtime, 0x36, 0x60, 0x00, 0xa1, 0x00)
+	init := []byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}
+	return append(init, runtime...)
+}
+
+// counterCode is the runtime code of a contract counting the calls of inc(),
+// returned by get().
+func counterCode() []byte {
+	code := []byte{0x60, 0x00, 0x35, 0x60, 0xe0, 0x1c} // calldataload(0) >> 224
+	code = append(code, 0x80, 0x63)                    // dup1, push4
+	code = append(code, crypto.Keccak256([]byte("inc()"))[:4]...)
+	code = append(code, 0x14, 0x60, 0x1a, 0x57, 0x63) // eq, jumpi(inc), push4
+	code = append(code, crypto.Keccak256([]byte("get()"))[:4]...)
+	code = append(code, 0x14, 0x60, 0x25, 0x57, 0x00) // eq, jumpi(get), stop
+	// inc: sstore(0, sload(0) + 1)
+	code = append(code, 0x5b, 0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00)
+	// get: return sload(0)
+	return append(code, 0x5b, 0x60, 0x00, 0x54, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3)
+}
+
+func newTestBackend(t *testing.T) (*SimulatedBackend, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := bind.NewKeyedTransactor(key)
+	alloc := genesis.GenesisAlloc{auth.From: {Balance: big.NewInt(1e18)}}
+	sim, err := NewSimulatedBackend(alloc, 10000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sim, auth
+}
+
+func TestSimulatedBackendContract(t *testing.T) {
+	sim, auth := newTestBackend(t)
+	parsed, err := abi.JSON(strings.NewReader(counterABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, _, counter, err := bind.DeployContract(auth, parsed, common.FromHex(counterBin), sim)
+	if err != nil {
+		t.Fatalf("failed to deploy: %v", err)
+	}
+	get := func() uint8 {
+		var out []interface{}
+		if err := counter.Call(nil, &out, "get"); err != nil {
+			t.Fatalf("failed to call: %v", err)
+		}
+		return out[0].(uint8)
+	}
+	// The contract only exists once its block is committed
+	if err := counter.Call(nil, nil, "get"); err != bind.ErrNoCode {
+		t.Fatalf("call before commit: got %v, want %v", err, bind.ErrNoCode)
+	}
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if height := sim.Blockchain().CurrentBlock().Height(); height != 1 {
+		t.Fatalf("height: got %d, want 1", height)
+	}
+	if n := get(); n != 0 {
+		t.Fatalf("counter: got %d, want 0", n)
+	}
+
+	tx, err := counter.Transact(auth, "set", uint8(7))
+	if err != nil {
+		t.Fatalf("failed to transact: %v", err)
+	}
+	if n := get(); n != 0 {
+		t.Fatalf("uncommitted counter: got %d, want 0", n)
+	}
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if n := get(); n != 7 {
+		t.Fatalf("counter: got %d, want 7", n)
+	}
+	// Calls can run against past blocks
+	var out []interface{}
+	if err := counter.Call(&bind.CallOpts{BlockHeight: 1}, &out, "get"); err != nil || out[0].(uint8) != 0 {
+		t.Fatalf("counter at block 1: got %v (%v), want 0", out, err)
+	}
+	receipt, err := sim.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil || receipt == nil {
+		t.Fatalf("missing receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("receipt status: got %d", receipt.Status)
+	}
+	if block := sim.Blockchain().GetBlockByHeight(2); block == nil || block.Transactions()[0].Hash() != tx.Hash() {
+		t.Fatalf("transaction not in block 2")
+	}
+	if addr != counter.Address() {
+		t.Fatalf("address: got %x, want %x", counter.Address(), addr)
+	}
+}
+
+func TestSimulatedBackendLogs(t *testing.T) {
+	sim, auth := newTestBackend(t)
+	ctx := context.Background()
+	topic := crypto.Keccak256Hash([]byte("Logged"))
+
+	send := func(to *common.Address, data []byte) *types.Transaction {
+		nonce, err := sim.PendingNonceAt(ctx, auth.From)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gas, err := sim.EstimateGas(ctx, bind.CallMsg{From: auth.From, To: to, Data: data})
+		if err != nil {
+			t.Fatalf("failed to estimate gas: %v", err)
+		}
+		var tx *types.Transaction
+		if to == nil {
+			tx = types.NewContractCreation(nonce, new(big.Int), gas, new(big.Int), data)
+		} else {
+			tx = types.NewTransaction(nonce, *to, new(big.Int), gas, new(big.Int), data)
+		}
+		if tx, err = auth.Signer(auth.From, tx); err != nil {
+			t.Fatal(err)
+		}
+		if err := sim.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("failed to send: %v", err)
+		}
+		return tx
+	}
+	deploy := send(nil, loggerCode(topic))
+	logger := crypto.CreateAddress(auth.From, deploy.Nonce())
+	// Transactions see the pending state of the block they are sent in
+	send(&logger, []byte("hello"))
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	send(&logger, []byte("world"))
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := sim.FilterLogs(ctx, bind.FilterQuery{Addresses: []common.Address{logger}, Topics: [][]common.Hash{{topic}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("logs: got %d, want 2", len(logs))
+	}
+	for i, want := range []string{"hello", "world"} {
+		if !bytes.Equal(logs[i].Data, []byte(want)) {
+			t.Errorf("log %d: got %q, want %q", i, logs[i].Data, want)
+		}
+		if logs[i].BlockHeight != uint64(i+1) {
+			t.Errorf("log %d: got height %d, want %d", i, logs[i].BlockHeight, i+1)
+		}
+	}
+	if logs, _ := sim.FilterLogs(ctx, bind.FilterQuery{FromBlock: 2, Addresses: []common.Address{logger}}); len(logs) != 1 {
+		t.Fatalf("logs from block 2: got %d, want 1", len(logs))
+	}
+}
+
+func TestSimulatedBackendRollback(t *testing.T) {
+	sim, auth := newTestBackend(t)
+	ctx := context.Background()
+
+	nonce, err := sim.PendingNonceAt(ctx, auth.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTransaction(nonce, common.HexToAddress("0x01"), big.NewInt(1), 21000, new(big.Int), nil)
+	if tx, err = auth.Signer(auth.From, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.SendTransaction(ctx, tx); err == nil {
+		t.Fatal("replayed transaction was accepted")
+	}
+	if err := sim.AdjustTime(time.Hour); err == nil {
+		t.Fatal("adjusted the time of a non-empty block")
+	}
+	if err := sim.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := sim.PendingNonceAt(ctx, auth.From); got != nonce {
+		t.Fatalf("nonce after rollback: got %d, want %d", got, nonce)
+	}
+	if err := sim.AdjustTime(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sim.Blockchain().CurrentBlock().Time().Int64(), int64(3600+10); got != want {
+		t.Fatalf("block time: got %d, want %d", got, want)
+	}
+}
+
+func TestSimulatedBackendKSML(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := &types.BaseAccount{Address: crypto.PubkeyToAddress(key.PublicKey), PrivateKey: *key}
+	master := common.HexToAddress("0x0a")
+	alloc := genesis.GenesisAlloc{
+		account.Address: {Balance: big.NewInt(1e18)},
+		master:          {Balance: new(big.Int), Code: counterCode()},
+	}
+	sim, err := NewSimulatedBackend(alloc, 10000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Close()
+	const masterABI = `[{"constant":false,"inputs":[],"name":"inc","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"get","outputs":[{"name":"","type":"uint8"}],"type":"function"}]`
+	parse := func(patterns ...string) []interface{} {
+		parser := sim.NewKSMLParser("ETH", account, master, masterABI, patterns, &message.EventMessage{}, nil)
+		if err := parser.ParseParams(); err != nil {
+			t.Fatalf("failed to parse %v: %v", patterns, err)
+		}
+		return parser.GetGlobalParams()
+	}
+
+	// The dual action triggers the master contract, its transaction being
+	// included by the next commit.
+	params := parse("${smc:trigger(inc)}", "${smc:trigger(inc)}")
+	if len(params) != 2 {
+		t.Fatalf("trigger params: got %v, want 2 transaction hashes", params)
+	}
+	if count := parse("${smc:getData(get)}"); count[0] != uint8(0) {
+		t.Fatalf("uncommitted count: got %v, want 0", count)
+	}
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	for _, hash := range params {
+		receipt, err := sim.TransactionReceipt(context.Background(), common.HexToHash(hash.(string)))
+		if err != nil || receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("receipt of %v: got %v (%v)", hash, receipt, err)
+		}
+	}
+	if count := parse("${smc:getData(get)}"); count[0] != uint8(2) {
+		t.Fatalf("count: got %v, want 2", count)
+	}
+
+	// Transactions already in the chain aren't included again.
+	parse("${smc:trigger(inc)}")
+	if err := sim.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(sim.Blockchain().CurrentBlock().Transactions()); n != 1 {
+		t.Fatalf("transactions in block 2: got %d, want 1", n)
+	}
+	if count := parse("${smc:getData(get)}"); count[0] != uint8(3) {
+		t.Fatalf("count: got %v, want 3", count)
+	}
+}
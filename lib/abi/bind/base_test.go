@@ -0,0 +1,160 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package bind
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// mockBackend answers every call with the same output and records the
+// transactions and log queries it receives.
+type mockBackend struct {
+	output  []byte
+	nonce   uint64
+	sent    []*types.Transaction
+	queries []FilterQuery
+	logs    []types.Log
+}
+
+func (b *mockBackend) CallContract(ctx context.Context, call CallMsg, blockHeight uint64) ([]byte, error) {
+	return b.output, nil
+}
+
+func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.nonce, nil
+}
+
+func (b *mockBackend) EstimateGas(ctx context.Context, call CallMsg) (uint64, error) {
+	return 50000, nil
+}
+
+func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *mockBackend) FilterLogs(ctx context.Context, query FilterQuery) ([]types.Log, error) {
+	b.queries = append(b.queries, query)
+	return b.logs, nil
+}
+
+func newTestContract(t *testing.T, backend *mockBackend) (*BoundContract, abi.ABI) {
+	parsed, err := abi.JSON(strings.NewReader(tokenABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewBoundContract(common.HexToAddress("0x1234"), parsed, backend, backend, backend), parsed
+}
+
+func TestBoundContractCall(t *testing.T) {
+	backend := new(mockBackend)
+	contract, parsed := newTestContract(t, backend)
+
+	var err error
+	if backend.output, err = parsed.Methods["info"].Outputs.Pack("KAI", uint8(18)); err != nil {
+		t.Fatal(err)
+	}
+	var out []interface{}
+	if err := contract.Call(nil, &out, "info"); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[0].(string) != "KAI" || out[1].(uint8) != 18 {
+		t.Errorf("unexpected outputs %v", out)
+	}
+	backend.output = nil
+	if err := contract.Call(nil, &out, "info"); err != ErrNoCode {
+		t.Errorf("expected ErrNoCode for an empty output, got %v", err)
+	}
+}
+
+func TestBoundContractTransact(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	backend := &mockBackend{nonce: 7}
+	contract, parsed := newTestContract(t, backend)
+
+	to, value := common.HexToAddress("0xabcd"), big.NewInt(100)
+	tx, err := contract.Transact(NewKeyedTransactor(key), "transfer", to, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backend.sent) != 1 || backend.sent[0] != tx {
+		t.Fatal("transaction not sent to the backend")
+	}
+	if tx.Nonce() != 7 || tx.Gas() != 50000 || *tx.To() != contract.Address() {
+		t.Errorf("unexpected transaction nonce %d, gas %d, to %v", tx.Nonce(), tx.Gas(), tx.To())
+	}
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("transaction signed by %v, err %v", from, err)
+	}
+	input, _ := parsed.Pack("transfer", to, value)
+	if string(tx.Data()) != string(input) {
+		t.Errorf("unexpected transaction data %x", tx.Data())
+	}
+
+	other, _ := crypto.GenerateKey()
+	opts := NewKeyedTransactor(key)
+	opts.From = crypto.PubkeyToAddress(other.PublicKey)
+	if _, err := contract.Transact(opts, "transfer", to, value); err != ErrNotAuthorized {
+		t.Errorf("expected ErrNotAuthorized, got %v", err)
+	}
+}
+
+func TestBoundContractFilterLogs(t *testing.T) {
+	backend := new(mockBackend)
+	contract, parsed := newTestContract(t, backend)
+
+	from, to := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	data, _ := parsed.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(42))
+	backend.logs = []types.Log{{
+		Address: contract.Address(),
+		Topics:  []common.Hash{parsed.Events["Transfer"].Id(), from.Hash(), to.Hash()},
+		Data:    data,
+	}}
+	logs, err := contract.FilterLogs(&FilterOpts{Start: 3}, "Transfer", []interface{}{from})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := backend.queries[0]
+	if query.FromBlock != 3 || len(query.Addresses) != 1 || query.Addresses[0] != contract.Address() {
+		t.Errorf("unexpected query %+v", query)
+	}
+	if len(query.Topics) != 2 || query.Topics[0][0] != parsed.Events["Transfer"].Id() || query.Topics[1][0] != from.Hash() {
+		t.Errorf("unexpected query topics %v", query.Topics)
+	}
+	var event struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+	}
+	if err := contract.UnpackLog(&event, "Transfer", logs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if event.From != from || event.To != to || event.Value.Int64() != 42 {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
@@ -0,0 +1,248 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package bind generates Kardia contract Go bindings.
+package bind
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+)
+
+// Bind generates a Go wrapper around a contract ABI. The wrapper contains a
+// caller for the constant methods, a transactor for the state changing ones
+// and a filterer for the events of every contract, plus a deployer for those
+// contracts whose bytecode is given.
+func Bind(types []string, abis []string, bytecodes []string, pkg string) (string, error) {
+	if len(types) != len(abis) || len(types) != len(bytecodes) {
+		return "", fmt.Errorf("mismatched number of types (%d), ABIs (%d) and bytecodes (%d)", len(types), len(abis), len(bytecodes))
+	}
+	data := &tmplData{Package: pkg}
+	for i := 0; i < len(types); i++ {
+		contract, err := newTmplContract(types[i], abis[i], bytecodes[i])
+		if err != nil {
+			return "", err
+		}
+		data.Contracts = append(data.Contracts, contract)
+	}
+	buffer := new(bytes.Buffer)
+	tmpl := template.Must(template.New("").Parse(tmplSource))
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer)
+	}
+	return string(code), nil
+}
+
+// newTmplContract parses the ABI of a single contract and collects everything
+// the template needs to render its binding.
+func newTmplContract(kind string, input string, bin string) (*tmplContract, error) {
+	evmABI, err := abi.JSON(strings.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	compact := new(bytes.Buffer)
+	if err := json.Compact(compact, []byte(input)); err != nil {
+		return nil, err
+	}
+	contract := &tmplContract{
+		Type:        capitalise(kind),
+		InputABI:    compact.String(),
+		InputBin:    strings.TrimPrefix(strings.TrimSpace(bin), "0x"),
+		Constructor: bindArgs(evmABI.Constructor.Inputs),
+	}
+	names := make([]string, 0, len(evmABI.Methods))
+	for name := range evmABI.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		original := evmABI.Methods[name]
+		method := &tmplMethod{
+			Original:   original,
+			Normalized: toCamelCase(original.Name),
+			Id:         fmt.Sprintf("0x%x", original.Id()),
+			Inputs:     bindArgs(original.Inputs),
+			Structured: len(original.Outputs) > 1,
+		}
+		for i, output := range original.Outputs {
+			name := capitalise(output.Name)
+			if name == "" {
+				name = fmt.Sprintf("Arg%d", i)
+			}
+			method.Outputs = append(method.Outputs, tmplArg{Name: name, Type: bindType(output.Type)})
+		}
+		if original.Const {
+			contract.Calls = append(contract.Calls, method)
+		} else {
+			contract.Transacts = append(contract.Transacts, method)
+		}
+	}
+	names = names[:0]
+	for name := range evmABI.Events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		original := evmABI.Events[name]
+		event := &tmplEvent{
+			Original:   original,
+			Normalized: toCamelCase(original.Name),
+		}
+		for i, input := range original.Inputs {
+			if input.Name == "" {
+				return nil, fmt.Errorf("event %s: unnamed argument %d can't be bound", original.Name, i)
+			}
+			field := tmplField{
+				Name:    capitalise(input.Name),
+				Type:    bindType(input.Type),
+				Indexed: input.Indexed,
+				Param:   paramName(input.Name, i),
+			}
+			if input.Indexed {
+				field.Type = bindTopicType(input.Type)
+			}
+			event.Fields = append(event.Fields, field)
+		}
+		contract.Events = append(contract.Events, event)
+	}
+	return contract, nil
+}
+
+// bindArgs converts ABI arguments into Go function parameters.
+func bindArgs(args abi.Arguments) []tmplArg {
+	params := make([]tmplArg, len(args))
+	for i, arg := range args {
+		params[i] = tmplArg{Name: paramName(arg.Name, i), Type: bindType(arg.Type)}
+	}
+	return params
+}
+
+// bindType converts a Solidity type to the Go one the ABI codec packs from and
+// unpacks into.
+func bindType(kind abi.Type) string {
+	switch kind.T {
+	case abi.IntTy, abi.UintTy:
+		prefix := "int"
+		if kind.T == abi.UintTy {
+			prefix = "uint"
+		}
+		switch kind.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, kind.Size)
+		}
+		return "*big.Int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy:
+		return "string"
+	case abi.AddressTy:
+		return "common.Address"
+	case abi.HashTy:
+		return "common.Hash"
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", kind.Size)
+	case abi.FunctionTy:
+		return "[24]byte"
+	case abi.SliceTy:
+		return "[]" + bindType(*kind.Elem)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]", kind.Size) + bindType(*kind.Elem)
+	case abi.TupleTy:
+		fields := make([]string, len(kind.TupleElems))
+		for i, elem := range kind.TupleElems {
+			name := kind.TupleRawNames[i]
+			fields[i] = fmt.Sprintf("%s %s `abi:\"%s\"`", capitalise(name), bindType(*elem), name)
+		}
+		return "struct {\n" + strings.Join(fields, "\n") + "\n}"
+	}
+	// Fixed point types aren't supported by the codec, fall back to raw values
+	return "interface{}"
+}
+
+// bindTopicType converts a Solidity type of an indexed event argument to Go.
+// Dynamic values are only stored as their hash in the log topics.
+func bindTopicType(kind abi.Type) string {
+	switch kind.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return "common.Hash"
+	}
+	return bindType(kind)
+}
+
+// reservedParams are the identifiers used by the generated code itself, which
+// an argument name must not shadow.
+var reservedParams = map[string]bool{
+	"opts": true, "auth": true, "backend": true, "out": true, "err": true,
+	"logs": true, "log": true, "events": true, "event": true, "outstruct": true,
+	"parsed": true, "address": true, "tx": true, "contract": true,
+}
+
+// paramName converts an ABI argument name into a Go parameter name, falling
+// back to argN for anonymous arguments and ones clashing with Go identifiers.
+func paramName(name string, index int) string {
+	name = decapitalise(toCamelCase(name))
+	if name == "" || token.Lookup(name).IsKeyword() || reservedParams[name] {
+		return fmt.Sprintf("arg%d", index)
+	}
+	return name
+}
+
+// capitalise makes the first character of a string upper case, also removing
+// any prefixing underscores, matching the field names the ABI codec expects.
+func capitalise(input string) string {
+	for len(input) > 0 && input[0] == '_' {
+		input = input[1:]
+	}
+	if len(input) == 0 {
+		return ""
+	}
+	return strings.ToUpper(input[:1]) + input[1:]
+}
+
+// decapitalise makes the first character of a string lower case.
+func decapitalise(input string) string {
+	if len(input) == 0 {
+		return input
+	}
+	return strings.ToLower(input[:1]) + input[1:]
+}
+
+// toCamelCase converts an under-score string to a camel-case string.
+func toCamelCase(input string) string {
+	parts := strings.Split(input, "_")
+	for i, s := range parts {
+		if len(s) > 0 {
+			parts[i] = strings.ToUpper(s[:1]) + s[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
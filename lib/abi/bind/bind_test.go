@@ -0,0 +1,71 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package bind
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const tokenABI = `[
+	{"type":"constructor","inputs":[{"name":"_name","type":"string"}]},
+	{"constant":true,"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"info","stateMutability":"view","inputs":[],"outputs":[{"name":"name","type":"string"},{"name":"decimals","type":"uint8"}]},
+	{"constant":true,"type":"function","name":"point","inputs":[],"outputs":[{"name":"p","type":"tuple","components":[{"name":"x","type":"uint256"},{"name":"y","type":"uint64"}]}]},
+	{"constant":false,"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[]},
+	{"constant":false,"type":"function","name":"set_type","inputs":[{"name":"type","type":"bytes32[]"}],"outputs":[]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},
+	{"type":"event","name":"Named","anonymous":false,"inputs":[{"indexed":true,"name":"name","type":"string"},{"indexed":false,"name":"flag","type":"bool"}]}
+]`
+
+func TestBind(t *testing.T) {
+	code, err := Bind([]string{"token"}, []string{tokenABI}, []string{"0x6060"}, "tokens")
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", code, 0); err != nil {
+		t.Fatalf("generated binding doesn't parse: %v\n%s", err, code)
+	}
+	for _, want := range []string{
+		"package tokens",
+		`const TokenBin = "0x6060"`,
+		"func DeployToken(auth *bind.TransactOpts, backend bind.ContractBackend, name string) (common.Address, *types.Transaction, *Token, error)",
+		"func NewToken(address common.Address, backend bind.ContractBackend) (*Token, error)",
+		"func (_Token *TokenCaller) BalanceOf(opts *bind.CallOpts, owner common.Address) (*big.Int, error)",
+		"func (_Token *TokenCaller) Info(opts *bind.CallOpts) (struct {\n\tName     string\n\tDecimals uint8\n}, error)",
+		"func (_Token *TokenCaller) Point(opts *bind.CallOpts) (struct {\n\tX *big.Int `abi:\"x\"`\n\tY uint64   `abi:\"y\"`\n}, error)",
+		"func (_Token *TokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error)",
+		"func (_Token *TokenTransactor) SetType(opts *bind.TransactOpts, arg0 [][32]byte) (*types.Transaction, error)",
+		"func (_Token *TokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) ([]*TokenTransfer, error)",
+		"func (_Token *TokenFilterer) FilterNamed(opts *bind.FilterOpts, name []common.Hash) ([]*TokenNamed, error)",
+		"type TokenNamed struct {\n\tName common.Hash\n\tFlag bool\n\tRaw  types.Log",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("binding is missing %q\n%s", want, code)
+		}
+	}
+}
+
+func TestBindMismatch(t *testing.T) {
+	if _, err := Bind([]string{"A", "B"}, []string{tokenABI}, []string{""}, "tokens"); err == nil {
+		t.Error("expected an error for mismatched inputs")
+	}
+}
@@ -0,0 +1,258 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package bind
+
+import "github.com/kardiachain/go-kardia/lib/abi"
+
+// tmplData is the data structure required to fill the binding template.
+type tmplData struct {
+	Package   string          // Name of the package to place the generated file in
+	Contracts []*tmplContract // List of contracts to generate into this file
+}
+
+// tmplContract contains the data needed to generate an individual contract binding.
+type tmplContract struct {
+	Type        string        // Type name of the main contract binding
+	InputABI    string        // JSON ABI used as the input to generate the binding from
+	InputBin    string        // Optional bytecode used to generate the deployer from
+	Constructor []tmplArg     // Parameters of the contract constructor
+	Calls       []*tmplMethod // Contract calls that only read state data
+	Transacts   []*tmplMethod // Contract calls that write state data
+	Events      []*tmplEvent  // Contract events accessors
+}
+
+// tmplMethod is a wrapper around an abi.Method that contains a few preprocessed
+// and cached data fields.
+type tmplMethod struct {
+	Original   abi.Method // Original method as parsed by the abi package
+	Normalized string     // Go name of the method, camel cased
+	Id         string     // Hex encoded method selector
+	Inputs     []tmplArg  // Go parameters of the method
+	Outputs    []tmplArg  // Go results of the method
+	Structured bool       // Whether the returns should be accumulated into a struct
+}
+
+// tmplEvent is a wrapper around an abi.Event that contains a few preprocessed
+// and cached data fields.
+type tmplEvent struct {
+	Original   abi.Event   // Original event as parsed by the abi package
+	Normalized string      // Go name of the event, camel cased
+	Fields     []tmplField // Go fields of the event struct
+}
+
+// tmplArg is a single Go parameter or result of a generated method.
+type tmplArg struct {
+	Name string // Go identifier of the argument
+	Type string // Go type of the argument
+}
+
+// tmplField is a single field of a generated event struct.
+type tmplField struct {
+	Name    string // Go field name, as expected by the ABI codec
+	Type    string // Go type of the field
+	Indexed bool   // Whether the field is stored in the log topics
+	Param   string // Go parameter name of the filter rule for indexed fields
+}
+
+// tmplSource is the Go source template the generated bindings are based on.
+const tmplSource = `// Code generated by abigen. DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.BytesToAddress
+	_ = types.NewTransaction
+)
+{{range $contract := .Contracts}}
+// {{.Type}}ABI is the input ABI used to generate the binding from.
+const {{.Type}}ABI = {{printf "%q" .InputABI}}
+{{if .InputBin}}
+// {{.Type}}Bin is the compiled bytecode used for deploying new contracts.
+const {{.Type}}Bin = "0x{{.InputBin}}"
+
+// Deploy{{.Type}} deploys a new Kardia contract, binding an instance of {{.Type}} to it.
+func Deploy{{.Type}}(auth *bind.TransactOpts, backend bind.ContractBackend{{range .Constructor}}, {{.Name}} {{.Type}}{{end}}) (common.Address, *types.Transaction, *{{.Type}}, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex({{.Type}}Bin), backend{{range .Constructor}}, {{.Name}}{{end}})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+}
+{{end}}
+// {{.Type}} is an auto generated Go binding around a Kardia contract.
+type {{.Type}} struct {
+	{{.Type}}Caller     // Read-only binding to the contract
+	{{.Type}}Transactor // Write-only binding to the contract
+	{{.Type}}Filterer   // Log filterer for contract events
+}
+
+// {{.Type}}Caller is an auto generated read-only Go binding around a Kardia contract.
+type {{.Type}}Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// {{.Type}}Transactor is an auto generated write-only Go binding around a Kardia contract.
+type {{.Type}}Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// {{.Type}}Filterer is an auto generated log filtering Go binding around a Kardia contract events.
+type {{.Type}}Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// New{{.Type}} creates a new instance of {{.Type}}, bound to a specific deployed contract.
+func New{{.Type}}(address common.Address, backend bind.ContractBackend) (*{{.Type}}, error) {
+	contract, err := bind{{.Type}}(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+}
+
+// New{{.Type}}Caller creates a new read-only instance of {{.Type}}, bound to a specific deployed contract.
+func New{{.Type}}Caller(address common.Address, caller bind.ContractCaller) (*{{.Type}}Caller, error) {
+	contract, err := bind{{.Type}}(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}Caller{contract: contract}, nil
+}
+
+// New{{.Type}}Transactor creates a new write-only instance of {{.Type}}, bound to a specific deployed contract.
+func New{{.Type}}Transactor(address common.Address, transactor bind.ContractTransactor) (*{{.Type}}Transactor, error) {
+	contract, err := bind{{.Type}}(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}Transactor{contract: contract}, nil
+}
+
+// New{{.Type}}Filterer creates a new log filterer instance of {{.Type}}, bound to a specific deployed contract.
+func New{{.Type}}Filterer(address common.Address, filterer bind.ContractFilterer) (*{{.Type}}Filterer, error) {
+	contract, err := bind{{.Type}}(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}Filterer{contract: contract}, nil
+}
+
+// bind{{.Type}} binds a generic wrapper to an already deployed contract.
+func bind{{.Type}}(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+{{range .Calls}}
+// {{.Normalized}} is a free data retrieval call binding the contract method {{.Id}}.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Caller) {{.Normalized}}(opts *bind.CallOpts{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) ({{if .Structured}}struct {
+	{{range .Outputs}}{{.Name}} {{.Type}}
+	{{end}}
+}, {{else}}{{range .Outputs}}{{.Type}}, {{end}}{{end}}error) {
+	var out []interface{}
+	err := _{{$contract.Type}}.contract.Call(opts, &out, "{{.Original.Name}}"{{range .Inputs}}, {{.Name}}{{end}})
+{{if .Structured}}
+	outstruct := new(struct {
+		{{range .Outputs}}{{.Name}} {{.Type}}
+		{{end}}
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+{{range $i, $_ := .Outputs}}
+	outstruct.{{.Name}} = *abi.ConvertType(out[{{$i}}], new({{.Type}})).(*{{.Type}}){{end}}
+
+	return *outstruct, nil
+{{- else}}
+	if err != nil {
+		return {{range .Outputs}}*new({{.Type}}), {{end}}err
+	}
+{{range $i, $_ := .Outputs}}
+	out{{$i}} := *abi.ConvertType(out[{{$i}}], new({{.Type}})).(*{{.Type}}){{end}}
+
+	return {{range $i, $_ := .Outputs}}out{{$i}}, {{end}}nil
+{{- end}}
+}
+{{end}}
+{{range .Transacts}}
+// {{.Normalized}} is a paid mutator transaction binding the contract method {{.Id}}.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Transactor) {{.Normalized}}(opts *bind.TransactOpts{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) (*types.Transaction, error) {
+	return _{{$contract.Type}}.contract.Transact(opts, "{{.Original.Name}}"{{range .Inputs}}, {{.Name}}{{end}})
+}
+{{end}}
+{{range .Events}}
+// {{$contract.Type}}{{.Normalized}} represents a {{.Original.Name}} event raised by the {{$contract.Type}} contract.
+type {{$contract.Type}}{{.Normalized}} struct {
+	{{range .Fields}}{{.Name}} {{.Type}}
+	{{end}}Raw types.Log // Blockchain specific contextual infos
+}
+
+// Filter{{.Normalized}} is a free log retrieval operation binding the contract event {{printf "0x%x" .Original.Id}}.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Filter{{.Normalized}}(opts *bind.FilterOpts{{range .Fields}}{{if .Indexed}}, {{.Param}} []{{.Type}}{{end}}{{end}}) ([]*{{$contract.Type}}{{.Normalized}}, error) {
+{{range .Fields}}{{if .Indexed}}
+	var {{.Param}}Rule []interface{}
+	for _, {{.Param}}Item := range {{.Param}} {
+		{{.Param}}Rule = append({{.Param}}Rule, {{.Param}}Item)
+	}{{end}}{{end}}
+
+	logs, err := _{{$contract.Type}}.contract.FilterLogs(opts, "{{.Original.Name}}"{{range .Fields}}{{if .Indexed}}, {{.Param}}Rule{{end}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*{{$contract.Type}}{{.Normalized}}, 0, len(logs))
+	for _, log := range logs {
+		event := new({{$contract.Type}}{{.Normalized}})
+		if err := _{{$contract.Type}}.contract.UnpackLog(event, "{{.Original.Name}}", log); err != nil {
+			return nil, err
+		}
+		event.Raw = log
+		events = append(events, event)
+	}
+	return events, nil
+}
+{{end}}
+{{end}}`
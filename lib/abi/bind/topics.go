@@ -0,0 +1,84 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package bind
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+// MakeTopics converts the values of indexed event arguments to the topics of a
+// filter query, position by position. Values of dynamic types are hashed, as
+// their topic is the hash of their value.
+func MakeTopics(query ...[]interface{}) ([][]common.Hash, error) {
+	topics := make([][]common.Hash, len(query))
+	for i, filter := range query {
+		for _, rule := range filter {
+			var topic common.Hash
+
+			// Try to generate the topic based on simple types
+			switch rule := rule.(type) {
+			case common.Hash:
+				copy(topic[:], rule[:])
+			case common.Address:
+				copy(topic[common.HashLength-common.AddressLength:], rule[:])
+			case *big.Int:
+				copy(topic[:], abi.U256(rule))
+			case bool:
+				if rule {
+					topic[common.HashLength-1] = 1
+				}
+			case int8:
+				copy(topic[:], abi.U256(big.NewInt(int64(rule))))
+			case int16:
+				copy(topic[:], abi.U256(big.NewInt(int64(rule))))
+			case int32:
+				copy(topic[:], abi.U256(big.NewInt(int64(rule))))
+			case int64:
+				copy(topic[:], abi.U256(big.NewInt(rule)))
+			case uint8:
+				topic[common.HashLength-1] = rule
+			case uint16:
+				copy(topic[:], abi.U256(new(big.Int).SetUint64(uint64(rule))))
+			case uint32:
+				copy(topic[:], abi.U256(new(big.Int).SetUint64(uint64(rule))))
+			case uint64:
+				copy(topic[:], abi.U256(new(big.Int).SetUint64(rule)))
+			case string:
+				topic = crypto.Keccak256Hash([]byte(rule))
+			case []byte:
+				topic = crypto.Keccak256Hash(rule)
+			default:
+				// Attempt to generate the topic from fixed bytes
+				val := reflect.ValueOf(rule)
+				if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Uint8 || val.Len() > common.HashLength {
+					return nil, fmt.Errorf("unsupported indexed type: %T", rule)
+				}
+				reflect.Copy(reflect.ValueOf(topic[:val.Len()]), val)
+			}
+			topics[i] = append(topics[i], topic)
+		}
+	}
+	return topics, nil
+}
@@ -323,6 +323,17 @@ func TestPack(t *testing.T) {
 			"foobar",
 			common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000006666f6f6261720000000000000000000000000000000000000000000000000000"),
 		},
+		{
+			"string[]",
+			[]string{"a", "bc"},
+			common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002" + // len(array) = 2
+				"0000000000000000000000000000000000000000000000000000000000000040" + // offset(a)
+				"0000000000000000000000000000000000000000000000000000000000000080" + // offset(bc)
+				"0000000000000000000000000000000000000000000000000000000000000001" + // len(a)
+				"6100000000000000000000000000000000000000000000000000000000000000" + // a
+				"0000000000000000000000000000000000000000000000000000000000000002" + // len(bc)
+				"6263000000000000000000000000000000000000000000000000000000000000"), // bc
+		},
 	} {
 		typ, err := NewType(test.typ)
 		if err != nil {
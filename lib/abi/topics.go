@@ -0,0 +1,82 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// ParseTopics unpacks the topics of the indexed arguments of an event into the
+// struct out, matching the fields as Unpack does.
+func ParseTopics(out interface{}, fields Arguments, topics []common.Hash) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("abi: ParseTopics(non-pointer to struct %T)", out)
+	}
+	value = value.Elem()
+	return parseTopicWithSetter(fields, topics, func(arg Argument, reconstr interface{}) error {
+		field := tupleField(value, arg.Name)
+		if !field.IsValid() {
+			return fmt.Errorf("abi: field %s can't be found in the given value", capitalise(arg.Name))
+		}
+		return set(field, reflect.ValueOf(reconstr), arg)
+	})
+}
+
+// ParseTopicsIntoMap unpacks the topics of the indexed arguments of an event
+// into out, by argument name.
+func ParseTopicsIntoMap(out map[string]interface{}, fields Arguments, topics []common.Hash) error {
+	return parseTopicWithSetter(fields, topics, func(arg Argument, reconstr interface{}) error {
+		out[arg.Name] = reconstr
+		return nil
+	})
+}
+
+// parseTopicWithSetter decodes the topic of every indexed argument and passes
+// it to setter. The arguments of dynamic types can't be recovered as their
+// topic is the hash of their encoding, so the hash is passed instead.
+func parseTopicWithSetter(fields Arguments, topics []common.Hash, setter func(Argument, interface{}) error) error {
+	if len(fields) != len(topics) {
+		return fmt.Errorf("abi: topic/field count mismatch: %d for %d", len(topics), len(fields))
+	}
+	for i, arg := range fields {
+		if !arg.Indexed {
+			return fmt.Errorf("abi: non-indexed field %s in topic reconstruction", arg.Name)
+		}
+		var (
+			reconstr interface{}
+			err      error
+		)
+		switch arg.Type.T {
+		case StringTy, BytesTy, SliceTy, ArrayTy, TupleTy:
+			reconstr = topics[i]
+		default:
+			if reconstr, err = toGoType(0, arg.Type, topics[i].Bytes()); err != nil {
+				return err
+			}
+		}
+		if err := setter(arg, reconstr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+const eventJSON = `[
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},
+	{"type":"event","name":"Named","anonymous":false,"inputs":[{"indexed":true,"name":"name","type":"string"},{"indexed":true,"name":"flag","type":"bool"}]}
+]`
+
+func TestUnpackLog(t *testing.T) {
+	abi, err := JSON(strings.NewReader(eventJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		from   = common.HexToAddress("0x0000000000000000000000000000000000000001")
+		to     = common.HexToAddress("0x0000000000000000000000000000000000000002")
+		event  = abi.Events["Transfer"]
+		topics = []common.Hash{event.Id(), from.Hash(), to.Hash()}
+		data   = common.LeftPadBytes(big.NewInt(10).Bytes(), 32)
+	)
+	if found, err := abi.EventById(event.Id()); err != nil || found.Name != "Transfer" {
+		t.Errorf("event by id is %v, %v, want Transfer", found, err)
+	}
+
+	var transfer struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+	}
+	if err := abi.UnpackLog(&transfer, "Transfer", topics, data); err != nil {
+		t.Fatal(err)
+	}
+	if transfer.From != from || transfer.To != to || transfer.Value.Int64() != 10 {
+		t.Errorf("unpacked %+v, want 10 from %x to %x", transfer, from, to)
+	}
+
+	values := make(map[string]interface{})
+	if err := abi.UnpackLogIntoMap(values, "Transfer", topics, data); err != nil {
+		t.Fatal(err)
+	}
+	if values["from"] != from || values["to"] != to || values["value"].(*big.Int).Int64() != 10 {
+		t.Errorf("unpacked %v, want 10 from %x to %x", values, from, to)
+	}
+
+	// logs of other events are rejected
+	if err := abi.UnpackLog(&transfer, "Transfer", []common.Hash{abi.Events["Named"].Id(), from.Hash(), to.Hash()}, data); err == nil {
+		t.Error("unpacked a Named log as Transfer")
+	}
+}
+
+func TestParseTopicsDynamic(t *testing.T) {
+	abi, err := JSON(strings.NewReader(eventJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// indexed dynamic arguments are only recoverable as the hash of their value
+	nameHash := crypto.Keccak256Hash([]byte("kardia"))
+	topics := []common.Hash{nameHash, common.BigToHash(big.NewInt(1))}
+
+	var named struct {
+		Name common.Hash
+		Flag bool
+	}
+	if err := ParseTopics(&named, abi.Events["Named"].Inputs, topics); err != nil {
+		t.Fatal(err)
+	}
+	if named.Name != nameHash || !named.Flag {
+		t.Errorf("parsed %+v, want name %x and flag", named, nameHash)
+	}
+	if err := ParseTopics(&named, abi.Events["Named"].Inputs, topics[:1]); err == nil {
+		t.Error("parsed 1 topic of 2 indexed arguments")
+	}
+}
@@ -46,6 +46,7 @@ const (
 	HashTy
 	FixedPointTy
 	FunctionTy
+	TupleTy
 )
 
 // Type is the reflection of the supported argument type
@@ -58,6 +59,10 @@ type Type struct {
 	T    byte // Our own type checking
 
 	stringKind string // holds the unparsed string for deriving signatures
+
+	// Tuple relative fields
+	TupleElems    []*Type  // Type information of all tuple fields
+	TupleRawNames []string // Raw field names of all tuple fields
 }
 
 var (
@@ -78,27 +83,71 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 		return nil, err
 	}
 
-	if t.T == SliceTy || t.T == ArrayTy {
-		var packed []byte
-
+	switch t.T {
+	case SliceTy, ArrayTy:
+		var ret []byte
+		if t.requiresLengthPrefix() {
+			// append length prefix
+			ret = append(ret, packNum(reflect.ValueOf(v.Len()))...)
+		}
+		// dynamic elements are encoded as offsets to their tail, relative to
+		// the start of the elements
+		offsetReq := isDynamicType(*t.Elem)
+		offset := 0
+		if offsetReq {
+			offset = getTypeSize(*t.Elem) * v.Len()
+		}
+		var tail []byte
 		for i := 0; i < v.Len(); i++ {
 			val, err := t.Elem.pack(v.Index(i))
 			if err != nil {
 				return nil, err
 			}
-			packed = append(packed, val...)
+			if !offsetReq {
+				ret = append(ret, val...)
+				continue
+			}
+			ret = append(ret, packNum(reflect.ValueOf(offset))...)
+			offset += len(val)
+			tail = append(tail, val...)
 		}
-		if t.T == SliceTy {
-			return packBytesSlice(packed, v.Len()), nil
-		} else if t.T == ArrayTy {
-			return packed, nil
+		return append(ret, tail...), nil
+	case TupleTy:
+		offset := 0
+		for _, elem := range t.TupleElems {
+			offset += getTypeSize(*elem)
 		}
+		var ret, tail []byte
+		for i, elem := range t.TupleElems {
+			field := tupleField(v, t.TupleRawNames[i])
+			if !field.IsValid() {
+				return nil, fmt.Errorf("abi: field %s for tuple not found in the given struct", t.TupleRawNames[i])
+			}
+			val, err := elem.pack(field)
+			if err != nil {
+				return nil, err
+			}
+			if isDynamicType(*elem) {
+				ret = append(ret, packNum(reflect.ValueOf(offset))...)
+				tail = append(tail, val...)
+				offset += len(val)
+			} else {
+				ret = append(ret, val...)
+			}
+		}
+		return append(ret, tail...), nil
 	}
 	return packElement(t, v), nil
 }
 
 // NewType creates a new reflection type of abi type given in t.
 func NewType(t string) (typ Type, err error) {
+	return newType(t, nil)
+}
+
+// newType creates a new reflection type of abi type given in t, with the given
+// components when t is a tuple or an array of tuples.
+func newType(t string, components []ArgumentMarshaling) (typ Type, err error) {
 	// check that array brackets are equal if they exist
 	if strings.Count(t, "[") != strings.Count(t, "]") {
 		return Type{}, fmt.Errorf("invalid arg type in abi")
@@ -111,7 +160,7 @@ func NewType(t string) (typ Type, err error) {
 	if strings.Count(t, "[") != 0 {
 		i := strings.LastIndex(t, "[")
 		// recursively embed the type
-		embeddedType, err := NewType(t[:i])
+		embeddedType, err := newType(t[:i], components)
 		if err != nil {
 			return Type{}, err
 		}
@@ -127,6 +176,9 @@ func NewType(t string) (typ Type, err error) {
 			typ.Kind = reflect.Slice
 			typ.Elem = &embeddedType
 			typ.Type = reflect.SliceOf(embeddedType.Type)
+			if embeddedType.T == TupleTy {
+				typ.stringKind = embeddedType.stringKind + sliced
+			}
 		} else if len(intz) == 1 {
 			// is a array
 			typ.T = ArrayTy
@@ -137,6 +189,9 @@ func NewType(t string) (typ Type, err error) {
 				return Type{}, fmt.Errorf("abi: error parsing variable size: %v", err)
 			}
 			typ.Type = reflect.ArrayOf(typ.Size, embeddedType.Type)
+			if embeddedType.T == TupleTy {
+				typ.stringKind = embeddedType.stringKind + sliced
+			}
 		} else {
 			return Type{}, fmt.Errorf("invalid formatting of array type")
 		}
@@ -198,6 +253,42 @@ func NewType(t string) (typ Type, err error) {
 		typ.T = FunctionTy
 		typ.Size = 24
 		typ.Type = reflect.ArrayOf(24, reflect.TypeOf(byte(0)))
+	case "tuple":
+		var (
+			fields     []reflect.StructField
+			elems      []*Type
+			names      []string
+			expression string // canonical parameter expression
+		)
+		expression += "("
+		for idx, c := range components {
+			cType, err := newType(c.Type, c.Components)
+			if err != nil {
+				return Type{}, err
+			}
+			fieldName := capitalise(c.Name)
+			if fieldName == "" {
+				return Type{}, fmt.Errorf("abi: purely anonymous or underscored field is not supported")
+			}
+			fields = append(fields, reflect.StructField{
+				Name: fieldName, // reflect.StructOf will panic for any exported field.
+				Type: cType.Type,
+				Tag:  reflect.StructTag(fmt.Sprintf(`abi:"%s"`, c.Name)),
+			})
+			elems = append(elems, &cType)
+			names = append(names, c.Name)
+			expression += cType.stringKind
+			if idx != len(components)-1 {
+				expression += ","
+			}
+		}
+		expression += ")"
+		typ.Kind = reflect.Struct
+		typ.Type = reflect.StructOf(fields)
+		typ.TupleElems = elems
+		typ.TupleRawNames = names
+		typ.T = TupleTy
+		typ.stringKind = expression
 	default:
 		return Type{}, fmt.Errorf("unsupported arg type: %s", t)
 	}
@@ -211,6 +302,55 @@ func (t Type) requiresLengthPrefix() bool {
 	return t.T == StringTy || t.T == BytesTy || t.T == SliceTy
 }
 
+// isDynamicType returns true if the type is dynamic, i.e. encoded as an offset
+// to its content in the tail of the encoding. The following types are called
+// "dynamic": bytes, string, T[] for any T, T[k] for any dynamic T and k >= 0,
+// and (T1,...,Tk) if Ti is dynamic for some 1 <= i <= k.
+func isDynamicType(t Type) bool {
+	if t.T == TupleTy {
+		for _, elem := range t.TupleElems {
+			if isDynamicType(*elem) {
+				return true
+			}
+		}
+		return false
+	}
+	return t.T == StringTy || t.T == BytesTy || t.T == SliceTy || (t.T == ArrayTy && isDynamicType(*t.Elem))
+}
+
+// getTypeSize returns the size that the type occupies in the head of the
+// encoding: 32 bytes for the offset of dynamic types, the full size of static
+// arrays and tuples and 32 bytes for the other types.
+func getTypeSize(t Type) int {
+	if t.T == ArrayTy && !isDynamicType(*t.Elem) {
+		// Recursively calculate type size if it is a nested array
+		if t.Elem.T == ArrayTy || t.Elem.T == TupleTy {
+			return t.Size * getTypeSize(*t.Elem)
+		}
+		return t.Size * 32
+	} else if t.T == TupleTy && !isDynamicType(t) {
+		total := 0
+		for _, elem := range t.TupleElems {
+			total += getTypeSize(*elem)
+		}
+		return total
+	}
+	return 32
+}
+
+// tupleField returns the field of the struct v holding the tuple field of the
+// given raw name: the field tagged with it, or else the field of its
+// capitalised name.
+func tupleField(v reflect.Value, name string) reflect.Value {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if tag, ok := typ.Field(i).Tag.Lookup("abi"); ok && tag == name {
+			return v.Field(i)
+		}
+	}
+	return v.FieldByName(capitalise(name))
+}
+
 //==============================================================================
 // Helpers
 //==============================================================================
@@ -456,12 +596,59 @@ func set(dst, src reflect.Value, output Argument) error {
 		dst.Set(src)
 	case dstType.Kind() == reflect.Ptr:
 		return set(dst.Elem(), src, output)
+	case dstType.Kind() == reflect.Struct && srcType.Kind() == reflect.Struct:
+		return setStruct(dst, src, output)
+	case dstType.Kind() == reflect.Slice && srcType.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(dstType, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := set(slice.Index(i), src.Index(i), output); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case dstType.Kind() == reflect.Array && srcType.Kind() == reflect.Array && dst.Len() == src.Len():
+		for i := 0; i < src.Len(); i++ {
+			if err := set(dst.Index(i), src.Index(i), output); err != nil {
+				return err
+			}
+		}
 	default:
 		return fmt.Errorf("abi: cannot unmarshal %v in to %v", src.Type(), dst.Type())
 	}
 	return nil
 }
 
+// ConvertType converts an unpacked value to the type of proto, a pointer. It
+// is used by the generated bindings to convert the values of UnpackValues, as
+// tuples unpack to structs of a type only convertible to the bound one.
+func ConvertType(in interface{}, proto interface{}) interface{} {
+	protoType := reflect.TypeOf(proto)
+	if reflect.TypeOf(in).ConvertibleTo(protoType) {
+		return reflect.ValueOf(in).Convert(protoType).Interface()
+	}
+	// Use set as a last ditch effort
+	if err := set(reflect.ValueOf(proto), reflect.ValueOf(in), Argument{}); err != nil {
+		panic(err)
+	}
+	return proto
+}
+
+// setStruct assigns the unpacked tuple src to the struct dst, field by field
+// of the same name.
+func setStruct(dst, src reflect.Value, output Argument) error {
+	for i := 0; i < src.NumField(); i++ {
+		name := src.Type().Field(i).Name
+		field := dst.FieldByName(name)
+		if !field.IsValid() {
+			return fmt.Errorf("abi: field %s can't be found in the given value", name)
+		}
+		if err := set(field, src.Field(i), output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // requireUnpackKind verifies preconditions for unpacking `args` into `kind`
 func requireUnpackKind(v reflect.Value, t reflect.Type, k reflect.Kind,
 	args Arguments) error {
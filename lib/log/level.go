@@ -0,0 +1,82 @@
+package log
+
+import (
+	"sync"
+)
+
+// LevelHandler is a handler filtering records by their level, whose verbosity
+// can be changed while the program is running. Besides the global level, a
+// separate level can be set for every module, i.e. the first tag of a logger
+// such as the name of a service.
+type LevelHandler struct {
+	origin Handler
+
+	lock    sync.RWMutex
+	level   Lvl
+	modules map[string]Lvl
+}
+
+// NewLevelHandler creates a new level handler passing the records up to the
+// given level to the wrapped handler.
+func NewLevelHandler(level Lvl, h Handler) *LevelHandler {
+	return &LevelHandler{
+		origin:  h,
+		level:   level,
+		modules: make(map[string]Lvl),
+	}
+}
+
+// SetLevel sets the global level, used for every module without its own level.
+func (h *LevelHandler) SetLevel(level Lvl) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.level = level
+}
+
+// SetModuleLevel sets the level of the given module, overriding the global one.
+func (h *LevelHandler) SetModuleLevel(module string, level Lvl) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.modules[module] = level
+}
+
+// ResetModuleLevel drops the level of the given module, so that the global one
+// applies again.
+func (h *LevelHandler) ResetModuleLevel(module string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.modules, module)
+}
+
+// Levels returns the global level and the levels of all modules having one.
+func (h *LevelHandler) Levels() (Lvl, map[string]Lvl) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	modules := make(map[string]Lvl, len(h.modules))
+	for module, level := range h.modules {
+		modules[module] = level
+	}
+	return h.level, modules
+}
+
+// Log implements Handler.Log, passing the record to the wrapped handler if its
+// level is within the level of its module.
+func (h *LevelHandler) Log(r *Record) error {
+	h.lock.RLock()
+	level := h.level
+	if r.Tag != nil && len(r.Tag.tags) > 0 {
+		if lvl, ok := h.modules[r.Tag.tags[0]]; ok {
+			level = lvl
+		}
+	}
+	h.lock.RUnlock()
+
+	if r.Lvl > level {
+		return nil
+	}
+	return h.origin.Log(r)
+}
@@ -0,0 +1,88 @@
+package log
+
+import (
+	"testing"
+)
+
+// newLevelLogger creates a logger tagged with the given module, logging into the
+// given level handler.
+func newLevelLogger(h *LevelHandler, module string) Logger {
+	l := New()
+	if module != "" {
+		l.AddTag(module)
+	}
+	l.SetHandler(h)
+	return l
+}
+
+func TestLevelHandler(t *testing.T) {
+	var passed []string
+	h := NewLevelHandler(LvlInfo, FuncHandler(func(r *Record) error {
+		passed = append(passed, r.Msg)
+		return nil
+	}))
+	root, p2p := newLevelLogger(h, ""), newLevelLogger(h, "p2p")
+
+	check := func(want ...string) {
+		t.Helper()
+		if len(passed) != len(want) {
+			t.Fatalf("passed records %v, want %v", passed, want)
+		}
+		for i := range want {
+			if passed[i] != want[i] {
+				t.Fatalf("passed records %v, want %v", passed, want)
+			}
+		}
+		passed = nil
+	}
+
+	// The global level applies to every module.
+	root.Info("root info")
+	root.Debug("root debug")
+	p2p.Warn("p2p warn")
+	p2p.Debug("p2p debug")
+	check("root info", "p2p warn")
+
+	// A module level overrides the global one for the module only.
+	h.SetModuleLevel("p2p", LvlTrace)
+	root.Debug("root debug")
+	p2p.Trace("p2p trace")
+	check("p2p trace")
+
+	h.SetModuleLevel("p2p", LvlError)
+	h.SetLevel(LvlDebug)
+	root.Debug("root debug")
+	p2p.Warn("p2p warn")
+	check("root debug")
+
+	// Child loggers keep the module of their parent.
+	p2p.New("peer", 1).Error("p2p error")
+	check("p2p error")
+
+	// Resetting the module level falls back to the global one.
+	h.ResetModuleLevel("p2p")
+	p2p.Debug("p2p debug")
+	p2p.Trace("p2p trace")
+	check("p2p debug")
+}
+
+func TestLevelHandlerLevels(t *testing.T) {
+	h := NewLevelHandler(LvlWarn, DiscardHandler())
+	h.SetModuleLevel("p2p", LvlDebug)
+	h.SetModuleLevel("consensus", LvlError)
+
+	level, modules := h.Levels()
+	if level != LvlWarn {
+		t.Errorf("global level %v, want %v", level, LvlWarn)
+	}
+	if len(modules) != 2 || modules["p2p"] != LvlDebug || modules["consensus"] != LvlError {
+		t.Errorf("module levels %v", modules)
+	}
+
+	// The returned levels are a copy.
+	modules["p2p"] = LvlCrit
+	delete(modules, "consensus")
+	if _, modules = h.Levels(); len(modules) != 2 || modules["p2p"] != LvlDebug {
+		t.Errorf("module levels changed through the returned map: %v", modules)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	mrand "math/rand"
 	"net"
 	"time"
 
@@ -72,6 +73,8 @@ type dialstate struct {
 	maxDynDials int
 	ntab        discoverTable
 	netrestrict *netutil.Netlist
+	banned      func(discover.NodeID) bool // reports banned nodes, which are never dialed
+	sources     []NodeSource               // dial candidates alongside the table, e.g. DNS trees
 
 	lookupRunning bool
 	dialing       map[discover.NodeID]connFlag
@@ -227,6 +230,23 @@ func (s *dialstate) newTasks(nRunning int, peers map[discover.NodeID]*Peer, now
 			}
 		}
 	}
+	// Use the nodes of the sources, in random order, for another share of the
+	// necessary dynamic dials.
+	if sourceCandidates := (needDynDials + 1) / 2; sourceCandidates > 0 && len(s.sources) > 0 {
+		var nodes []*discover.Node
+		for _, source := range s.sources {
+			nodes = append(nodes, source.Nodes()...)
+		}
+		for _, i := range mrand.Perm(len(nodes)) {
+			if sourceCandidates == 0 {
+				break
+			}
+			if addDial(dynDialedConn, nodes[i]) {
+				sourceCandidates--
+				needDynDials--
+			}
+		}
+	}
 	// Create dynamic dials from random lookup results, removing tried
 	// items from the result buffer.
 	i := 0
@@ -259,6 +279,7 @@ var (
 	errAlreadyConnected = errors.New("already connected")
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
+	errBanned           = errors.New("is banned")
 )
 
 func (s *dialstate) checkDial(n *discover.Node, peers map[discover.NodeID]*Peer) error {
@@ -272,6 +293,8 @@ func (s *dialstate) checkDial(n *discover.Node, peers map[discover.NodeID]*Peer)
 		return errSelf
 	case s.netrestrict != nil && !s.netrestrict.Contains(n.IP):
 		return errNotWhitelisted
+	case s.banned != nil && s.banned(n.ID):
+		return errBanned
 	case s.hist.contains(n.ID):
 		return errRecentlyDialed
 	}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+// emptyTable is a discoverTable without any node.
+type emptyTable struct{}
+
+func (emptyTable) Self() *discover.Node                                   { return new(discover.Node) }
+func (emptyTable) Close()                                                 {}
+func (emptyTable) Resolve(discover.NodeID) *discover.Node                 { return nil }
+func (emptyTable) Lookup(discover.NodeID) []*discover.Node                { return nil }
+func (emptyTable) ReadRandomNodes([]*discover.Node) int                   { return 0 }
+func (emptyTable) Bond(bool, discover.NodeID, *net.UDPAddr, uint16) error { return nil }
+
+// staticSource is a NodeSource with a fixed list of nodes.
+type staticSource []*discover.Node
+
+func (s staticSource) Nodes() []*discover.Node { return s }
+func (s staticSource) Close()                  {}
+
+// dialedNodes returns the destinations of the dial tasks, and whether a
+// discovery lookup was scheduled.
+func dialedNodes(t *testing.T, tasks []task) (map[discover.NodeID]bool, bool) {
+	dialed, lookup := make(map[discover.NodeID]bool), false
+	for _, tt := range tasks {
+		switch tt := tt.(type) {
+		case *dialTask:
+			if tt.flags != dynDialedConn {
+				t.Errorf("dial of %x has flags %v, want %v", tt.dest.ID[:4], tt.flags, dynDialedConn)
+			}
+			if dialed[tt.dest.ID] {
+				t.Errorf("node %x dialed twice", tt.dest.ID[:4])
+			}
+			dialed[tt.dest.ID] = true
+		case *discoverTask:
+			lookup = true
+		}
+	}
+	return dialed, lookup
+}
+
+// Tests that the nodes of the sources fill half of the dynamic dials left by
+// the table, the rest being looked up.
+func TestDialStateSources(t *testing.T) {
+	var nodes []*discover.Node
+	for i := byte(1); i <= 10; i++ {
+		nodes = append(nodes, testSourceNode(i))
+	}
+	s := newDialState(nil, nil, emptyTable{}, 8, nil)
+	s.sources = []NodeSource{staticSource(nodes[:4]), staticSource(nodes[4:])}
+	now := time.Now()
+
+	dialed, lookup := dialedNodes(t, s.newTasks(0, nil, now))
+	if len(dialed) != 4 || !lookup {
+		t.Fatalf("dialed %d source nodes, lookup %v: want 4 nodes and a lookup", len(dialed), lookup)
+	}
+	// The nodes being dialed aren't dialed again.
+	more, _ := dialedNodes(t, s.newTasks(4, nil, now))
+	if len(more) != 2 {
+		t.Fatalf("dialed %d more source nodes, want 2", len(more))
+	}
+	for id := range more {
+		if dialed[id] {
+			t.Errorf("node %x dialed while being dialed", id[:4])
+		}
+	}
+}
+
+// Tests that source nodes which can't be dialed are skipped.
+func TestDialStateSourcesCheckDial(t *testing.T) {
+	banned, connected, dialable := testSourceNode(1), testSourceNode(2), testSourceNode(3)
+	s := newDialState(nil, nil, emptyTable{}, 8, nil)
+	s.sources = []NodeSource{staticSource{banned, connected, dialable}}
+	s.banned = func(id discover.NodeID) bool { return id == banned.ID }
+	peers := map[discover.NodeID]*Peer{
+		connected.ID: {rw: &conn{flags: inboundConn, id: connected.ID}},
+	}
+
+	dialed, _ := dialedNodes(t, s.newTasks(0, peers, time.Now()))
+	if len(dialed) != 1 || !dialed[dialable.ID] {
+		t.Fatalf("dialed %v, want node %x only", dialed, dialable.ID[:4])
+	}
+}
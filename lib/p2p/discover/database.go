@@ -58,6 +58,7 @@ type nodeDB struct {
 var (
 	nodeDBVersionKey = []byte("version") // Version of the database to flush if changes
 	nodeDBItemPrefix = []byte("n:")      // Identifier to prefix node entries with
+	nodeDBBanPrefix  = []byte("ban:")    // Identifier to prefix ban entries with, kept apart so bans outlive node expiry
 
 	nodeDBDiscoverRoot      = ":discover"
 	nodeDBDiscoverPing      = nodeDBDiscoverRoot + ":lastping"
@@ -305,6 +306,55 @@ func (db *nodeDB) updateFindFails(id NodeID, fails int) error {
 	return db.storeInt64(makeKey(id, nodeDBDiscoverFindFails), int64(fails))
 }
 
+// makeBanKey generates the leveldb key-blob of the ban entry of a node.
+func makeBanKey(id NodeID) []byte {
+	return append(append([]byte{}, nodeDBBanPrefix...), id[:]...)
+}
+
+// banExpiry retrieves the time until which a node is banned, or the zero time
+// if the node isn't banned.
+func (db *nodeDB) banExpiry(id NodeID) time.Time {
+	if until := db.fetchInt64(makeBanKey(id)); until != 0 {
+		return time.Unix(until, 0)
+	}
+	return time.Time{}
+}
+
+// updateBan bans a node until the given time.
+func (db *nodeDB) updateBan(id NodeID, until time.Time) error {
+	return db.storeInt64(makeBanKey(id), until.Unix())
+}
+
+// deleteBan lifts the ban of a node.
+func (db *nodeDB) deleteBan(id NodeID) error {
+	return db.lvl.Delete(makeBanKey(id), nil)
+}
+
+// bans retrieves all nodes which are currently banned along with the expiry of
+// their bans. Expired bans are removed from the database.
+func (db *nodeDB) bans() map[NodeID]time.Time {
+	now := time.Now()
+	bans := make(map[NodeID]time.Time)
+
+	it := db.lvl.NewIterator(util.BytesPrefix(nodeDBBanPrefix), nil)
+	defer it.Release()
+
+	for it.Next() {
+		var id NodeID
+		if len(it.Key()) != len(nodeDBBanPrefix)+len(id) {
+			continue
+		}
+		copy(id[:], it.Key()[len(nodeDBBanPrefix):])
+		until := db.banExpiry(id)
+		if !until.After(now) {
+			db.deleteBan(id)
+			continue
+		}
+		bans[id] = until
+	}
+	return bans
+}
+
 // querySeeds retrieves random nodes to be used as potential seed nodes
 // for bootstrapping.
 func (db *nodeDB) querySeeds(n int, maxAge time.Duration) []*Node {
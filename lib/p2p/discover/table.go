@@ -209,6 +209,23 @@ func (tab *Table) ReadRandomNodes(buf []*Node) (n int) {
 	return i + 1
 }
 
+// BanNode records in the node database that the given node is banned until the
+// given time.
+func (tab *Table) BanNode(id NodeID, until time.Time) error {
+	return tab.db.updateBan(id, until)
+}
+
+// UnbanNode removes the ban of the given node from the node database.
+func (tab *Table) UnbanNode(id NodeID) error {
+	return tab.db.deleteBan(id)
+}
+
+// BannedNodes returns the nodes banned in the node database along with the
+// expiry of their bans.
+func (tab *Table) BannedNodes() map[NodeID]time.Time {
+	return tab.db.bans()
+}
+
 // Close terminates the network listener and flushes the node database.
 func (tab *Table) Close() {
 	select {
@@ -0,0 +1,265 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+const (
+	// defaultTimeout is the timeout of a single DNS lookup.
+	defaultTimeout = 5 * time.Second
+
+	// defaultRecheckInterval is the interval to check the trees for updates.
+	defaultRecheckInterval = 30 * time.Minute
+
+	// maxEntries is the maximum number of entries synced from a tree, protecting
+	// against loops and oversized trees.
+	maxEntries = 10000
+)
+
+// Resolver is a DNS resolver that can query TXT records. net.DefaultResolver
+// implements it.
+type Resolver interface {
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+}
+
+// MapResolver is a Resolver serving the TXT records held in the map, keyed by
+// name. It stands in for DNS in tests and private networks.
+type MapResolver map[string]string
+
+// LookupTXT returns the TXT record of the given name.
+func (mr MapResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if record, ok := mr[name]; ok {
+		return []string{record}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+// Config holds Client options.
+type Config struct {
+	Timeout         time.Duration // timeout used for DNS lookups (default 5s)
+	RecheckInterval time.Duration // time between tree root update checks (default 30min)
+	Resolver        Resolver      // the DNS resolver to use (defaults to system DNS)
+	Logger          log.Logger    // destination of client log messages
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.RecheckInterval == 0 {
+		cfg.RecheckInterval = defaultRecheckInterval
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = new(net.Resolver)
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New()
+	}
+	return cfg
+}
+
+// Client discovers nodes by querying DNS trees. It keeps the nodes of the trees
+// it follows up to date in the background.
+type Client struct {
+	cfg Config
+
+	lock  sync.RWMutex
+	trees map[string]*Tree // last synced tree of every URL
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewClient creates a client following the trees at the given enrtree URLs.
+func NewClient(cfg Config, urls ...string) (*Client, error) {
+	for _, url := range urls {
+		if _, _, err := parseURL(url); err != nil {
+			return nil, fmt.Errorf("invalid DNS discovery URL %q: %v", url, err)
+		}
+	}
+	c := &Client{
+		cfg:   cfg.withDefaults(),
+		trees: make(map[string]*Tree),
+		quit:  make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.loop(urls)
+	return c, nil
+}
+
+// Nodes returns the nodes of all the followed trees.
+func (c *Client) Nodes() []*discover.Node {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var nodes []*discover.Node
+	for _, tree := range c.trees {
+		nodes = append(nodes, tree.Nodes()...)
+	}
+	return nodes
+}
+
+// Close stops updating the trees.
+func (c *Client) Close() {
+	select {
+	case <-c.quit:
+	default:
+		close(c.quit)
+	}
+	c.wg.Wait()
+}
+
+// loop syncs the trees of the given URLs on every recheck interval.
+func (c *Client) loop(urls []string) {
+	defer c.wg.Done()
+
+	for {
+		for _, url := range urls {
+			c.lock.RLock()
+			current := c.trees[url]
+			c.lock.RUnlock()
+
+			tree, err := c.syncTree(url, current)
+			if err != nil {
+				c.cfg.Logger.Warn("Failed to sync DNS discovery tree", "url", url, "err", err)
+				continue
+			}
+			if tree != current {
+				c.cfg.Logger.Info("Synced DNS discovery tree", "url", url, "seq", tree.Seq(), "nodes", len(tree.Nodes()))
+				c.lock.Lock()
+				c.trees[url] = tree
+				c.lock.Unlock()
+			}
+		}
+		select {
+		case <-time.After(c.cfg.RecheckInterval):
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// SyncTree downloads the tree at the given URL.
+func (c *Client) SyncTree(url string) (*Tree, error) {
+	return c.syncTree(url, nil)
+}
+
+// syncTree downloads the tree at the given URL, unless its root matches the one
+// of the given current tree, which is returned then.
+func (c *Client) syncTree(url string, current *Tree) (*Tree, error) {
+	domain, pubkey, err := parseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	root, err := c.resolveRoot(domain, pubkey)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.root.eroot == root.eroot && current.root.seq == root.seq {
+		return current, nil
+	}
+	if current != nil && root.seq < current.root.seq {
+		return nil, fmt.Errorf("tree sequence number went backwards: %d < %d", root.seq, current.root.seq)
+	}
+	tree := &Tree{root: root, entries: make(map[string]entry)}
+	if err := c.syncSubtree(tree, domain, root.eroot); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// syncSubtree downloads the entries of the subtree starting at the given hash.
+// Links to other trees are not followed.
+func (c *Client) syncSubtree(tree *Tree, domain, hash string) error {
+	missing := []string{hash}
+	for len(missing) > 0 {
+		hash, missing = missing[0], missing[1:]
+		if _, ok := tree.entries[hash]; ok {
+			continue
+		}
+		if len(tree.entries) >= maxEntries {
+			return fmt.Errorf("tree exceeds %d entries", maxEntries)
+		}
+		e, err := c.resolveEntry(domain, hash)
+		if err != nil {
+			return err
+		}
+		tree.entries[hash] = e
+		if branch, ok := e.(*branchEntry); ok {
+			missing = append(missing, branch.children...)
+		}
+	}
+	return nil
+}
+
+// resolveRoot retrieves the root of the tree at the given domain and verifies its
+// signature.
+func (c *Client) resolveRoot(domain string, pubkey *ecdsa.PublicKey) (*rootEntry, error) {
+	txts, err := c.lookupTXT(domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		if !strings.HasPrefix(txt, rootPrefix) {
+			continue
+		}
+		root, err := parseRoot(txt)
+		if err != nil {
+			return nil, err
+		}
+		if !root.verifySignature(pubkey) {
+			return nil, errInvalidSig
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("no tree root found at %s", domain)
+}
+
+// resolveEntry retrieves the entry stored at the given hash and checks its hash.
+func (c *Client) resolveEntry(domain, hash string) (entry, error) {
+	txts, err := c.lookupTXT(hash + "." + domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		if !checkHash(hash, txt) {
+			continue
+		}
+		return parseEntry(txt)
+	}
+	return nil, fmt.Errorf("no entry with hash %s found at %s", hash, domain)
+}
+
+func (c *Client) lookupTXT(name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	return c.cfg.Resolver.LookupTXT(ctx, name)
+}
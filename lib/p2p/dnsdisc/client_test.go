@@ -0,0 +1,209 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dnsdisc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+func testNodes(t *testing.T, n int) []*discover.Node {
+	nodes := make([]*discover.Node, n)
+	for i := range nodes {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes[i] = discover.NewNode(discover.PubkeyID(&key.PublicKey), net.IP{10, 0, byte(i >> 8), byte(i)}, 3000, 3000)
+	}
+	return nodes
+}
+
+func TestClientSyncTree(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Enough nodes to need several levels of branches
+	nodes := testNodes(t, 3*maxChildren+1)
+	tree, err := MakeTree(1, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, err := tree.Sign(key, "nodes.example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(Config{Resolver: MapResolver(tree.ToTXT("nodes.example.org"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	synced, err := c.SyncTree(url)
+	if err != nil {
+		t.Fatal("sync failed:", err)
+	}
+	if synced.Seq() != 1 {
+		t.Errorf("sequence number mismatch: have %d, want 1", synced.Seq())
+	}
+	have := make(map[discover.NodeID]bool)
+	for _, n := range synced.Nodes() {
+		have[n.ID] = true
+	}
+	if len(have) != len(nodes) {
+		t.Errorf("node count mismatch: have %d, want %d", len(have), len(nodes))
+	}
+	for _, n := range nodes {
+		if !have[n.ID] {
+			t.Errorf("node %x missing from synced tree", n.ID[:8])
+		}
+	}
+}
+
+func TestClientRejectsBadTrees(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := MakeTree(1, testNodes(t, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, err := tree.Sign(key, "n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherURL := linkURL(&other.PublicKey, "n")
+
+	// A tree signed by another key must be rejected
+	c, err := NewClient(Config{Resolver: MapResolver(tree.ToTXT("n"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.SyncTree(otherURL); err != errInvalidSig {
+		t.Errorf("tree with wrong signer accepted: err %v", err)
+	}
+
+	// An entry not matching its hash must be rejected
+	records := tree.ToTXT("n")
+	for name, record := range records {
+		if name != "n" && record[:len(enodePrefix)] == enodePrefix {
+			records[name] = testNodes(t, 1)[0].String()
+			break
+		}
+	}
+	c2, err := NewClient(Config{Resolver: MapResolver(records)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close()
+	if _, err := c2.SyncTree(url); err == nil {
+		t.Errorf("tree with tampered entry accepted")
+	}
+}
+
+// syncedResolver is a MapResolver whose records may be updated concurrently.
+type syncedResolver struct {
+	lock    sync.Mutex
+	records MapResolver
+}
+
+func (r *syncedResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.records.LookupTXT(ctx, name)
+}
+
+func (r *syncedResolver) publish(records map[string]string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for name, record := range records {
+		r.records[name] = record
+	}
+}
+
+func TestClientNodesUpdate(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := testNodes(t, 4)
+	tree1, _ := MakeTree(1, nodes[:2])
+	url, _ := tree1.Sign(key, "n")
+	tree2, _ := MakeTree(2, nodes)
+	tree2.Sign(key, "n")
+
+	resolver := &syncedResolver{records: make(MapResolver)}
+	resolver.publish(tree1.ToTXT("n"))
+	c, err := NewClient(Config{Resolver: resolver, RecheckInterval: 10 * time.Millisecond}, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	waitNodes := func(want int) error {
+		for i := 0; i < 100; i++ {
+			if len(c.Nodes()) == want {
+				return nil
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return fmt.Errorf("node count mismatch: have %d, want %d", len(c.Nodes()), want)
+	}
+	if err := waitNodes(2); err != nil {
+		t.Fatal(err)
+	}
+	// Publishing a new version of the tree must update the nodes
+	resolver.publish(tree2.ToTXT("n"))
+	if err := waitNodes(4); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, pubkey, err := parseURL(linkURL(&key.PublicKey, "nodes.example.org"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain != "nodes.example.org" || pubkey.X.Cmp(key.PublicKey.X) != 0 {
+		t.Errorf("parsed URL mismatch: have %s %x", domain, crypto.CompressPubkey(pubkey))
+	}
+	for _, url := range []string{"enode://abc@n", "enrtree://n", "enrtree://AAAA@n"} {
+		if _, _, err := parseURL(url); err == nil {
+			t.Errorf("invalid URL %q accepted", url)
+		}
+	}
+}
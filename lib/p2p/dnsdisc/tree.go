@@ -0,0 +1,331 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package dnsdisc implements node discovery via signed node lists published in
+// DNS TXT records, in the tree format of EIP-1459. As lib/p2p has no ENR support,
+// the leaves of the tree hold enode URLs instead of ENRs.
+package dnsdisc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+const (
+	rootPrefix   = "enrtree-root:v1"
+	linkPrefix   = "enrtree://"
+	branchPrefix = "enrtree-branch:"
+	enodePrefix  = "enode://"
+
+	// maxChildren is the maximum number of children of a branch, keeping its
+	// TXT record within the size of a DNS UDP response.
+	maxChildren = 13
+
+	// hashAbbrev is the number of bytes of an entry hash used as its subdomain.
+	hashAbbrev = 16
+)
+
+var (
+	b32format = base32.StdEncoding.WithPadding(base32.NoPadding)
+	b64format = base64.RawURLEncoding
+)
+
+var (
+	errUnknownEntry = errors.New("unknown entry type")
+	errNoPubkey     = errors.New("missing public key")
+	errBadPubkey    = errors.New("invalid public key")
+	errInvalidSig   = errors.New("invalid signature")
+	errSyntax       = errors.New("invalid syntax")
+)
+
+// Tree is a signed tree of enode URLs.
+type Tree struct {
+	root    *rootEntry
+	entries map[string]entry
+}
+
+type (
+	entry interface {
+		fmt.Stringer
+	}
+	rootEntry struct {
+		eroot string
+		lroot string
+		seq   uint
+		sig   []byte
+	}
+	branchEntry struct {
+		children []string
+	}
+	enodeEntry struct {
+		node *discover.Node
+	}
+)
+
+// MakeTree creates a tree containing the given nodes, to be signed with Sign.
+func MakeTree(seq uint, nodes []*discover.Node) (*Tree, error) {
+	tree := &Tree{entries: make(map[string]entry)}
+
+	// Sort the leaves so the same nodes always make the same tree
+	leaves := make([]entry, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Incomplete() {
+			return nil, fmt.Errorf("can't add incomplete node %v to tree", n)
+		}
+		leaves = append(leaves, &enodeEntry{n})
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].String() < leaves[j].String()
+	})
+	eroot := tree.build(leaves)
+	lroot := tree.build(nil)
+	tree.root = &rootEntry{eroot: subdomain(eroot), lroot: subdomain(lroot), seq: seq}
+	return tree, nil
+}
+
+// build adds the given entries to the tree as leaves of a subtree, returning
+// the root of the subtree.
+func (t *Tree) build(entries []entry) entry {
+	if len(entries) == 1 {
+		t.entries[subdomain(entries[0])] = entries[0]
+		return entries[0]
+	}
+	if len(entries) <= maxChildren {
+		branch := &branchEntry{}
+		for _, e := range entries {
+			sd := subdomain(e)
+			t.entries[sd] = e
+			branch.children = append(branch.children, sd)
+		}
+		t.entries[subdomain(branch)] = branch
+		return branch
+	}
+	var subtrees []entry
+	for len(entries) > 0 {
+		n := maxChildren
+		if len(entries) < n {
+			n = len(entries)
+		}
+		subtrees = append(subtrees, t.build(entries[:n]))
+		entries = entries[n:]
+	}
+	return t.build(subtrees)
+}
+
+// Sign signs the tree with the given private key and returns the URL of the tree
+// published at the given domain.
+func (t *Tree) Sign(key *ecdsa.PrivateKey, domain string) (string, error) {
+	root := *t.root
+	sig, err := crypto.Sign(root.sigHash(), key)
+	if err != nil {
+		return "", err
+	}
+	root.sig = sig
+	t.root = &root
+	return linkURL(&key.PublicKey, domain), nil
+}
+
+// Seq returns the sequence number of the tree.
+func (t *Tree) Seq() uint {
+	return t.root.seq
+}
+
+// Nodes returns all nodes contained in the tree.
+func (t *Tree) Nodes() []*discover.Node {
+	var nodes []*discover.Node
+	for _, e := range t.entries {
+		if ee, ok := e.(*enodeEntry); ok {
+			nodes = append(nodes, ee.node)
+		}
+	}
+	return nodes
+}
+
+// ToTXT returns all DNS TXT records of the tree, keyed by name, to be published
+// at the given domain.
+func (t *Tree) ToTXT(domain string) map[string]string {
+	records := map[string]string{domain: t.root.String()}
+	for sd, e := range t.entries {
+		name := sd
+		if domain != "" {
+			name = sd + "." + domain
+		}
+		records[name] = e.String()
+	}
+	return records
+}
+
+// Entry encoding.
+
+func (e *rootEntry) sigHash() []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("%s e=%s l=%s seq=%d", rootPrefix, e.eroot, e.lroot, e.seq)))
+}
+
+func (e *rootEntry) verifySignature(pubkey *ecdsa.PublicKey) bool {
+	if len(e.sig) != 65 {
+		return false
+	}
+	signer, err := crypto.SigToPub(e.sigHash(), e.sig)
+	if err != nil || signer == nil {
+		return false
+	}
+	return bytes.Equal(crypto.CompressPubkey(signer), crypto.CompressPubkey(pubkey))
+}
+
+func (e *rootEntry) String() string {
+	return fmt.Sprintf("%s e=%s l=%s seq=%d sig=%s", rootPrefix, e.eroot, e.lroot, e.seq, b64format.EncodeToString(e.sig))
+}
+
+func (e *branchEntry) String() string {
+	return branchPrefix + strings.Join(e.children, ",")
+}
+
+func (e *enodeEntry) String() string {
+	return e.node.String()
+}
+
+// subdomain returns the name of the subdomain holding the given entry.
+func subdomain(e entry) string {
+	h := crypto.Keccak256([]byte(e.String()))
+	return b32format.EncodeToString(h[:hashAbbrev])
+}
+
+// linkURL returns the enrtree URL of the tree signed by the given key at domain.
+func linkURL(pubkey *ecdsa.PublicKey, domain string) string {
+	return linkPrefix + b32format.EncodeToString(crypto.CompressPubkey(pubkey)) + "@" + domain
+}
+
+// Entry parsing.
+
+func parseEntry(e string) (entry, error) {
+	switch {
+	case strings.HasPrefix(e, branchPrefix):
+		return parseBranch(e[len(branchPrefix):])
+	case strings.HasPrefix(e, enodePrefix):
+		node, err := discover.ParseNode(e)
+		if err != nil {
+			return nil, err
+		}
+		if node.Incomplete() {
+			return nil, fmt.Errorf("incomplete node %v", node)
+		}
+		return &enodeEntry{node}, nil
+	default:
+		return nil, errUnknownEntry
+	}
+}
+
+func parseRoot(e string) (*rootEntry, error) {
+	var (
+		eroot, lroot, sig string
+		seq               uint
+	)
+	if !strings.HasPrefix(e, rootPrefix+" ") {
+		return nil, errUnknownEntry
+	}
+	for _, field := range strings.Fields(e[len(rootPrefix):]) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return nil, errSyntax
+		}
+		switch kv[0] {
+		case "e":
+			eroot = kv[1]
+		case "l":
+			lroot = kv[1]
+		case "seq":
+			n, err := strconv.ParseUint(kv[1], 10, 32)
+			if err != nil {
+				return nil, errSyntax
+			}
+			seq = uint(n)
+		case "sig":
+			sig = kv[1]
+		}
+	}
+	if !isValidHash(eroot) || !isValidHash(lroot) {
+		return nil, errSyntax
+	}
+	sigb, err := b64format.DecodeString(sig)
+	if err != nil || len(sigb) != 65 {
+		return nil, errInvalidSig
+	}
+	return &rootEntry{eroot: eroot, lroot: lroot, seq: seq, sig: sigb}, nil
+}
+
+func parseBranch(e string) (*branchEntry, error) {
+	if e == "" {
+		return &branchEntry{}, nil
+	}
+	children := strings.Split(e, ",")
+	for _, c := range children {
+		if !isValidHash(c) {
+			return nil, errSyntax
+		}
+	}
+	return &branchEntry{children}, nil
+}
+
+// parseURL parses an enrtree URL into the domain of the tree and the public key
+// signing it.
+func parseURL(url string) (string, *ecdsa.PublicKey, error) {
+	if !strings.HasPrefix(url, linkPrefix) {
+		return "", nil, errors.New("wrong/missing scheme 'enrtree' in URL")
+	}
+	pos := strings.IndexByte(url, '@')
+	if pos == -1 {
+		return "", nil, errNoPubkey
+	}
+	keystring, domain := url[len(linkPrefix):pos], url[pos+1:]
+	keybytes, err := b32format.DecodeString(keystring)
+	if err != nil {
+		return "", nil, errBadPubkey
+	}
+	key, err := crypto.DecompressPubkey(keybytes)
+	if err != nil {
+		return "", nil, errBadPubkey
+	}
+	return domain, key, nil
+}
+
+func isValidHash(s string) bool {
+	dlen := b32format.DecodedLen(len(s))
+	if dlen < 12 || dlen > 32 || strings.ContainsAny(s, "\n\r") {
+		return false
+	}
+	buf := make([]byte, 32)
+	_, err := b32format.Decode(buf, []byte(s))
+	return err == nil
+}
+
+// checkHash reports whether the given entry text is stored at its subdomain.
+func checkHash(sd string, e string) bool {
+	h := crypto.Keccak256([]byte(e))
+	want, err := b32format.DecodeString(sd)
+	return err == nil && len(want) <= len(h) && bytes.Equal(h[:len(want)], want)
+}
@@ -0,0 +1,150 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+// nodesFileReloadInterval is the interval to check a nodes file for changes.
+const nodesFileReloadInterval = time.Minute
+
+// NodeSource provides dial candidates alongside the discovery table, such as the
+// nodes of a DNS tree or of a nodes file. Sources keep their nodes up to date in
+// the background until closed.
+type NodeSource interface {
+	// Nodes returns the current nodes of the source.
+	Nodes() []*discover.Node
+
+	// Close stops updating the nodes.
+	Close()
+}
+
+// FileSource is a NodeSource reading a JSON list of enode URLs from a file,
+// reloaded whenever the file changes.
+type FileSource struct {
+	path string
+	log  log.Logger
+
+	lock    sync.RWMutex
+	nodes   []*discover.Node
+	modTime time.Time
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewFileSource creates a FileSource for the nodes file at the given path,
+// checking it for changes on every interval.
+func NewFileSource(path string, interval time.Duration, logger log.Logger) *FileSource {
+	if interval == 0 {
+		interval = nodesFileReloadInterval
+	}
+	if logger == nil {
+		logger = log.New()
+	}
+	s := &FileSource{
+		path: path,
+		log:  logger.New("nodes", path),
+		quit: make(chan struct{}),
+	}
+	s.reload()
+
+	s.wg.Add(1)
+	go s.loop(interval)
+	return s
+}
+
+// Nodes returns the nodes last loaded from the file.
+func (s *FileSource) Nodes() []*discover.Node {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.nodes
+}
+
+// Close stops reloading the file.
+func (s *FileSource) Close() {
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+	s.wg.Wait()
+}
+
+func (s *FileSource) loop(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.reload()
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// reload loads the nodes of the file if it changed since the last load. Invalid
+// files and URLs are logged, keeping the nodes loaded so far.
+func (s *FileSource) reload() {
+	stat, err := os.Stat(s.path)
+	if err != nil {
+		s.log.Warn("Can't read nodes file", "err", err)
+		return
+	}
+	if stat.ModTime().Equal(s.modTime) {
+		return
+	}
+	blob, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		s.log.Warn("Can't read nodes file", "err", err)
+		return
+	}
+	var urls []string
+	if err := json.Unmarshal(blob, &urls); err != nil {
+		s.log.Warn("Can't parse nodes file", "err", err)
+		return
+	}
+	nodes := make([]*discover.Node, 0, len(urls))
+	for _, url := range urls {
+		node, err := discover.ParseNode(url)
+		if err != nil {
+			s.log.Warn("Skipping invalid node URL", "url", url, "err", err)
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	s.lock.Lock()
+	s.nodes, s.modTime = nodes, stat.ModTime()
+	s.lock.Unlock()
+
+	s.log.Info("Loaded nodes file", "nodes", len(nodes))
+}
@@ -0,0 +1,134 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+// testSourceNode returns the i-th node of a test nodes file.
+func testSourceNode(i byte) *discover.Node {
+	return discover.NewNode(discover.NodeID{i}, net.IP{127, 0, 0, i}, 30303, 30303)
+}
+
+// writeNodesFile writes the given URLs as nodes file, moving its modification
+// time forward so that the change is noticed whatever the timestamp resolution.
+func writeNodesFile(t *testing.T, path string, urls []string, modTime time.Time) {
+	blob, err := json.Marshal(urls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitNodes waits for the source to return the given number of nodes.
+func waitNodes(t *testing.T, s *FileSource, n int) []*discover.Node {
+	t.Helper()
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		nodes := s.Nodes()
+		if len(nodes) == n {
+			return nodes
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("source has %d nodes, want %d", len(nodes), n)
+		}
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodesource")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nodes.json")
+	modTime := time.Now().Add(-time.Hour)
+
+	// Invalid URLs are skipped.
+	writeNodesFile(t, path, []string{testSourceNode(1).String(), "enode://invalid", testSourceNode(2).String()}, modTime)
+	s := NewFileSource(path, 10*time.Millisecond, nil)
+	defer s.Close()
+
+	nodes := s.Nodes()
+	if len(nodes) != 2 || nodes[0].ID != testSourceNode(1).ID || nodes[1].ID != testSourceNode(2).ID {
+		t.Fatalf("loaded nodes %v, want nodes 1 and 2", nodes)
+	}
+
+	// Changes of the file are reloaded.
+	modTime = modTime.Add(time.Second)
+	writeNodesFile(t, path, []string{testSourceNode(3).String()}, modTime)
+	if nodes := waitNodes(t, s, 1); nodes[0].ID != testSourceNode(3).ID {
+		t.Fatalf("reloaded nodes %v, want node 3", nodes)
+	}
+
+	// An invalid file keeps the nodes loaded so far.
+	modTime = modTime.Add(time.Second)
+	if err := ioutil.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if nodes := s.Nodes(); len(nodes) != 1 || nodes[0].ID != testSourceNode(3).ID {
+		t.Fatalf("nodes %v after invalid file, want node 3", nodes)
+	}
+
+	// So does a removed one.
+	os.Remove(path)
+	time.Sleep(50 * time.Millisecond)
+	if nodes := s.Nodes(); len(nodes) != 1 {
+		t.Fatalf("nodes %v after removed file, want node 3", nodes)
+	}
+
+	// Closing twice is fine.
+	s.Close()
+	s.Close()
+}
+
+func TestFileSourceMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodesource")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nodes.json")
+
+	s := NewFileSource(path, 10*time.Millisecond, nil)
+	defer s.Close()
+	if nodes := s.Nodes(); len(nodes) != 0 {
+		t.Fatalf("nodes %v without a file", nodes)
+	}
+	// The file is loaded once created.
+	writeNodesFile(t, path, []string{testSourceNode(1).String(), testSourceNode(2).String()}, time.Now())
+	waitNodes(t, s, 2)
+}
@@ -118,6 +118,9 @@ type Peer struct {
 	// events receives message send / receive events if set
 	events *event.Feed
 
+	// scorer tracks the reputation of the peer, set by the server
+	scorer *peerScorer
+
 	// Peer data
 	Data *common.CMap
 
@@ -203,6 +206,16 @@ func newPeer(conn *conn, protocols []Protocol) *Peer {
 	return p
 }
 
+// Report charges the peer with a misbehavior. Once its accumulated penalty
+// crosses the ban threshold, the peer is disconnected and banned.
+func (p *Peer) Report(m Misbehavior, err error) {
+	if p.scorer == nil {
+		p.log.Debug("Peer misbehaved", "misbehavior", m.Name, "err", err)
+		return
+	}
+	p.scorer.report(p.ID(), m, err)
+}
+
 func (p *Peer) Log() log.Logger {
 	return p.log
 }
@@ -0,0 +1,243 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+const (
+	// defaultBanThreshold is the accumulated penalty at which a peer gets banned.
+	defaultBanThreshold = 100
+
+	// defaultBanDuration is how long a peer stays banned after crossing the
+	// ban threshold.
+	defaultBanDuration = 24 * time.Hour
+
+	// penaltyDecayPerMinute is the amount of penalty a peer is forgiven every
+	// minute, so that occasional faults of honest peers never add up to a ban.
+	penaltyDecayPerMinute = 10
+)
+
+// Misbehavior is a protocol violation a remote peer can be penalized for.
+type Misbehavior struct {
+	Name    string // Short description, used in logs
+	Penalty int    // Penalty charged to the peer
+}
+
+var (
+	MisbehaviorInvalidMsg       = Misbehavior{"invalid message", 20}
+	MisbehaviorInvalidTx        = Misbehavior{"invalid transaction", 5}
+	MisbehaviorInvalidVote      = Misbehavior{"invalid vote", 25}
+	MisbehaviorInvalidProposal  = Misbehavior{"invalid proposal", 25}
+	MisbehaviorInvalidBlockPart = Misbehavior{"invalid block part", 25}
+)
+
+// banStore persists bans across restarts. It is implemented by the discovery
+// table, which keeps them in its node database.
+type banStore interface {
+	BanNode(id discover.NodeID, until time.Time) error
+	UnbanNode(id discover.NodeID) error
+	BannedNodes() map[discover.NodeID]time.Time
+}
+
+// PeerScore is a summary of the reputation of a remote peer.
+type PeerScore struct {
+	ID          string    `json:"id"`          // Unique node identifier
+	Penalty     int       `json:"penalty"`     // Current penalty after decay
+	LastOffense string    `json:"lastOffense"` // Description of the last reported misbehavior
+	BannedUntil time.Time `json:"bannedUntil"` // Expiry of the ban, zero if not banned
+}
+
+type peerPenalty struct {
+	value       float64
+	updated     time.Time
+	lastOffense string
+}
+
+// decay forgives the penalty accrued since the last update.
+func (p *peerPenalty) decay(now time.Time) {
+	p.value -= now.Sub(p.updated).Minutes() * penaltyDecayPerMinute
+	if p.value < 0 {
+		p.value = 0
+	}
+	p.updated = now
+}
+
+// peerScorer tracks the penalties of remote peers and bans the ones crossing
+// the threshold. Bans are keyed by node ID and are optionally persisted.
+type peerScorer struct {
+	threshold  int
+	duration   time.Duration
+	store      banStore                 // Persistent ban storage, nil if discovery is off
+	disconnect func(id discover.NodeID) // Drops the connection of a banned peer
+	log        log.Logger
+
+	lock      sync.Mutex
+	penalties map[discover.NodeID]*peerPenalty
+	bans      map[discover.NodeID]time.Time
+}
+
+func newPeerScorer(threshold int, duration time.Duration, logger log.Logger) *peerScorer {
+	if threshold <= 0 {
+		threshold = defaultBanThreshold
+	}
+	if duration <= 0 {
+		duration = defaultBanDuration
+	}
+	return &peerScorer{
+		threshold: threshold,
+		duration:  duration,
+		log:       logger,
+		penalties: make(map[discover.NodeID]*peerPenalty),
+		bans:      make(map[discover.NodeID]time.Time),
+	}
+}
+
+// setStore attaches the persistent ban storage and loads the bans it holds.
+func (s *peerScorer) setStore(store banStore) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.store = store
+	for id, until := range store.BannedNodes() {
+		s.bans[id] = until
+	}
+}
+
+// report charges the peer with the penalty of the given misbehavior, banning
+// and disconnecting it once its penalty reaches the threshold.
+func (s *peerScorer) report(id discover.NodeID, m Misbehavior, err error) {
+	s.lock.Lock()
+	now := time.Now()
+	if until, ok := s.bans[id]; ok && now.Before(until) {
+		// Already banned, any further offense is moot
+		s.lock.Unlock()
+		return
+	}
+	p, ok := s.penalties[id]
+	if !ok {
+		p = &peerPenalty{updated: now}
+		s.penalties[id] = p
+	}
+	p.decay(now)
+	p.value += float64(m.Penalty)
+	p.lastOffense = m.Name
+
+	s.log.Debug("Peer misbehaved", "id", id, "misbehavior", m.Name, "penalty", int(p.value), "err", err)
+	if p.value < float64(s.threshold) {
+		s.lock.Unlock()
+		return
+	}
+	s.log.Warn("Banning misbehaving peer", "id", id, "misbehavior", m.Name, "duration", s.duration)
+	s.banLocked(id, now.Add(s.duration))
+	s.lock.Unlock()
+
+	if s.disconnect != nil {
+		s.disconnect(id)
+	}
+}
+
+// ban bans the peer until the given time and disconnects it.
+func (s *peerScorer) ban(id discover.NodeID, until time.Time) error {
+	s.lock.Lock()
+	err := s.banLocked(id, until)
+	s.lock.Unlock()
+
+	if s.disconnect != nil {
+		s.disconnect(id)
+	}
+	return err
+}
+
+func (s *peerScorer) banLocked(id discover.NodeID, until time.Time) error {
+	s.bans[id] = until
+	if s.store != nil {
+		if err := s.store.BanNode(id, until); err != nil {
+			s.log.Error("Failed to persist peer ban", "id", id, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// unban lifts the ban of the peer and clears its penalty.
+func (s *peerScorer) unban(id discover.NodeID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.bans, id)
+	delete(s.penalties, id)
+	if s.store != nil {
+		return s.store.UnbanNode(id)
+	}
+	return nil
+}
+
+// banned reports whether the peer is currently banned.
+func (s *peerScorer) banned(id discover.NodeID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	until, ok := s.bans[id]
+	if !ok {
+		return false
+	}
+	if time.Now().Before(until) {
+		return true
+	}
+	delete(s.bans, id)
+	delete(s.penalties, id)
+	return false
+}
+
+// scores returns the reputation of every peer that either misbehaved or is
+// banned.
+func (s *peerScorer) scores() []*PeerScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	summaries := make(map[discover.NodeID]*PeerScore)
+	for id, p := range s.penalties {
+		if p.decay(now); p.value == 0 {
+			delete(s.penalties, id)
+			continue
+		}
+		summaries[id] = &PeerScore{ID: id.String(), Penalty: int(p.value), LastOffense: p.lastOffense}
+	}
+	for id, until := range s.bans {
+		if !now.Before(until) {
+			continue
+		}
+		if _, ok := summaries[id]; !ok {
+			summaries[id] = &PeerScore{ID: id.String()}
+		}
+		summaries[id].BannedUntil = until
+	}
+	scores := make([]*PeerScore, 0, len(summaries))
+	for _, score := range summaries {
+		scores = append(scores, score)
+	}
+	return scores
+}
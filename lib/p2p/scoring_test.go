@@ -0,0 +1,145 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+// memBanStore is an in-memory banStore.
+type memBanStore map[discover.NodeID]time.Time
+
+func (s memBanStore) BanNode(id discover.NodeID, until time.Time) error {
+	s[id] = until
+	return nil
+}
+
+func (s memBanStore) UnbanNode(id discover.NodeID) error {
+	delete(s, id)
+	return nil
+}
+
+func (s memBanStore) BannedNodes() map[discover.NodeID]time.Time {
+	nodes := make(map[discover.NodeID]time.Time, len(s))
+	for id, until := range s {
+		nodes[id] = until
+	}
+	return nodes
+}
+
+func TestPeerPenaltyDecay(t *testing.T) {
+	now := time.Now()
+	p := &peerPenalty{value: 50, updated: now}
+	p.decay(now.Add(2 * time.Minute))
+	if p.value != 50-2*penaltyDecayPerMinute {
+		t.Errorf("penalty after 2 minutes = %v, want %v", p.value, 50-2*penaltyDecayPerMinute)
+	}
+	p.decay(now.Add(time.Hour))
+	if p.value != 0 {
+		t.Errorf("penalty after an hour = %v, want 0", p.value)
+	}
+}
+
+func TestPeerScorerBan(t *testing.T) {
+	var disconnected []discover.NodeID
+	s := newPeerScorer(50, time.Hour, log.New())
+	s.disconnect = func(id discover.NodeID) { disconnected = append(disconnected, id) }
+	id := discover.NodeID{1}
+
+	s.report(id, MisbehaviorInvalidVote, nil)
+	if s.banned(id) || len(disconnected) != 0 {
+		t.Fatalf("peer banned below the threshold")
+	}
+	// The penalty decays, so offenses far apart never add up to a ban.
+	s.penalties[id].updated = time.Now().Add(-time.Hour)
+	s.report(id, MisbehaviorInvalidVote, nil)
+	if s.banned(id) {
+		t.Fatalf("peer banned for decayed offenses")
+	}
+	s.report(id, MisbehaviorInvalidVote, nil)
+	s.report(id, MisbehaviorInvalidVote, nil)
+	if !s.banned(id) || len(disconnected) != 1 || disconnected[0] != id {
+		t.Fatalf("peer not banned and disconnected at the threshold: banned %v, disconnected %v", s.banned(id), disconnected)
+	}
+	scores := s.scores()
+	if len(scores) != 1 || scores[0].LastOffense != MisbehaviorInvalidVote.Name || scores[0].BannedUntil.IsZero() {
+		t.Errorf("unexpected scores %+v", scores)
+	}
+
+	// Expired bans are lifted with the penalty.
+	s.bans[id] = time.Now().Add(-time.Second)
+	if s.banned(id) {
+		t.Errorf("expired ban still effective")
+	}
+	if len(s.scores()) != 0 {
+		t.Errorf("penalty kept past the ban")
+	}
+}
+
+func TestPeerScorerPersistence(t *testing.T) {
+	store := memBanStore{}
+	banned, other := discover.NodeID{1}, discover.NodeID{2}
+
+	s := newPeerScorer(0, 0, log.New())
+	s.setStore(store)
+	if err := s.ban(banned, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ban(other, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.unban(other); err != nil {
+		t.Fatal(err)
+	}
+	if len(store) != 1 {
+		t.Fatalf("stored bans = %v, want only %v", store, banned)
+	}
+
+	// A restarted scorer loads the bans back.
+	s = newPeerScorer(0, 0, log.New())
+	s.setStore(store)
+	if !s.banned(banned) || s.banned(other) {
+		t.Errorf("bans not restored: %v banned %v, %v banned %v", banned, s.banned(banned), other, s.banned(other))
+	}
+	if err := s.unban(banned); err != nil {
+		t.Fatal(err)
+	}
+	if s.banned(banned) || len(store) != 0 {
+		t.Errorf("unban not persisted: %v", store)
+	}
+}
+
+func TestServerScoringBeforeStart(t *testing.T) {
+	srv := &Server{}
+	id := discover.NodeID{1}
+	srv.ReportPeer(id, MisbehaviorInvalidMsg, nil)
+	if err := srv.BanPeer(id, 0); err != errServerStopped {
+		t.Errorf("BanPeer error = %v, want %v", err, errServerStopped)
+	}
+	if err := srv.UnbanPeer(id); err != errServerStopped {
+		t.Errorf("UnbanPeer error = %v, want %v", err, errServerStopped)
+	}
+	if scores := srv.PeerScores(); scores != nil {
+		t.Errorf("PeerScores = %v, want nil", scores)
+	}
+}
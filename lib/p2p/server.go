@@ -29,6 +29,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/event"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+	"github.com/kardiachain/go-kardia/lib/p2p/dnsdisc"
 	"github.com/kardiachain/go-kardia/lib/p2p/nat"
 	"github.com/kardiachain/go-kardia/lib/p2p/netutil"
 	"github.com/kardiachain/go-kardia/lib/sysutils"
@@ -99,6 +100,18 @@ type Config struct {
 	// allowed to connect, even above the peer limit.
 	TrustedNodes []*discover.Node
 
+	// DNSDiscoveryURLs are the enrtree:// URLs of signed DNS trees listing nodes
+	// to dial alongside the ones found by discovery.
+	DNSDiscoveryURLs []string `toml:",omitempty"`
+
+	// DNSResolver is the resolver used to query the DNS trees. Nil defaults to
+	// the system resolver.
+	DNSResolver dnsdisc.Resolver `toml:"-"`
+
+	// NodesFile is the path of a JSON list of enode URLs to dial alongside the
+	// nodes found by discovery. The file is reloaded whenever it changes.
+	NodesFile string `toml:",omitempty"`
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
@@ -108,6 +121,14 @@ type Config struct {
 	// live nodes in the network.
 	NodeDatabase string `toml:",omitempty"`
 
+	// BanThreshold is the accumulated misbehavior penalty at which a peer gets
+	// disconnected and banned. Zero defaults to preset values.
+	BanThreshold int `toml:",omitempty"`
+
+	// BanDuration is how long a misbehaving peer stays banned. Bans are kept in
+	// the node database so they survive restarts. Zero defaults to preset values.
+	BanDuration time.Duration `toml:",omitempty"`
+
 	// Protocols should contain the protocols supported
 	// by the server. Matching protocols are launched for
 	// each peer.
@@ -151,10 +172,14 @@ type Server struct {
 	newTransport func(net.Conn) transport
 	newPeerHook  func(*Peer)
 
-	lock    sync.Mutex // protects running
+	lock    sync.Mutex // protects running and scorer
 	running bool
 
 	ntab         discoverTable
+	sources      []NodeSource
+	scorer       *peerScorer
+	admitLock    sync.RWMutex // protects admitChecks
+	admitChecks  []AdmissionCheck
 	listener     net.Listener
 	ourHandshake *protoHandshake
 	lastLookup   time.Time
@@ -167,6 +192,9 @@ type Server struct {
 	quit          chan struct{}
 	addstatic     chan *discover.Node
 	removestatic  chan *discover.Node
+	addtrusted    chan *discover.Node
+	removetrusted chan *discover.Node
+	trustedOp     chan trustedOpFunc
 	posthandshake chan *conn
 	addpeer       chan *conn
 	delpeer       chan peerDrop
@@ -177,6 +205,12 @@ type Server struct {
 
 type peerOpFunc func(map[discover.NodeID]*Peer)
 
+// AdmissionCheck decides whether a remote node may connect, returning a non-nil
+// error to reject it.
+type AdmissionCheck func(id discover.NodeID) error
+
+type trustedOpFunc func(map[discover.NodeID]bool)
+
 type peerDrop struct {
 	*Peer
 	err       error
@@ -303,6 +337,124 @@ func (srv *Server) RemovePeer(node *discover.Node) {
 	}
 }
 
+// AddTrustedPeer adds the given node to a reserved whitelist which allows the
+// node to always connect, even if the slots are full. The flag applies to new
+// connections of the node.
+func (srv *Server) AddTrustedPeer(node *discover.Node) {
+	select {
+	case srv.addtrusted <- node:
+	case <-srv.quit:
+	}
+}
+
+// RemoveTrustedPeer removes the given node from the trusted peer set.
+func (srv *Server) RemoveTrustedPeer(node *discover.Node) {
+	select {
+	case srv.removetrusted <- node:
+	case <-srv.quit:
+	}
+}
+
+// TrustedPeers returns the IDs of all trusted nodes.
+func (srv *Server) TrustedPeers() []discover.NodeID {
+	var ids []discover.NodeID
+	select {
+	case srv.trustedOp <- func(trusted map[discover.NodeID]bool) {
+		for id := range trusted {
+			ids = append(ids, id)
+		}
+	}:
+		<-srv.peerOpDone
+	case <-srv.quit:
+	}
+	return ids
+}
+
+// ReportPeer charges the given peer with a misbehavior. Peers whose accumulated
+// penalty crosses the ban threshold are disconnected and banned.
+func (srv *Server) ReportPeer(id discover.NodeID, m Misbehavior, err error) {
+	if scorer := srv.peerScorer(); scorer != nil {
+		scorer.report(id, m, err)
+	}
+}
+
+// BanPeer disconnects the given peer and refuses any connection with it for
+// the given duration. Zero bans the peer for the configured ban duration.
+func (srv *Server) BanPeer(id discover.NodeID, duration time.Duration) error {
+	scorer := srv.peerScorer()
+	if scorer == nil {
+		return errServerStopped
+	}
+	if duration <= 0 {
+		duration = scorer.duration
+	}
+	return scorer.ban(id, time.Now().Add(duration))
+}
+
+// UnbanPeer lifts the ban of the given peer and clears its penalty.
+func (srv *Server) UnbanPeer(id discover.NodeID) error {
+	scorer := srv.peerScorer()
+	if scorer == nil {
+		return errServerStopped
+	}
+	return scorer.unban(id)
+}
+
+// PeerScores returns the reputation of every peer that either misbehaved
+// recently or is banned.
+func (srv *Server) PeerScores() []*PeerScore {
+	scorer := srv.peerScorer()
+	if scorer == nil {
+		return nil
+	}
+	return scorer.scores()
+}
+
+// peerScorer returns the peer scorer, nil until the server is started.
+func (srv *Server) peerScorer() *peerScorer {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+	return srv.scorer
+}
+
+// AddAdmissionCheck adds a check run on the identity of every remote node during
+// the handshakes, before it becomes a peer. Nodes failing any of the checks are
+// rejected, unless they are trusted. Checks run concurrently for the connections
+// being set up.
+func (srv *Server) AddAdmissionCheck(check AdmissionCheck) {
+	srv.admitLock.Lock()
+	defer srv.admitLock.Unlock()
+
+	srv.admitChecks = append(srv.admitChecks, check)
+}
+
+// admitted reports whether the node passes all admission checks.
+func (srv *Server) admitted(id discover.NodeID) bool {
+	srv.admitLock.RLock()
+	defer srv.admitLock.RUnlock()
+
+	for _, check := range srv.admitChecks {
+		if err := check(id); err != nil {
+			srv.log.Debug("Rejected node by admission check", "id", id, "err", err)
+			return false
+		}
+	}
+	return true
+}
+
+// disconnectPeer drops the connection with the given peer, if any.
+func (srv *Server) disconnectPeer(id discover.NodeID) {
+	select {
+	case srv.peerOp <- func(peers map[discover.NodeID]*Peer) {
+		if p, ok := peers[id]; ok {
+			p.Disconnect(DiscUselessPeer)
+		}
+	}:
+		<-srv.peerOpDone
+	case <-srv.quit:
+	}
+}
+
 // SubscribePeers subscribes the given channel to peer events
 func (srv *Server) SubscribeEvents(ch chan *PeerEvent) event.Subscription {
 	return srv.peerFeed.Subscribe(ch)
@@ -354,6 +506,10 @@ func (srv *Server) Stop() {
 	}
 	close(srv.quit)
 	srv.loopWG.Wait()
+	for _, source := range srv.sources {
+		source.Close()
+	}
+	srv.sources = nil
 }
 
 /*
@@ -412,12 +568,18 @@ func (srv *Server) Start() (err error) {
 		srv.Dialer = TCPDialer{&net.Dialer{Timeout: defaultDialTimeout}}
 	}
 
+	srv.scorer = newPeerScorer(srv.BanThreshold, srv.BanDuration, srv.log)
+	srv.scorer.disconnect = srv.disconnectPeer
+
 	srv.quit = make(chan struct{})
 	srv.addpeer = make(chan *conn)
 	srv.delpeer = make(chan peerDrop)
 	srv.posthandshake = make(chan *conn)
 	srv.addstatic = make(chan *discover.Node)
 	srv.removestatic = make(chan *discover.Node)
+	srv.addtrusted = make(chan *discover.Node)
+	srv.removetrusted = make(chan *discover.Node)
+	srv.trustedOp = make(chan trustedOpFunc)
 	srv.peerOp = make(chan peerOpFunc)
 	srv.peerOpDone = make(chan struct{})
 
@@ -427,6 +589,23 @@ func (srv *Server) Start() (err error) {
 		realaddr  *net.UDPAddr
 		unhandled chan discover.ReadPacket
 	)
+	// release what was set up if the server fails to start
+	defer func() {
+		if err == nil {
+			return
+		}
+		srv.running = false
+		close(srv.quit)
+		if srv.ntab != nil {
+			srv.ntab.Close()
+		} else if conn != nil {
+			conn.Close()
+		}
+		for _, source := range srv.sources {
+			source.Close()
+		}
+		srv.sources = nil
+	}()
 
 	if !srv.NoDiscovery || srv.DiscoveryV5 {
 		addr, err := net.ResolveUDPAddr("udp", srv.ListenAddr)
@@ -471,6 +650,7 @@ func (srv *Server) Start() (err error) {
 			return err
 		}
 		srv.ntab = ntab
+		srv.scorer.setStore(ntab)
 	}
 	/*@huny
 	if srv.DiscoveryV5 {
@@ -493,7 +673,12 @@ func (srv *Server) Start() (err error) {
 	}
 	*/
 	dynPeers := srv.maxDialedConns()
+	if err := srv.setupNodeSources(); err != nil {
+		return err
+	}
 	dialer := newDialState(srv.StaticNodes, srv.BootstrapNodes, srv.ntab, dynPeers, srv.NetRestrict)
+	dialer.banned = srv.scorer.banned
+	dialer.sources = srv.sources
 
 	// handshake
 	srv.ourHandshake = &protoHandshake{Version: baseProtocolVersion, Name: srv.Name, ID: discover.PubkeyID(&srv.PrivateKey.PublicKey)}
@@ -517,6 +702,21 @@ func (srv *Server) Start() (err error) {
 	return nil
 }
 
+// setupNodeSources starts the configured sources of dial candidates.
+func (srv *Server) setupNodeSources() error {
+	if len(srv.DNSDiscoveryURLs) > 0 {
+		client, err := dnsdisc.NewClient(dnsdisc.Config{Resolver: srv.DNSResolver, Logger: srv.log}, srv.DNSDiscoveryURLs...)
+		if err != nil {
+			return err
+		}
+		srv.sources = append(srv.sources, client)
+	}
+	if srv.NodesFile != "" {
+		srv.sources = append(srv.sources, NewFileSource(srv.NodesFile, 0, srv.log))
+	}
+	return nil
+}
+
 func (srv *Server) startListening() error {
 	// Launch the TCP listener.
 	listener, err := net.Listen("tcp", srv.ListenAddr)
@@ -559,8 +759,8 @@ func (srv *Server) run(dialstate dialer) {
 		queuedTasks  []task // tasks that can't run yet
 	)
 	// Put trusted nodes into a map to speed up checks.
-	// Trusted peers are loaded on startup and cannot be
-	// modified while the server is running.
+	// Trusted peers are loaded on startup and can be
+	// modified through AddTrustedPeer and RemoveTrustedPeer.
 	for _, n := range srv.TrustedNodes {
 		trusted[n.ID] = true
 	}
@@ -618,6 +818,20 @@ running:
 			if p, ok := peers[n.ID]; ok {
 				p.Disconnect(DiscRequested)
 			}
+		case n := <-srv.addtrusted:
+			// This channel is used by AddTrustedPeer to add a node
+			// to the trusted node set.
+			srv.log.Trace("Adding trusted node", "node", n)
+			trusted[n.ID] = true
+		case n := <-srv.removetrusted:
+			// This channel is used by RemoveTrustedPeer to remove a node
+			// from the trusted node set.
+			srv.log.Trace("Removing trusted node", "node", n)
+			delete(trusted, n.ID)
+		case op := <-srv.trustedOp:
+			// This channel is used by TrustedPeers.
+			op(trusted)
+			srv.peerOpDone <- struct{}{}
 		case op := <-srv.peerOp:
 			// This channel is used by Peers and PeerCount.
 			op(peers)
@@ -649,6 +863,7 @@ running:
 			if err == nil {
 				// The handshakes are done and it passed all checks.
 				p := newPeer(c, srv.Protocols)
+				p.scorer = srv.scorer
 				// If message events are enabled, pass the peerFeed
 				// to the peer
 				if srv.EnableMsgEvents {
@@ -723,6 +938,8 @@ func (srv *Server) encHandshakeChecks(peers map[discover.NodeID]*Peer, inboundCo
 		return DiscTooManyPeers
 	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns():
 		return DiscTooManyPeers
+	case !c.is(trustedConn) && srv.scorer.banned(c.id):
+		return DiscUselessPeer
 	case peers[c.id] != nil:
 		return DiscAlreadyConnected
 	case c.id == srv.Self().ID:
@@ -849,6 +1066,11 @@ func (srv *Server) setupConn(c *conn, flags connFlag, dialDest *discover.Node) e
 		clog.Trace("Rejected peer before protocol handshake", "err", err)
 		return err
 	}
+	// The admission checks may be slow, run them here rather than in the run
+	// loop. The trusted flag has been set by the checkpoint.
+	if !c.is(trustedConn) && !srv.admitted(c.id) {
+		return DiscUselessPeer
+	}
 	// Run the protocol handshake
 	phs, err := c.doProtoHandshake(srv.ourHandshake)
 	if err != nil {
@@ -0,0 +1,171 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/crypto/sha3"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+// testTransport skips the handshakes, the remote node being identified by id.
+type testTransport struct {
+	id discover.NodeID
+	*rlpx
+}
+
+func newTestTransport(id discover.NodeID, fd net.Conn) transport {
+	wrapped := newRLPX(fd).(*rlpx)
+	wrapped.rw = newRLPXFrameRW(fd, secrets{
+		MAC:        make([]byte, 16),
+		AES:        make([]byte, 16),
+		IngressMAC: sha3.NewKeccak256(),
+		EgressMAC:  sha3.NewKeccak256(),
+	})
+	return &testTransport{id: id, rlpx: wrapped}
+}
+
+func (c *testTransport) doEncHandshake(prv *ecdsa.PrivateKey, dialDest *discover.Node) (discover.NodeID, error) {
+	return c.id, nil
+}
+
+func (c *testTransport) doProtoHandshake(our *protoHandshake) (*protoHandshake, error) {
+	return &protoHandshake{ID: c.id, Name: "test"}, nil
+}
+
+func (c *testTransport) close(err error) {
+	c.rlpx.fd.Close()
+}
+
+func TestServerAdmissionCheck(t *testing.T) {
+	var (
+		admitted = discover.NodeID{1}
+		rejected = discover.NodeID{2}
+		trusted  = discover.NodeID{3}
+		slow     = discover.NodeID{4}
+		entered  = make(chan struct{})
+		release  = make(chan struct{})
+	)
+	key, _ := crypto.GenerateKey()
+	srv := &Server{Config: Config{
+		PrivateKey:   key,
+		MaxPeers:     10,
+		NoDial:       true,
+		NoDiscovery:  true,
+		TrustedNodes: []*discover.Node{{ID: trusted}},
+		Logger:       log.New(),
+	}}
+	srv.AddAdmissionCheck(func(id discover.NodeID) error {
+		switch id {
+		case slow:
+			close(entered)
+			<-release
+		case rejected, trusted:
+			return errors.New("not permitted")
+		}
+		return nil
+	})
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+
+	setup := func(id discover.NodeID) error {
+		fd, _ := net.Pipe()
+		c := &conn{fd: fd, transport: newTestTransport(id, fd), flags: inboundConn, cont: make(chan error)}
+		err := srv.setupConn(c, inboundConn, nil)
+		if err != nil {
+			c.close(err)
+		}
+		return err
+	}
+	if err := setup(admitted); err != nil {
+		t.Errorf("admitted node rejected: %v", err)
+	}
+	if err := setup(rejected); err != DiscUselessPeer {
+		t.Errorf("rejected node error = %v, want %v", err, DiscUselessPeer)
+	}
+	if err := setup(trusted); err != nil {
+		t.Errorf("trusted node rejected: %v", err)
+	}
+
+	// A slow check doesn't hold the run loop up.
+	errc := make(chan error, 1)
+	go func() { errc <- setup(slow) }()
+	<-entered
+	peers := make(chan int, 1)
+	go func() { peers <- srv.PeerCount() }()
+	select {
+	case n := <-peers:
+		if n != 2 {
+			t.Errorf("peer count = %d, want 2", n)
+		}
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("run loop blocked by the admission check")
+	}
+	close(release)
+	if err := <-errc; err != nil {
+		t.Errorf("slowly admitted node rejected: %v", err)
+	}
+}
+
+// Tests that a server failing to start releases the discovery socket and the
+// node sources set up before the failure.
+func TestServerStartFailure(t *testing.T) {
+	// Keep the TCP port busy so that the server fails to listen on it, after the
+	// discovery and the node sources were set up.
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+
+	key, _ := crypto.GenerateKey()
+	srv := &Server{Config: Config{
+		PrivateKey: key,
+		MaxPeers:   10,
+		ListenAddr: busy.Addr().String(),
+		NodesFile:  filepath.Join(os.TempDir(), "p2p-test-missing-nodes.json"),
+		Logger:     log.New(),
+	}}
+	if err := srv.Start(); err == nil {
+		srv.Stop()
+		t.Fatal("server started on a busy port")
+	}
+	if srv.sources != nil {
+		t.Errorf("node sources %v left after the failure", srv.sources)
+	}
+	conn, err := net.ListenPacket("udp", busy.Addr().String())
+	if err != nil {
+		t.Fatalf("discovery socket left open: %v", err)
+	}
+	conn.Close()
+	// A failed server is not running.
+	srv.Stop()
+}
@@ -25,6 +25,7 @@ import (
 	"math/big"
 	"time"
 
+	cstypes "github.com/kardiachain/go-kardia/consensus/types"
 	"github.com/kardiachain/go-kardia/kai/state"
 	"github.com/kardiachain/go-kardia/kvm"
 	"github.com/kardiachain/go-kardia/lib/common"
@@ -32,13 +33,18 @@ import (
 	"github.com/kardiachain/go-kardia/lib/rlp"
 	"github.com/kardiachain/go-kardia/mainchain/blockchain"
 	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/tool"
+	"github.com/kardiachain/go-kardia/trie"
 	"github.com/kardiachain/go-kardia/types"
 )
 
 const (
 	defaultGasPrice             = 1e9 * 50
 	defaultTimeOutForStaticCall = 5
+	// addressTxsPageSize is the number of transactions per page of kai_getTransactionsByAddress.
+	addressTxsPageSize = 100
 )
 
 // BlockHeaderJSON represents BlockHeader in JSON format
@@ -54,7 +60,8 @@ type BlockHeaderJSON struct {
 	TxHash         string `json:"data_hash"`    // transactions
 	AppHash        string `json:"app_hash"`     // state root
 	ReceiptHash    string `json:"receiptsRoot"` // receipt root
-	Bloom          int64  `json:"logsBloom"`
+	Bloom          string `json:"logsBloom"`
+	EvidenceHash   string `json:"evidence_hash"`
 	ValidatorsHash string `json:"validators_hash"` // validators for the current block
 	ConsensusHash  string `json:"consensus_hash"`
 }
@@ -72,7 +79,8 @@ type BlockJSON struct {
 	TxHash         string               `json:"data_hash"`    // transactions
 	AppHash        string               `json:"app_hash"`     // state root
 	ReceiptHash    string               `json:"receiptsRoot"` // receipt root
-	Bloom          int64                `json:"logsBloom"`
+	Bloom          string               `json:"logsBloom"`
+	EvidenceHash   string               `json:"evidence_hash"`
 	ValidatorsHash string               `json:"validators_hash"` // validators for the current block
 	ConsensusHash  string               `json:"consensus_hash"`
 	Txs            []*PublicTransaction `json:"txs"`
@@ -127,6 +135,9 @@ func NewBlockHeaderJSON(block types.Block) *BlockHeaderJSON {
 		GasLimit:       block.Header().GasLimit,
 		Validator:      block.Header().Validator.Hex(),
 		AppHash:        block.Header().AppHash.Hex(),
+		ReceiptHash:    block.Header().ReceiptHash.Hex(),
+		Bloom:          common.Encode(block.Header().Bloom.Bytes()),
+		EvidenceHash:   block.Header().EvidenceHash.Hex(),
 		ValidatorsHash: block.Header().ValidatorsHash.Hex(),
 		ConsensusHash:  block.Header().ConsensusHash.Hex(),
 	}
@@ -156,6 +167,9 @@ func NewBasicBlockJSON(block types.Block) *BlockJSON {
 		GasLimit:       block.Header().GasLimit,
 		Validator:      block.Header().Validator.Hex(),
 		AppHash:        block.Header().AppHash.Hex(),
+		ReceiptHash:    block.Header().ReceiptHash.Hex(),
+		Bloom:          common.Encode(block.Header().Bloom.Bytes()),
+		EvidenceHash:   block.Header().EvidenceHash.Hex(),
 		ValidatorsHash: block.Header().ValidatorsHash.Hex(),
 		ConsensusHash:  block.Header().ConsensusHash.Hex(),
 	}
@@ -190,6 +204,9 @@ func NewBlockJSON(block types.Block, receipts types.Receipts) *BlockJSON {
 		GasLimit:       block.Header().GasLimit,
 		Validator:      block.Header().Validator.Hex(),
 		AppHash:        block.Header().AppHash.Hex(),
+		ReceiptHash:    block.Header().ReceiptHash.Hex(),
+		Bloom:          common.Encode(block.Header().Bloom.Bytes()),
+		EvidenceHash:   block.Header().EvidenceHash.Hex(),
 		ValidatorsHash: block.Header().ValidatorsHash.Hex(),
 		ConsensusHash:  block.Header().ConsensusHash.Hex(),
 		Receipts:       basicReceipts,
@@ -202,8 +219,8 @@ func (s *PublicKaiAPI) BlockNumber() uint64 {
 }
 
 // GetHeaderBlockByNumber returns blockHeader by block number
-func (s *PublicKaiAPI) GetBlockHeaderByNumber(blockNumber uint64) *BlockHeaderJSON {
-	block := s.kaiService.blockchain.GetBlockByHeight(blockNumber)
+func (s *PublicKaiAPI) GetBlockHeaderByNumber(blockNumber rpc.BlockNumber) *BlockHeaderJSON {
+	block := s.kaiService.blockByNumber(blockNumber)
 	if block == nil {
 		return nil
 	}
@@ -229,8 +246,8 @@ func (s *PublicKaiAPI) GetBasicBlockByHash(blockHash string) *BlockJSON {
 }
 
 // GetBasicBlockByNumber returns block by block number
-func (s *PublicKaiAPI) GetBasicBlockByNumber(blockNumber uint64) *BlockJSON {
-	block := s.kaiService.blockchain.GetBlockByHeight(blockNumber)
+func (s *PublicKaiAPI) GetBasicBlockByNumber(blockNumber rpc.BlockNumber) *BlockJSON {
+	block := s.kaiService.blockByNumber(blockNumber)
 	if block == nil {
 		return nil
 	}
@@ -252,8 +269,8 @@ func (s *PublicKaiAPI) GetBlockByHash(blockHash string) *BlockJSON {
 }
 
 // GetBlockByNumber returns block by block number
-func (s *PublicKaiAPI) GetBlockByNumber(blockNumber uint64) *BlockJSON {
-	block := s.kaiService.blockchain.GetBlockByHeight(blockNumber)
+func (s *PublicKaiAPI) GetBlockByNumber(blockNumber rpc.BlockNumber) *BlockJSON {
+	block := s.kaiService.blockByNumber(blockNumber)
 	if block == nil {
 		return nil
 	}
@@ -265,6 +282,80 @@ func (s *PublicKaiAPI) GetBlockByNumber(blockNumber uint64) *BlockJSON {
 	return NewBlockJSON(*block, receipts)
 }
 
+// GetTransactionsByAddress returns the given page, starting at 0, of the transactions
+// involving the given address between fromBlock and toBlock inclusive, in the order
+// of the chain. A toBlock of 0 stands for the latest block. It requires the address
+// index to be enabled.
+func (s *PublicKaiAPI) GetTransactionsByAddress(address string, fromBlock, toBlock, page uint64) ([]*PublicTransaction, error) {
+	if !s.kaiService.blockchain.AddressIndex {
+		return nil, fmt.Errorf("address index is not enabled")
+	}
+	if toBlock == 0 {
+		toBlock = s.kaiService.blockchain.CurrentBlock().Height()
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("invalid block range %d to %d", fromBlock, toBlock)
+	}
+	hashes := s.kaiService.kaiDb.ReadAddressTxs(common.HexToAddress(address), fromBlock, toBlock,
+		int(page)*addressTxsPageSize, addressTxsPageSize)
+	txs := make([]*PublicTransaction, 0, len(hashes))
+	for _, hash := range hashes {
+		tx, blockHash, height, index := s.kaiService.kaiDb.ReadTransaction(hash)
+		if tx == nil {
+			log.Error("Indexed transaction missing", "hash", hash)
+			continue
+		}
+		publicTx := NewPublicTransaction(tx, blockHash, height, index)
+		if header := s.kaiService.blockchain.GetHeader(blockHash, height); header != nil {
+			publicTx.Time = header.Time.Int64()
+		}
+		txs = append(txs, publicTx)
+	}
+	return txs, nil
+}
+
+// FilterQueryJSON holds the criteria of a log query. A ToBlock of 0 stands for
+// the latest block. Each entry of Topics lists the alternatives for the topic at
+// that position, an empty entry matching any topic.
+type FilterQueryJSON struct {
+	FromBlock uint64     `json:"fromBlock"`
+	ToBlock   uint64     `json:"toBlock"`
+	Addresses []string   `json:"addresses"`
+	Topics    [][]string `json:"topics"`
+}
+
+// GetLogs returns the logs emitted between the blocks of the query, inclusive,
+// by any of its addresses and matching its topics. The query may span up to the
+// LogsBlockRange of the service config.
+func (s *PublicKaiAPI) GetLogs(query FilterQueryJSON) ([]Log, error) {
+	toBlock := query.ToBlock
+	if toBlock == 0 {
+		toBlock = s.kaiService.blockchain.CurrentBlock().Height()
+	}
+	if query.FromBlock > toBlock {
+		return nil, fmt.Errorf("invalid block range %d to %d", query.FromBlock, toBlock)
+	}
+	if max := s.kaiService.config.LogsBlockRange; max > 0 && toBlock-query.FromBlock >= max {
+		return nil, fmt.Errorf("block range %d to %d exceeds the maximum of %d blocks", query.FromBlock, toBlock, max)
+	}
+	addresses := make([]common.Address, len(query.Addresses))
+	for i, address := range query.Addresses {
+		addresses[i] = common.HexToAddress(address)
+	}
+	topics := make([][]common.Hash, len(query.Topics))
+	for i, sub := range query.Topics {
+		for _, topic := range sub {
+			topics[i] = append(topics[i], common.HexToHash(topic))
+		}
+	}
+	filtered := blockchain.FilterLogs(s.kaiService.kaiDb, query.FromBlock, toBlock, addresses, topics)
+	logs := make([]Log, 0, len(filtered))
+	for _, l := range filtered {
+		logs = append(logs, newLog(l))
+	}
+	return logs, nil
+}
+
 // Validator returns node's validator, nil if current node is not a validator
 func (s *PublicKaiAPI) Validator() map[string]interface{} {
 	if val := s.kaiService.csManager.Validator(); val != nil {
@@ -394,13 +485,205 @@ func (a *PublicTransactionAPI) SendRawTransaction(ctx context.Context, txs strin
 
 // KardiaCall execute a contract method call only against
 // state on the local node. No tx is generated and submitted
-// onto the blockchain
-func (s *PublicKaiAPI) KardiaCall(ctx context.Context, call types.CallArgsJSON, blockNumber uint64) (string, error) {
+// onto the blockchain. The call runs against the latest block when blockNrOrHash
+// is omitted. Block 0 is rejected, as this method used to take it for the latest
+// block: the genesis block is selected by its hash.
+func (s *PublicKaiAPI) KardiaCall(ctx context.Context, call types.CallArgsJSON, blockNrOrHash *rpc.BlockNumberOrHash) (string, error) {
+	blockNrOrHashOrLatest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		if number, ok := blockNrOrHash.Number(); ok && number == rpc.EarliestBlockNumber {
+			return "", fmt.Errorf("block 0 is no longer the latest block, omit the block or use \"latest\", and select the genesis block by its hash")
+		}
+		blockNrOrHashOrLatest = *blockNrOrHash
+	}
 	args := types.NewArgs(call)
-	result, _, _, err := s.doCall(ctx, args, blockNumber, kvm.Config{}, defaultTimeOutForStaticCall*time.Second)
+	result, _, _, err := s.doCall(ctx, args, blockNrOrHashOrLatest, kvm.Config{}, defaultTimeOutForStaticCall*time.Second)
 	return common.Encode(result), err
 }
 
+// StorageResult is the Merkle proof of a single storage slot.
+type StorageResult struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// AccountResult is the Merkle proof of an account and a set of its storage
+// slots against the state root (app hash) of a block.
+type AccountResult struct {
+	Address      string          `json:"address"`
+	StateRoot    string          `json:"stateRoot"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      string          `json:"balance"`
+	CodeHash     string          `json:"codeHash"`
+	Nonce        common.Uint64   `json:"nonce"`
+	StorageHash  string          `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// GetProof returns the Merkle proof of the given account and storage keys in the
+// state after executing the given block. Note the state root the proof verifies
+// against is committed as AppHash by the header of the following block. The
+// pending state has no committed root to prove against, so it is rejected.
+func (s *PublicKaiAPI) GetProof(address string, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountResult, error) {
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		return nil, fmt.Errorf("proofs of the pending state are not supported")
+	}
+	addr := common.HexToAddress(address)
+	state, header, err := s.kaiService.stateAndHeaderByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	root := s.kaiService.kaiDb.ReadAppHash(header.Height)
+	storageHash := types.EmptyRootHash
+	if state.Exist(addr) {
+		if hash := state.GetStorageRoot(addr); hash != (common.Hash{}) {
+			storageHash = hash
+		}
+	}
+	storageProof := make([]StorageResult, len(storageKeys))
+	for i, key := range storageKeys {
+		storageKey := common.HexToHash(key)
+		if storageHash == types.EmptyRootHash {
+			storageProof[i] = StorageResult{key, "0x0", []string{}}
+			continue
+		}
+		proof, err := state.GetStorageProof(addr, storageKey)
+		if err != nil {
+			return nil, err
+		}
+		value := state.GetState(addr, storageKey)
+		storageProof[i] = StorageResult{key, common.Encode(value.Bytes()), encodeProof(proof)}
+	}
+	accountProof, err := state.GetProof(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountResult{
+		Address:      addr.Hex(),
+		StateRoot:    root.Hex(),
+		AccountProof: encodeProof(accountProof),
+		Balance:      state.GetBalance(addr).String(),
+		CodeHash:     state.GetCodeHash(addr).Hex(),
+		Nonce:        common.Uint64(state.GetNonce(addr)),
+		StorageHash:  storageHash.Hex(),
+		StorageProof: storageProof,
+	}, nil
+}
+
+// encodeProof hex encodes the nodes of a Merkle proof.
+func encodeProof(proof [][]byte) []string {
+	encoded := make([]string, len(proof))
+	for i, node := range proof {
+		encoded[i] = common.Encode(node)
+	}
+	return encoded
+}
+
+// AccountJSON is the state of an account after executing a block.
+type AccountJSON struct {
+	Address     string        `json:"address"`
+	Balance     string        `json:"balance"`
+	Nonce       common.Uint64 `json:"nonce"`
+	CodeHash    string        `json:"codeHash"`
+	StorageRoot string        `json:"storageRoot"`
+}
+
+// GetAccount returns the balance, nonce, code hash and storage root of the given
+// account in the state after executing the given block.
+func (s *PublicKaiAPI) GetAccount(address string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountJSON, error) {
+	addr := common.HexToAddress(address)
+	state, _, err := s.kaiService.stateAndHeaderByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	storageRoot := types.EmptyRootHash
+	if hash := state.GetStorageRoot(addr); hash != (common.Hash{}) {
+		storageRoot = hash
+	}
+	return &AccountJSON{
+		Address:     addr.Hex(),
+		Balance:     state.GetBalance(addr).String(),
+		Nonce:       common.Uint64(state.GetNonce(addr)),
+		CodeHash:    state.GetCodeHash(addr).Hex(),
+		StorageRoot: storageRoot.Hex(),
+	}, nil
+}
+
+// GetCode returns the code of the given contract in the state after executing
+// the given block.
+func (s *PublicKaiAPI) GetCode(address string, blockNrOrHash rpc.BlockNumberOrHash) (string, error) {
+	state, _, err := s.kaiService.stateAndHeaderByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return "", err
+	}
+	return common.Encode(state.GetCode(common.HexToAddress(address))), nil
+}
+
+// GetStorageAt returns the value of the given storage slot of the given contract
+// in the state after executing the given block.
+func (s *PublicKaiAPI) GetStorageAt(address string, slot string, blockNrOrHash rpc.BlockNumberOrHash) (string, error) {
+	state, _, err := s.kaiService.stateAndHeaderByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return "", err
+	}
+	value := state.GetState(common.HexToAddress(address), common.HexToHash(slot))
+	return value.Hex(), nil
+}
+
+// blockByNumber returns the block of the given number, nil if it's unknown. The
+// pending tag selects the latest block.
+func (s *KardiaService) blockByNumber(number rpc.BlockNumber) *types.Block {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return s.blockchain.CurrentBlock()
+	}
+	return s.blockchain.GetBlockByHeight(uint64(number))
+}
+
+// blockByNumberOrHash returns the given block, failing for the blocks the node
+// doesn't have.
+func (s *KardiaService) blockByNumberOrHash(blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block := s.blockchain.GetBlockByHash(hash)
+		if block == nil {
+			return nil, fmt.Errorf("block %s not found", hash.Hex())
+		}
+		return block, nil
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return nil, fmt.Errorf("neither block number nor block hash specified")
+	}
+	if head := s.blockchain.CurrentBlock().Height(); number >= 0 && uint64(number) > head {
+		return nil, fmt.Errorf("block %d not found, the latest block is %d", number, head)
+	}
+	block := s.blockByNumber(number)
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", number)
+	}
+	return block, nil
+}
+
+// stateAndHeaderByNumberOrHash returns the state after executing the given block
+// along with the block header, failing for the blocks the node doesn't have or
+// whose state was pruned. The pending tag selects a copy of the state of the
+// transaction pool on top of the latest block.
+func (s *KardiaService) stateAndHeaderByNumberOrHash(blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		return s.txPool.State().Copy(), s.blockchain.CurrentHeader(), nil
+	}
+	block, err := s.blockByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := s.blockchain.StateAt(block.Height())
+	if err != nil {
+		return nil, nil, fmt.Errorf("state of block %d is not available: %v", block.Height(), err)
+	}
+	return st, block.Header(), nil
+}
+
 // PendingTransactions returns pending transactions
 func (a *PublicTransactionAPI) PendingTransactions() ([]*PublicTransaction, error) {
 	pendingTxs := a.s.TxPool().GetPendingData()
@@ -428,6 +711,115 @@ func (a *PublicTransactionAPI) GetTransaction(hash string) *PublicTransaction {
 	return publicTx
 }
 
+// TransactionStatus is the status of a transaction as known by the node, one of
+// included, pending, queued, dropped or unknown. Pending and queued transactions
+// may report why they last failed in a block.
+type TransactionStatus struct {
+	Status      string `json:"status"`
+	BlockHash   string `json:"blockHash,omitempty"`
+	BlockHeight uint64 `json:"blockHeight,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	Error       string `json:"error,omitempty"`
+	ReplacedBy  string `json:"replacedBy,omitempty"`
+	Time        int64  `json:"time,omitempty"`
+}
+
+// Status returns the status of the transaction with the given hash, telling why it
+// left the pool if it was recently dropped.
+func (a *PublicTransactionAPI) Status(hash string) *TransactionStatus {
+	txHash := common.HexToHash(hash)
+	if tx, blockHash, height, _ := a.s.kaiDb.ReadTransaction(txHash); tx != nil {
+		return &TransactionStatus{Status: "included", BlockHash: blockHash.Hex(), BlockHeight: height}
+	}
+	status := &TransactionStatus{Status: "unknown"}
+	switch a.s.TxPool().Status([]common.Hash{txHash})[0] {
+	case tx_pool.TxStatusPending:
+		status.Status = "pending"
+	case tx_pool.TxStatusQueued:
+		status.Status = "queued"
+	}
+	drop := a.s.TxPool().Dropped(txHash)
+	if drop == nil || (status.Status != "unknown" && drop.Reason != tx_pool.DropFailed) {
+		return status
+	}
+	if status.Status == "unknown" {
+		status.Status = "dropped"
+	}
+	status.Reason = string(drop.Reason)
+	status.Error = drop.Err
+	status.BlockHeight = drop.BlockHeight
+	status.Time = drop.Time.Unix()
+	if drop.ReplacedBy != (common.Hash{}) {
+		status.ReplacedBy = drop.ReplacedBy.Hex()
+	}
+	return status
+}
+
+// PublicTxPoolAPI offers the transaction pool related RPC methods.
+type PublicTxPoolAPI struct {
+	s *KardiaService
+}
+
+// NewPublicTxPoolAPI creates a new transaction pool API.
+func NewPublicTxPoolAPI(kaiService *KardiaService) *PublicTxPoolAPI {
+	return &PublicTxPoolAPI{kaiService}
+}
+
+// Content returns the transactions contained within the transaction pool, grouped
+// by status, sender address and nonce.
+func (a *PublicTxPoolAPI) Content() map[string]map[string]map[string]*PublicTransaction {
+	content := map[string]map[string]map[string]*PublicTransaction{
+		"pending": make(map[string]map[string]*PublicTransaction),
+		"queued":  make(map[string]map[string]*PublicTransaction),
+	}
+	pending, queue := a.s.TxPool().Content()
+	for status, txs := range map[string]map[common.Address]types.Transactions{"pending": pending, "queued": queue} {
+		for account, list := range txs {
+			dump := make(map[string]*PublicTransaction, len(list))
+			for _, tx := range list {
+				dump[fmt.Sprintf("%d", tx.Nonce())] = NewPublicTransaction(tx, common.Hash{}, 0, 0)
+			}
+			content[status][account.Hex()] = dump
+		}
+	}
+	return content
+}
+
+// Inspect returns a textual summary of the transactions contained within the
+// transaction pool, grouped by status, sender address and nonce.
+func (a *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+	content := map[string]map[string]map[string]string{
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
+	}
+	format := func(tx *types.Transaction) string {
+		if to := tx.To(); to != nil {
+			return fmt.Sprintf("%s: %v cell + %v gas × %v cell", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+		return fmt.Sprintf("contract creation: %v cell + %v gas × %v cell", tx.Value(), tx.Gas(), tx.GasPrice())
+	}
+	pending, queue := a.s.TxPool().Content()
+	for status, txs := range map[string]map[common.Address]types.Transactions{"pending": pending, "queued": queue} {
+		for account, list := range txs {
+			dump := make(map[string]string, len(list))
+			for _, tx := range list {
+				dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+			}
+			content[status][account.Hex()] = dump
+		}
+	}
+	return content
+}
+
+// Status returns the number of pending and queued transactions in the pool.
+func (a *PublicTxPoolAPI) Status() map[string]common.Uint64 {
+	pending, queue := a.s.TxPool().Stats()
+	return map[string]common.Uint64{
+		"pending": common.Uint64(pending),
+		"queued":  common.Uint64(queue),
+	}
+}
+
 func getReceipts(kaiDb types.StoreDB, hash common.Hash) (types.Receipts, error) {
 	height := kaiDb.ReadHeaderNumber(hash)
 	if height == nil {
@@ -441,27 +833,32 @@ func getReceiptLogs(receipt types.Receipt) []Log {
 	if receipt.Logs != nil {
 		logs := make([]Log, 0, len(receipt.Logs))
 		for _, l := range receipt.Logs {
-			topics := make([]string, 0, len(l.Topics))
-			for _, topic := range l.Topics {
-				topics = append(topics, topic.Hex())
-			}
-			logs = append(logs, Log{
-				Address:     l.Address.Hex(),
-				Topics:      topics,
-				Data:        hex.EncodeToString(l.Data),
-				BlockHeight: l.BlockHeight,
-				TxHash:      l.TxHash.Hex(),
-				TxIndex:     l.TxIndex,
-				BlockHash:   l.BlockHash.Hex(),
-				Index:       l.Index,
-				Removed:     l.Removed,
-			})
+			logs = append(logs, newLog(l))
 		}
 		return logs
 	}
 	return nil
 }
 
+// newLog converts a log into its JSON representation.
+func newLog(l *types.Log) Log {
+	topics := make([]string, 0, len(l.Topics))
+	for _, topic := range l.Topics {
+		topics = append(topics, topic.Hex())
+	}
+	return Log{
+		Address:     l.Address.Hex(),
+		Topics:      topics,
+		Data:        hex.EncodeToString(l.Data),
+		BlockHeight: l.BlockHeight,
+		TxHash:      l.TxHash.Hex(),
+		TxIndex:     l.TxIndex,
+		BlockHash:   l.BlockHash.Hex(),
+		Index:       l.Index,
+		Removed:     l.Removed,
+	}
+}
+
 // getTransactionReceipt gets transaction receipt from transaction, blockHash, blockNumber and index.
 func getPublicReceipt(receipt types.Receipt, tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64) *PublicReceipt {
 	from, _ := types.Sender(types.HomesteadSigner{}, tx)
@@ -518,6 +915,68 @@ func (a *PublicTransactionAPI) GetTransactionReceipt(ctx context.Context, hash s
 	return getPublicReceipt(*receipt, tx, blockHash, height, index), nil
 }
 
+// ReceiptProof is the Merkle proof of a transaction receipt against the receipt
+// root committed by a block header.
+type ReceiptProof struct {
+	BlockHash        string   `json:"blockHash"`
+	BlockHeight      uint64   `json:"blockHeight"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex uint64   `json:"transactionIndex"`
+	Key              string   `json:"key"`
+	Receipt          string   `json:"receipt"`
+	ReceiptsRoot     string   `json:"receiptsRoot"`
+	HeaderHash       string   `json:"headerHash"`
+	HeaderHeight     uint64   `json:"headerHeight"`
+	Proof            []string `json:"proof"`
+}
+
+// GetReceiptProof returns the Merkle proof of the receipt of the given
+// transaction. Blocks are executed once committed, so the receipts of a block
+// are committed by the ReceiptHash of the following block's header, which is
+// returned along with the proof.
+func (a *PublicTransactionAPI) GetReceiptProof(hash string) (*ReceiptProof, error) {
+	txHash := common.HexToHash(hash)
+	tx, blockHash, height, index := a.s.kaiDb.ReadTransaction(txHash)
+	if tx == nil {
+		return nil, nil
+	}
+	receipts, err := getReceipts(a.s.kaiDb, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) <= int(index) {
+		return nil, nil
+	}
+	next := a.s.blockchain.GetBlockByHeight(height + 1)
+	if next == nil {
+		return nil, fmt.Errorf("receipts of block %d are not committed yet", height)
+	}
+	header := next.Header()
+	if !header.HasCommitments() {
+		return nil, fmt.Errorf("block %d does not commit to receipts", header.Height)
+	}
+	root := types.DeriveSha(receipts)
+	if root != header.ReceiptHash {
+		return nil, fmt.Errorf("receipt root mismatch for block %d: have %v, committed %v", height, root.Hex(), header.ReceiptHash.Hex())
+	}
+	var proof trie.ProofList
+	if err := types.DeriveProof(receipts, int(index), &proof); err != nil {
+		return nil, err
+	}
+	return &ReceiptProof{
+		BlockHash:        blockHash.Hex(),
+		BlockHeight:      height,
+		TransactionHash:  txHash.Hex(),
+		TransactionIndex: index,
+		Key:              common.Encode(types.DeriveKey(int(index))),
+		Receipt:          common.Encode(receipts.GetRlp(int(index))),
+		ReceiptsRoot:     root.Hex(),
+		HeaderHash:       header.Hash().Hex(),
+		HeaderHeight:     header.Height,
+		Proof:            encodeProof(proof),
+	}, nil
+}
+
 // PublicAccountAPI provides APIs support getting account's info
 type PublicAccountAPI struct {
 	kaiService *KardiaService
@@ -528,57 +987,100 @@ func NewPublicAccountAPI(kaiService *KardiaService) *PublicAccountAPI {
 	return &PublicAccountAPI{kaiService}
 }
 
-// Balance returns address's balance
-func (a *PublicAccountAPI) Balance(address string, hash string, height int64) string {
-	addr := common.HexToAddress(address)
-	log.Info("Addr", "addr", addr.Hex())
-	block := new(types.Block)
-	if len(hash) > 0 && height >= 0 {
-		block = a.kaiService.blockchain.GetBlock(common.HexToHash(hash), uint64(height))
-	} else if len(hash) > 0 {
-		block = a.kaiService.blockchain.GetBlockByHash(common.HexToHash(hash))
-	} else if height >= 0 {
-		block = a.kaiService.blockchain.GetBlockByHeight(uint64(height))
-	} else {
-		block = a.kaiService.blockchain.CurrentBlock()
+// Balance returns address's balance in the state after executing the given block,
+// the latest one if omitted
+func (a *PublicAccountAPI) Balance(address string, blockNrOrHash *rpc.BlockNumberOrHash) (string, error) {
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
 	}
-	state, err := a.kaiService.blockchain.StateAt(block.Height())
+	state, _, err := a.kaiService.stateAndHeaderByNumberOrHash(*blockNrOrHash)
 	if err != nil {
-		log.Error("Fail to get state from block", "err", err, "block", block.Hash().String())
-		return "-1"
+		return "", err
 	}
-	return state.GetBalance(addr).String()
+	return state.GetBalance(common.HexToAddress(address)).String(), nil
 }
 
-// Nonce return address's nonce
-func (a *PublicAccountAPI) Nonce(address string) (uint64, error) {
+// Nonce return address's nonce in the state after executing the given block. The
+// pending nonce, returned if the block is omitted, accounts for the transactions
+// of the pool.
+func (a *PublicAccountAPI) Nonce(address string, blockNrOrHash *rpc.BlockNumberOrHash) (uint64, error) {
 	addr := common.HexToAddress(address)
-	nonce := a.kaiService.txPool.Nonce(addr)
-	return nonce, nil
+	if blockNrOrHash == nil {
+		return a.kaiService.txPool.Nonce(addr), nil
+	}
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		return a.kaiService.txPool.Nonce(addr), nil
+	}
+	state, _, err := a.kaiService.stateAndHeaderByNumberOrHash(*blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	return state.GetNonce(addr), nil
+}
+
+// PrivateAdminAPI provides the administrative APIs of the Kardia service. They
+// are served in the admin namespace along with the ones of the node.
+type PrivateAdminAPI struct {
+	kaiService *KardiaService
+}
+
+// NewPrivateAdminAPI is a constructor that init new PrivateAdminAPI
+func NewPrivateAdminAPI(kaiService *KardiaService) *PrivateAdminAPI {
+	return &PrivateAdminAPI{kaiService}
+}
+
+// SetGasPrice sets the minimum gas price accepted by the transaction pool and
+// drops all transactions below it. The price is either decimal or 0x prefixed hex.
+func (a *PrivateAdminAPI) SetGasPrice(price string) (bool, error) {
+	gasPrice, ok := new(big.Int).SetString(price, 0)
+	if !ok || gasPrice.Sign() < 0 {
+		return false, fmt.Errorf("invalid gas price %q", price)
+	}
+	a.kaiService.txPool.SetGasPrice(gasPrice)
+	return true, nil
+}
+
+// FlushTxPool drops every transaction of the transaction pool and returns the
+// number of dropped transactions.
+func (a *PrivateAdminAPI) FlushTxPool() int {
+	return a.kaiService.txPool.Flush()
+}
+
+// PeerConsensusState is the consensus state of a connected peer.
+type PeerConsensusState struct {
+	ID         string                  `json:"id"`
+	RoundState *cstypes.PeerRoundState `json:"round_state"`
+}
+
+// ConsensusStateDump is a snapshot of the consensus state of the node and of
+// all its peers.
+type ConsensusStateDump struct {
+	RoundState *cstypes.RoundState   `json:"round_state"`
+	Peers      []*PeerConsensusState `json:"peers"`
+}
+
+// DumpConsensusState returns the current round state of the consensus and the
+// known round states of all connected peers.
+func (a *PrivateAdminAPI) DumpConsensusState() *ConsensusStateDump {
+	csManager := a.kaiService.csManager
+	dump := &ConsensusStateDump{
+		RoundState: csManager.GetRoundState(),
+		Peers:      make([]*PeerConsensusState, 0),
+	}
+	for id, prs := range csManager.PeerRoundStates() {
+		dump.Peers = append(dump.Peers, &PeerConsensusState{ID: id.String(), RoundState: prs})
+	}
+	return dump
 }
 
 // doCall is an interface to make smart contract call against the state of local node
 // No tx is generated or submitted to the blockchain
-func (s *PublicKaiAPI) doCall(ctx context.Context, args *types.CallArgs, blockNr uint64, vmCfg kvm.Config, timeout time.Duration) ([]byte, uint64, bool, error) {
+func (s *PublicKaiAPI) doCall(ctx context.Context, args *types.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, vmCfg kvm.Config, timeout time.Duration) ([]byte, uint64, bool, error) {
 	defer func(start time.Time) { log.Debug("Executing KVM call finished", "runtime", time.Since(start)) }(time.Now())
 
-	var (
-		statedb *state.StateDB
-		err     error
-		header  *types.Header
-	)
-	// If blockNr is specified, we used the state and header at the block at height blockNr
-	// otherwise we use the current state and header
-	if blockNr > 0 {
-		block := s.kaiService.BlockChain().GetBlockByHeight(blockNr)
-		statedb, err = s.kaiService.BlockChain().StateAt(block.Height())
-		header = block.Header()
-	} else {
-		statedb, err = s.kaiService.BlockChain().State()
-		header = s.kaiService.BlockChain().CurrentHeader()
-	}
-
-	if statedb == nil || err != nil {
+	statedb, header, err := s.kaiService.stateAndHeaderByNumberOrHash(blockNrOrHash)
+	if err != nil {
 		return nil, 0, false, err
 	}
 	// Set sender address or use a default if none specified
@@ -594,7 +1096,7 @@ func (s *PublicKaiAPI) doCall(ctx context.Context, args *types.CallArgs, blockNr
 	if gas == 0 {
 		gas = common.MaxInt64 / 2
 	}
-	if gasPrice.Sign() == 0 {
+	if gasPrice == nil || gasPrice.Sign() == 0 {
 		gasPrice = new(big.Int).SetUint64(defaultGasPrice)
 	}
 
@@ -661,7 +1163,7 @@ func (s *PublicKaiAPI) EstimateGas(ctx context.Context, call types.CallArgsJSON)
 	executable := func(gas uint64) bool {
 		args.Gas = gas
 
-		_, _, failed, err := s.doCall(ctx, args, s.BlockNumber(), kvm.Config{}, 0)
+		_, _, failed, err := s.doCall(ctx, args, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber), kvm.Config{}, 0)
 		if err != nil || failed {
 			return false
 		}
@@ -669,6 +1171,9 @@ func (s *PublicKaiAPI) EstimateGas(ctx context.Context, call types.CallArgsJSON)
 	}
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		mid := (hi + lo) / 2
 		if !executable(mid) {
 			lo = mid
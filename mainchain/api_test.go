@@ -0,0 +1,176 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+var (
+	testOwner    = common.HexToAddress("0xc1fe56E3F58D3244F606306611a5d10c8333f1f6")
+	testContract = common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	testCode     = []byte{0x60, 0x00, 0x54, 0x00} // PUSH1 0 SLOAD STOP
+)
+
+// newTestService returns a Kardia service running a chain whose genesis holds
+// the given storage in the test contract.
+func newTestService(t *testing.T, storage map[common.Hash]common.Hash) *KardiaService {
+	privateKey, _ := crypto.HexToECDSA("8843ebcb1021b00ae9a644db6617f9c6d870e5fd53624cefe374c1d2d710fd06")
+	kaiDb := kvstore.NewStoreDB(memorydb.New())
+	g := genesis.DefaultTestnetGenesisBlock(map[string]*big.Int{testOwner.String(): configs.InitValueInCell})
+	g.Alloc[testContract] = genesis.GenesisAccount{
+		Code:    testCode,
+		Storage: storage,
+		Balance: big.NewInt(7),
+		Nonce:   3,
+	}
+	chainConfig, _, err := genesis.SetupGenesisBlock(log.New(), kaiDb, g, &types.BaseAccount{
+		Address:    testOwner,
+		PrivateKey: *privateKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc, err := blockchain.NewBlockChain(log.New(), kaiDb, chainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &KardiaService{logger: log.New(), config: &Config{}, kaiDb: kaiDb, chainConfig: chainConfig, blockchain: bc}
+}
+
+func TestGetAccount(t *testing.T) {
+	s := newTestService(t, map[common.Hash]common.Hash{{1}: {2}})
+	api := NewPublicKaiAPI(s)
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	account, err := api.GetAccount(testOwner.Hex(), latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The nonce of the existing accounts is the one of their next transaction,
+	// as returned by the state.
+	want := AccountJSON{
+		Address:     testOwner.Hex(),
+		Balance:     configs.InitValueInCell.String(),
+		Nonce:       1,
+		CodeHash:    crypto.Keccak256Hash(nil).Hex(),
+		StorageRoot: types.EmptyRootHash.Hex(),
+	}
+	if *account != want {
+		t.Errorf("owner account %+v, want %+v", *account, want)
+	}
+
+	// The contract is read by the hash of the block as well.
+	byHash := rpc.BlockNumberOrHashWithHash(s.blockchain.CurrentBlock().Hash())
+	account, err = api.GetAccount(testContract.Hex(), byHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := s.blockchain.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = AccountJSON{
+		Address:     testContract.Hex(),
+		Balance:     "7",
+		Nonce:       4,
+		CodeHash:    crypto.Keccak256Hash(testCode).Hex(),
+		StorageRoot: st.GetStorageRoot(testContract).Hex(),
+	}
+	if *account != want {
+		t.Errorf("contract account %+v, want %+v", *account, want)
+	}
+	if account.StorageRoot == types.EmptyRootHash.Hex() {
+		t.Error("contract has an empty storage root")
+	}
+
+	// Unknown accounts are empty, unknown blocks fail.
+	unknown := common.HexToAddress("0x1234")
+	account, err = api.GetAccount(unknown.Hex(), latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = AccountJSON{
+		Address:     unknown.Hex(),
+		Balance:     "0",
+		CodeHash:    common.Hash{}.Hex(),
+		StorageRoot: types.EmptyRootHash.Hex(),
+	}
+	if *account != want {
+		t.Errorf("unknown account %+v, want %+v", *account, want)
+	}
+	if _, err := api.GetAccount(testOwner.Hex(), rpc.BlockNumberOrHashWithHash(common.Hash{1})); err == nil {
+		t.Error("no error for an unknown block")
+	}
+}
+
+func TestKardiaCall(t *testing.T) {
+	s := newTestService(t, nil)
+	api := NewPublicKaiAPI(s)
+	call := types.CallArgsJSON{From: testOwner.Hex(), To: testContract.Hex(), Gas: 100000, GasPrice: big.NewInt(1)}
+
+	latest, err := api.KardiaCall(context.Background(), call, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := rpc.BlockNumberOrHashWithHash(s.blockchain.GetBlockByHeight(0).Hash())
+	if res, err := api.KardiaCall(context.Background(), call, &genesis); err != nil || res != latest {
+		t.Errorf("call at the genesis hash = %s, %v, want %s", res, err, latest)
+	}
+	// Block 0 used to be taken for the latest block.
+	earliest := rpc.BlockNumberOrHashWithNumber(rpc.EarliestBlockNumber)
+	if _, err := api.KardiaCall(context.Background(), call, &earliest); err == nil {
+		t.Error("no error for block 0")
+	}
+}
+
+func TestGetLogsBlockRange(t *testing.T) {
+	s := newTestService(t, nil)
+	s.config.LogsBlockRange = 5
+	api := NewPublicKaiAPI(s)
+
+	for _, test := range []struct {
+		from, to uint64
+		ok       bool
+	}{
+		{0, 0, true}, // up to the latest block
+		{0, 4, true},
+		{10, 14, true},
+		{0, 5, false},
+		{3, 2, false},
+	} {
+		_, err := api.GetLogs(FilterQueryJSON{FromBlock: test.from, ToBlock: test.to})
+		if (err == nil) != test.ok {
+			t.Errorf("blocks %d to %d: error %v, want ok %v", test.from, test.to, err, test.ok)
+		}
+	}
+}
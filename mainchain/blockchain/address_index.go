@@ -0,0 +1,102 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// transferRecorder collects the accounts taking part in the value transfers made
+// by each executed transaction, internal calls included. A nil recorder records nothing.
+type transferRecorder struct {
+	current   common.Hash
+	transfers map[common.Hash][]common.Address
+}
+
+func newTransferRecorder() *transferRecorder {
+	return &transferRecorder{transfers: make(map[common.Hash][]common.Address)}
+}
+
+// start attributes the following transfers to the transaction with the given hash.
+func (r *transferRecorder) start(hash common.Hash) {
+	if r != nil {
+		r.current = hash
+	}
+}
+
+// discard forgets the transfers of a transaction whose execution was reverted.
+func (r *transferRecorder) discard(hash common.Hash) {
+	if r != nil {
+		delete(r.transfers, hash)
+	}
+}
+
+// record implements kvm.Config.OnTransfer, ignoring transfers without value.
+func (r *transferRecorder) record(from, to common.Address, value *big.Int) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+	r.transfers[r.current] = append(r.transfers[r.current], from, to)
+}
+
+// WriteAddressIndex indexes every transaction of the given block under the addresses
+// it involves: its sender and recipient, the contract it created if any, and the
+// accounts of the value transfers recorded for it.
+func WriteAddressIndex(db types.StoreDB, block *types.Block, receipts types.Receipts, transfers map[common.Hash][]common.Address) {
+	created := make(map[common.Hash]common.Address, len(receipts))
+	for _, receipt := range receipts {
+		if receipt.ContractAddress != (common.Address{}) {
+			created[receipt.TxHash] = receipt.ContractAddress
+		}
+	}
+	for i, tx := range block.Transactions() {
+		hash := tx.Hash()
+		addresses := make([]common.Address, 0, 3+len(transfers[hash]))
+		if from, err := types.Sender(types.HomesteadSigner{}, tx); err == nil {
+			addresses = append(addresses, from)
+		} else {
+			log.Error("Failed to derive transaction sender", "hash", hash, "err", err)
+		}
+		if to := tx.To(); to != nil {
+			addresses = append(addresses, *to)
+		}
+		if contract, ok := created[hash]; ok {
+			addresses = append(addresses, contract)
+		}
+		addresses = append(addresses, transfers[hash]...)
+		db.WriteAddressTxs(block.Height(), uint32(i), hash, uniqueAddresses(addresses))
+	}
+}
+
+// uniqueAddresses removes the duplicates of the given addresses, keeping their order.
+func uniqueAddresses(addresses []common.Address) []common.Address {
+	seen := make(map[common.Address]struct{}, len(addresses))
+	unique := addresses[:0]
+	for _, address := range addresses {
+		if _, ok := seen[address]; !ok {
+			seen[address] = struct{}{}
+			unique = append(unique, address)
+		}
+	}
+	return unique
+}
@@ -35,6 +35,14 @@ import (
 	"github.com/kardiachain/go-kardia/types"
 )
 
+const (
+	// blockGasLimit is the gas limit of the proposed blocks.
+	blockGasLimit = 215040000
+	// maxBlockTxsBytes bounds the size of the transactions of a proposed block, leaving
+	// room for its header and last commit within types.MaxBlockSizeBytes.
+	maxBlockTxsBytes = types.MaxBlockSizeBytes - 1024*1024
+)
+
 // TODO(thientn/namdoh): this is similar to execution.go & validation.go in state/
 // These files should be consolidated in the future.
 
@@ -71,23 +79,33 @@ func (bo *BlockOperations) CreateProposalBlock(
 	// Tx execution can happen in parallel with voting or precommitted.
 	// For simplicity, this code executes & commits txs before sending proposal,
 	// so statedb of proposal node already contains the new state and txs receipts of this proposal block.
-	txs := bo.txPool.ProposeTransactions()
+	// The number of transactions of the header is set by newBlock.
+	header := bo.newHeader(height, 0, lastState.LastBlockID, proposerAddr, lastState.LastValidators.Hash())
+	txs := bo.txPool.ProposeTransactions(header.GasLimit, maxBlockTxsBytes)
 	bo.logger.Debug("Collected transactions", "txs count", len(txs))
 
-	header := bo.newHeader(height, uint64(len(txs)), lastState.LastBlockID, proposerAddr, lastState.LastValidators.Hash())
 	header.AppHash = lastState.AppHash
+	if bo.blockchain.Config().IsHeaderCommitments(header.Height) {
+		header.ReceiptHash = lastState.LastReceiptHash
+		header.Bloom = lastState.LastBloom
+		header.EvidenceHash = common.BytesToHash(types.EvidenceList{}.Hash())
+	}
 
 	block = bo.newBlock(header, txs, commit)
 	bo.logger.Info("Make block to propose", "height", block.Height(), "AppHash", block.AppHash(), "hash", block.Hash())
 
-	// claim reward
-	if err := bo.claimReward(uint64(height)); err != nil {
-		panic(err)
-	}
+	// Before the system phases, the proposer claims the rewards and starts the
+	// consensus periods by transactions.
+	if !bo.blockchain.Config().IsSystemPhases(block.Height()) {
+		// claim reward
+		if err := bo.claimReward(uint64(height)); err != nil {
+			panic(err)
+		}
 
-	// try add new consensusPeriod
-	if err := bo.newConsensusPeriod(block.Height()); err != nil {
-		panic(err)
+		// try add new consensusPeriod
+		if err := bo.newConsensusPeriod(block.Height()); err != nil {
+			panic(err)
+		}
 	}
 
 	return block, block.MakePartSet(types.BlockPartSizeBytes)
@@ -137,14 +155,19 @@ func (bo *BlockOperations) claimReward(height uint64) error {
 // CommitAndValidateBlockTxs executes and commits the transactions in the given block.
 // New calculated state root is validated against the root field in block.
 // Transactions, new state and receipts are saved to storage.
-func (bo *BlockOperations) CommitAndValidateBlockTxs(block *types.Block) (common.Hash, error) {
-	root, receipts, _, err := bo.commitTransactions(block.Transactions(), block.Header())
+// The returned receipts are committed to by the ReceiptHash and Bloom of the next block header.
+func (bo *BlockOperations) CommitAndValidateBlockTxs(block *types.Block) (common.Hash, types.Receipts, error) {
+	var transfers *transferRecorder
+	if bo.blockchain.AddressIndex {
+		transfers = newTransferRecorder()
+	}
+	root, receipts, _, err := bo.commitTransactions(block.Transactions(), block.Header(), transfers)
 	if err != nil {
-		return common.Hash{}, err
+		return common.Hash{}, nil, err
 	}
-	bo.saveReceipts(receipts, block)
+	bo.saveReceipts(receipts, block, transfers)
 	bo.blockchain.WriteAppHash(block.Height(), root)
-	return root, nil
+	return root, receipts, nil
 }
 
 // SaveBlock saves the given block, blockParts, and seenCommit to the underlying storage.
@@ -223,7 +246,7 @@ func (bo *BlockOperations) newHeader(height int64, numTxs uint64, blockId types.
 		LastBlockID:    blockId,
 		Validator:      validator,
 		ValidatorsHash: validatorsHash,
-		GasLimit:       215040000,
+		GasLimit:       blockGasLimit,
 	}
 }
 
@@ -238,7 +261,8 @@ func (bo *BlockOperations) newBlock(header *types.Header, txs []*types.Transacti
 }
 
 // commitTransactions executes the given transactions and commits the result stateDB to disk.
-func (bo *BlockOperations) commitTransactions(txs types.Transactions, header *types.Header) (common.Hash, types.Receipts,
+// The value transfers they make are recorded by the given recorder, if any.
+func (bo *BlockOperations) commitTransactions(txs types.Transactions, header *types.Header, transfers *transferRecorder) (common.Hash, types.Receipts,
 	types.Transactions, error) {
 	var (
 		newTxs   = types.Transactions{}
@@ -258,17 +282,25 @@ func (bo *BlockOperations) commitTransactions(txs types.Transactions, header *ty
 	bo.logger.Info("header gas limit", "limit", header.GasLimit)
 	gasPool := new(types.GasPool).AddGas(header.GasLimit)
 
+	vmConfig := kvm.Config{IsZeroFee: bo.blockchain.IsZeroFee}
+	if transfers != nil {
+		vmConfig.OnTransfer = transfers.record
+	}
+
+	beginBlock(bo.logger, bo.blockchain, state, header, common.Hash{}, len(txs))
+
 	// TODO(thientn): verifies the list is sorted by nonce so tx with lower nonce is execute first.
 LOOP:
 	for _, tx := range txs {
 		state.Prepare(tx.Hash(), common.Hash{}, counter)
 		snap := state.Snapshot()
+		transfers.start(tx.Hash())
 		// TODO(thientn): confirms nil coinbase is acceptable.
-		receipt, _, err := ApplyTransaction(bo.logger, bo.blockchain, gasPool, state, header, tx, usedGas, kvm.Config{
-			IsZeroFee: bo.blockchain.IsZeroFee,
-		})
+		receipt, _, err := ApplyTransaction(bo.logger, bo.blockchain, gasPool, state, header, tx, usedGas, vmConfig)
 		if err != nil {
 			bo.logger.Error("ApplyTransaction failed", "tx", tx.Hash().Hex(), "nonce", tx.Nonce(), "err", err)
+			bo.txPool.MarkFailed(header.Height, types.Transactions{tx}, []error{err})
+			transfers.discard(tx.Hash())
 			state.RevertToSnapshot(snap)
 			// TODO(thientn): check error type and jump to next tx if possible
 			// kiendn: instead of return nil and err, jump to next tx
@@ -279,6 +311,9 @@ LOOP:
 		receipts = append(receipts, receipt)
 		newTxs = append(newTxs, tx)
 	}
+	if receipt := endBlock(bo.logger, bo.blockchain, state, header, common.Hash{}, counter, *usedGas); receipt != nil {
+		receipts = append(receipts, receipt)
+	}
 
 	root, err := state.Commit(true)
 
@@ -295,9 +330,13 @@ LOOP:
 	return root, receipts, newTxs, nil
 }
 
-// saveReceipts saves receipts of block transactions to storage.
-func (bo *BlockOperations) saveReceipts(receipts types.Receipts, block *types.Block) {
+// saveReceipts saves receipts of block transactions to storage, indexing the
+// transactions by address along with them if transfers were recorded.
+func (bo *BlockOperations) saveReceipts(receipts types.Receipts, block *types.Block, transfers *transferRecorder) {
 	bo.blockchain.WriteReceipts(receipts, block)
+	if transfers != nil {
+		bo.blockchain.WriteAddressIndex(block, receipts, transfers.transfers)
+	}
 }
 
 func (bo *BlockOperations) Blockchain() base.BaseBlockChain {
@@ -28,6 +28,7 @@ import (
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/kardiachain/go-kardia/kai/events"
+	"github.com/kardiachain/go-kardia/kai/kaidb"
 	"github.com/kardiachain/go-kardia/kai/state"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/event"
@@ -75,6 +76,9 @@ type BlockChain struct {
 	// IsZeroFee is true then sender will be refunded all gas spent for a transaction
 	IsZeroFee bool
 
+	// AddressIndex is true then transactions are indexed by the addresses they involve
+	AddressIndex bool
+
 	pos.ConsensusInfo
 }
 
@@ -340,6 +344,13 @@ func (bc *BlockChain) SetHead(head uint64) error {
 	bc.hc.SetHead(head, delFn)
 	currentHeader := bc.hc.CurrentHeader()
 
+	// Drop the frozen blocks above the new head as well
+	if ancients, ok := bc.db.DB().(kaidb.AncientStore); ok {
+		if err := ancients.TruncateAncients(head + 1); err != nil {
+			return err
+		}
+	}
+
 	// Clear out any stale content from the caches
 	bc.blockCache.Purge()
 	bc.futureBlocks.Purge()
@@ -396,6 +407,15 @@ func (bc *BlockChain) WriteReceipts(receipts types.Receipts, block *types.Block)
 	bc.db.WriteReceipts(block.Hash(), block.Header().Height, receipts)
 }
 
+// WriteAddressIndex indexes the transactions of the given block by the addresses they
+// involve, given their receipts and the accounts of the value transfers they made.
+func (bc *BlockChain) WriteAddressIndex(block *types.Block, receipts types.Receipts, transfers map[common.Hash][]common.Address) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	WriteAddressIndex(bc.db, block, receipts, transfers)
+}
+
 // CommitTrie commits trie node such as statedb forcefully to disk.
 func (bc *BlockChain) CommitTrie(root common.Hash) error {
 	triedb := bc.stateCache.TrieDB()
@@ -0,0 +1,118 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package blockchain
+
+import (
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// FilterLogs returns the logs of the canonical blocks in [from, to] emitted by
+// any of the given addresses and matching the topic criteria. An empty address
+// list matches every contract. Each topic position holds the alternatives one
+// of which the log topic at that position has to equal, an empty position
+// matching any topic.
+func FilterLogs(db types.StoreDB, from, to uint64, addresses []common.Address, topics [][]common.Hash) []*types.Log {
+	var logs []*types.Log
+	for height := from; height <= to; height++ {
+		hash := db.ReadCanonicalHash(height)
+		if hash.IsZero() {
+			break
+		}
+		// The bloom of a block's logs is committed by the header of the next one
+		if next := db.ReadHeader(db.ReadCanonicalHash(height+1), height+1); next != nil && next.HasCommitments() {
+			if !bloomFilter(next.Bloom, addresses, topics) {
+				continue
+			}
+		}
+		for _, receipt := range db.ReadReceipts(hash, height) {
+			for _, log := range receipt.Logs {
+				if matchLog(log, addresses, topics) {
+					logs = append(logs, log)
+				}
+			}
+		}
+	}
+	return logs
+}
+
+// bloomFilter reports whether a block whose logs are summarised by the given
+// bloom may contain logs matching the criteria.
+func bloomFilter(bloom types.Bloom, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		included := false
+		for _, address := range addresses {
+			if types.BloomLookup(bloom, address) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, sub := range topics {
+		included := len(sub) == 0 // empty rule set == wildcard
+		for _, topic := range sub {
+			if types.BloomLookup(bloom, topic) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLog reports whether the log matches the address and topic criteria.
+func matchLog(log *types.Log, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 && !includes(addresses, log.Address) {
+		return false
+	}
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, sub := range topics {
+		if len(sub) == 0 {
+			continue
+		}
+		match := false
+		for _, topic := range sub {
+			if log.Topics[i] == topic {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+func includes(addresses []common.Address, a common.Address) bool {
+	for _, addr := range addresses {
+		if addr == a {
+			return true
+		}
+	}
+	return false
+}
@@ -74,6 +74,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		allLogs  []*types.Log
 		gp       = new(types.GasPool).AddGas(block.GasLimit())
 	)
+	beginBlock(p.logger, p.bc, statedb, header, block.Hash(), len(block.Transactions()))
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
@@ -84,10 +85,70 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
 	}
+	if receipt := endBlock(p.logger, p.bc, statedb, header, block.Hash(), len(block.Transactions()), *usedGas); receipt != nil {
+		receipts = append(receipts, receipt)
+		allLogs = append(allLogs, receipt.Logs...)
+	}
 
 	return receipts, allLogs, *usedGas, nil
 }
 
+// systemTxHash is the hash under which the logs of the begin and end block phases
+// of the block at the given height are recorded, these phases having no
+// transaction of their own.
+func systemTxHash(height uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("system"), new(big.Int).SetUint64(height).Bytes())
+}
+
+// beginBlock applies the system changes due before the transactions of a block
+// once the system phases are active, see kvm.BeginBlock. These changes are made
+// by no transaction, so a failure is logged and reverted rather than failing the
+// block; txIndex is the index of the receipt of the system phases in the block.
+func beginBlock(logger log.Logger, bc base.BaseBlockChain, statedb *state.StateDB, header *types.Header, blockHash common.Hash, txIndex int) {
+	if !bc.Config().IsSystemPhases(header.Height) {
+		return
+	}
+	statedb.Prepare(systemTxHash(header.Height), blockHash, txIndex)
+	snap := statedb.Snapshot()
+	if err := kvm.BeginBlock(bc, statedb, header); err != nil {
+		logger.Error("Begin block phase failed", "height", header.Height, "err", err)
+		statedb.RevertToSnapshot(snap)
+	}
+	statedb.Finalise(true)
+}
+
+// endBlock applies the system changes due after the transactions of a block once
+// the system phases are active, see kvm.EndBlock. It returns the receipt of the
+// system phases of the block if they produced logs, nil otherwise. txIndex is the
+// number of transactions applied, which may be lower than the one beginBlock took
+// when some of them failed.
+func endBlock(logger log.Logger, bc base.BaseBlockChain, statedb *state.StateDB, header *types.Header, blockHash common.Hash, txIndex int, usedGas uint64) *types.Receipt {
+	if !bc.Config().IsSystemPhases(header.Height) {
+		return nil
+	}
+	hash := systemTxHash(header.Height)
+	statedb.Prepare(hash, blockHash, txIndex)
+	snap := statedb.Snapshot()
+	if err := kvm.EndBlock(bc, statedb, header, usedGas); err != nil {
+		logger.Error("End block phase failed", "height", header.Height, "err", err)
+		statedb.RevertToSnapshot(snap)
+	}
+	statedb.Finalise(true)
+
+	logs := statedb.GetLogs(hash)
+	if len(logs) == 0 {
+		return nil
+	}
+	for _, l := range logs {
+		l.TxIndex = uint(txIndex)
+	}
+	receipt := types.NewReceipt(nil, false, usedGas)
+	receipt.TxHash = hash
+	receipt.Logs = logs
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	return receipt
+}
+
 // ApplyTransaction attempts to apply a transaction to the given state database
 // and uses the input parameters for its environment. It returns the receipt
 // for the transaction, gas used and an error if the transaction failed,
@@ -19,6 +19,7 @@
 package kai
 
 import (
+	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/kai/storage"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
@@ -57,6 +58,12 @@ type Config struct {
 	// IsZeroFee is true then sender will be refunded all gas spent for a transaction
 	IsZeroFee bool
 
+	// AddressIndex is true then transactions are indexed by the addresses they involve
+	AddressIndex bool
+
+	// LogsBlockRange is the maximum number of blocks a kai_getLogs query may span, 0 for no limit
+	LogsBlockRange uint64
+
 	// isPrivate is true then peerId will be checked through smc to make sure that it has permission to access the chain
 	IsPrivate bool
 
@@ -65,4 +72,8 @@ type Config struct {
 
 	// BaseAccount defines account which is used to execute internal smart contracts
 	BaseAccount *types.BaseAccount
+
+	// Consensus holds the consensus timing and empty-block policy. If nil, the
+	// default consensus config is used.
+	Consensus *configs.ConsensusConfig `toml:",omitempty"`
 }
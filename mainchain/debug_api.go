@@ -0,0 +1,100 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/trie"
+)
+
+// maxStorageRange is the maximum number of storage slots returned per page by
+// debug_storageRangeAt.
+const maxStorageRange = 1024
+
+// PrivateDebugAPI provides the debugging APIs of the Kardia service, such as
+// inspecting the raw state of contracts.
+type PrivateDebugAPI struct {
+	kaiService *KardiaService
+}
+
+// NewPrivateDebugAPI is a constructor that init new PrivateDebugAPI
+func NewPrivateDebugAPI(kaiService *KardiaService) *PrivateDebugAPI {
+	return &PrivateDebugAPI{kaiService}
+}
+
+// StorageEntry is a storage slot of a contract. Its key is only known if the
+// node recorded the preimage of its hash.
+type StorageEntry struct {
+	Key   *string `json:"key"`
+	Value string  `json:"value"`
+}
+
+// StorageRangeResult is a page of the storage of a contract, by hashed slot key.
+// NextKey is the hashed key the next page starts at, nil on the last page.
+type StorageRangeResult struct {
+	Storage map[string]StorageEntry `json:"storage"`
+	NextKey *string                 `json:"nextKey"`
+}
+
+// StorageRangeAt returns up to maxResult storage slots of the given contract in
+// the state after executing the given block, in the order of their hashed keys
+// from keyStart on.
+func (api *PrivateDebugAPI) StorageRangeAt(blockNrOrHash rpc.BlockNumberOrHash, address string, keyStart string, maxResult int) (*StorageRangeResult, error) {
+	if maxResult <= 0 || maxResult > maxStorageRange {
+		return nil, fmt.Errorf("maxResult must be between 1 and %d", maxStorageRange)
+	}
+	st, _, err := api.kaiService.stateAndHeaderByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	storage := st.StorageTrie(common.HexToAddress(address))
+	if storage == nil {
+		return nil, fmt.Errorf("account %s doesn't exist", address)
+	}
+	return storageRangeAt(storage, common.FromHex(keyStart), maxResult)
+}
+
+// storageRangeAt iterates the storage trie from the start key on.
+func storageRangeAt(st state.Trie, start []byte, maxResult int) (*StorageRangeResult, error) {
+	it := trie.NewIterator(st.NodeIterator(start))
+	result := &StorageRangeResult{Storage: make(map[string]StorageEntry)}
+	for i := 0; i < maxResult && it.Next(); i++ {
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		entry := StorageEntry{Value: common.BytesToHash(content).Hex()}
+		if preimage := st.GetKey(it.Key); preimage != nil {
+			key := common.BytesToHash(preimage).Hex()
+			entry.Key = &key
+		}
+		result.Storage[common.BytesToHash(it.Key).Hex()] = entry
+	}
+	// Add the next key so that clients can continue downloading
+	if it.Next() {
+		next := common.BytesToHash(it.Key).Hex()
+		result.NextKey = &next
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+// Tests that the pages of storageRangeAt cover the storage once, each page
+// starting at the next key of the previous one.
+func TestStorageRangeAt(t *testing.T) {
+	var (
+		storage = make(map[common.Hash]common.Hash)
+		hashed  = make(map[string]common.Hash)
+	)
+	for i := byte(1); i <= 10; i++ {
+		storage[common.Hash{i}] = common.Hash{0xff, i}
+		hashed[crypto.Keccak256Hash(common.Hash{i}.Bytes()).Hex()] = common.Hash{0xff, i}
+	}
+	s := newTestService(t, storage)
+	api := NewPrivateDebugAPI(s)
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	for _, pageSize := range []int{1, 3, 5, 9, 10, 11} {
+		var (
+			seen  = make(map[string]bool)
+			start string
+			pages int
+		)
+		for {
+			page, err := api.StorageRangeAt(latest, testContract.Hex(), start, pageSize)
+			if err != nil {
+				t.Fatalf("page size %d: %v", pageSize, err)
+			}
+			pages++
+			if page.NextKey != nil && len(page.Storage) != pageSize {
+				t.Fatalf("page size %d: %d slots on a page followed by another", pageSize, len(page.Storage))
+			}
+			for key, entry := range page.Storage {
+				if seen[key] {
+					t.Fatalf("page size %d: slot %s returned twice", pageSize, key)
+				}
+				seen[key] = true
+				if value, ok := hashed[key]; !ok || common.HexToHash(entry.Value) != value {
+					t.Errorf("page size %d: slot %s has value %s, want %s", pageSize, key, entry.Value, value.Hex())
+				}
+			}
+			if page.NextKey == nil {
+				break
+			}
+			// The next key is the first one of the next page.
+			if seen[*page.NextKey] {
+				t.Fatalf("page size %d: next key %s already returned", pageSize, *page.NextKey)
+			}
+			start = *page.NextKey
+		}
+		if len(seen) != len(storage) {
+			t.Errorf("page size %d: got %d slots, want %d", pageSize, len(seen), len(storage))
+		}
+		if want := (len(storage) + pageSize - 1) / pageSize; pages != want {
+			t.Errorf("page size %d: got %d pages, want %d", pageSize, pages, want)
+		}
+	}
+
+	if _, err := api.StorageRangeAt(latest, testContract.Hex(), "", 0); err == nil {
+		t.Error("no error for an empty page")
+	}
+	if _, err := api.StorageRangeAt(latest, testContract.Hex(), "", maxStorageRange+1); err == nil {
+		t.Error("no error for a page above the maximum")
+	}
+}
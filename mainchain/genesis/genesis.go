@@ -137,6 +137,21 @@ func SetupGenesisBlock(logger log.Logger, db types.StoreDB, genesis *Genesis, ba
 		return storedcfg, stored, nil
 	}
 
+	// Refuse a new config moving the activation of a fork the chain already passed.
+	if head := db.ReadHeaderHeight(db.ReadHeadBlockHash()); head != nil {
+		if err := storedcfg.CheckCompatible(newcfg, *head); err != nil {
+			return newcfg, stored, err
+		}
+	}
+
+	// The consensus timing is agreed on by the network when the chain starts,
+	// keep it so that nodes running with a different timing get warned about it.
+	if storedcfg.ConsensusTiming != nil && !storedcfg.ConsensusTiming.Equal(newcfg.ConsensusTiming) {
+		cfg := *newcfg
+		cfg.ConsensusTiming = storedcfg.ConsensusTiming
+		newcfg = &cfg
+	}
+
 	// Set baseAccount
 	if baseAccount != nil {
 		newcfg.SetBaseAccount(baseAccount)
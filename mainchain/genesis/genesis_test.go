@@ -5,9 +5,61 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/types"
 )
 
 func TestToCell(t *testing.T) {
 	cell := ToCell(int64(math.Pow(10, 6)))
 	assert.Equal(t, len(cell.String()), 25)
 }
+
+func TestSetupGenesisBlockForkChange(t *testing.T) {
+	db := kvstore.NewStoreDB(memorydb.New())
+	newGenesis := func(systemPhasesHeight *uint64) *Genesis {
+		config := *configs.TestnetChainConfig
+		config.SystemPhasesHeight = systemPhasesHeight
+		return &Genesis{Config: &config, GasLimit: 16777216}
+	}
+	_, hash, err := SetupGenesisBlock(log.New(), db, newGenesis(nil), nil)
+	assert.NoError(t, err)
+
+	// The head being the genesis block, activating a fork from it is refused.
+	_, _, err = SetupGenesisBlock(log.New(), db, newGenesis(new(uint64)), nil)
+	assert.Error(t, err)
+	assert.Nil(t, db.ReadChainConfig(hash).SystemPhasesHeight)
+
+	// A fork activated above the head can be scheduled, and moved as long as
+	// the chain did not reach it.
+	height := uint64(10)
+	_, _, err = SetupGenesisBlock(log.New(), db, newGenesis(&height), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, height, *db.ReadChainConfig(hash).SystemPhasesHeight)
+	later := uint64(20)
+	_, _, err = SetupGenesisBlock(log.New(), db, newGenesis(&later), nil)
+	assert.NoError(t, err)
+	_, _, err = SetupGenesisBlock(log.New(), db, newGenesis(new(uint64)), nil)
+	assert.Error(t, err)
+}
+
+func TestSetupGenesisBlockConsensusTiming(t *testing.T) {
+	db := kvstore.NewStoreDB(memorydb.New())
+	newGenesis := func(timeoutCommit uint64) *Genesis {
+		config := *configs.TestnetChainConfig
+		config.ConsensusTiming = &types.ConsensusTimingConfig{TimeoutPropose: 3000, TimeoutCommit: timeoutCommit}
+		return &Genesis{Config: &config, GasLimit: 16777216}
+	}
+	_, hash, err := SetupGenesisBlock(log.New(), db, newGenesis(1000), nil)
+	assert.NoError(t, err)
+
+	// Restarting with another timing keeps the one of the chain, for the
+	// node to be warned about the difference.
+	config, _, err := SetupGenesisBlock(log.New(), db, newGenesis(500), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), config.ConsensusTiming.TimeoutCommit)
+	assert.Equal(t, uint64(1000), db.ReadChainConfig(hash).ConsensusTiming.TimeoutCommit)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/mainchain/blockchain"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/mainchain/permissioned"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
 	"github.com/kardiachain/go-kardia/node"
 	"github.com/kardiachain/go-kardia/rpc"
@@ -66,6 +67,7 @@ type KardiaService struct {
 	protocolManager *service.ProtocolManager
 	blockchain      *blockchain.BlockChain
 	csManager       *consensus.ConsensusManager
+	nodeChecker     *permissioned.NodeChecker // nil unless the network is private
 
 	subService KardiaSubService
 
@@ -113,10 +115,17 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 		return nil, err
 	}
 
-	consensusConfig := configs.DefaultConsensusConfig()
+	consensusConfig := config.Consensus
+	if consensusConfig == nil {
+		consensusConfig = configs.DefaultConsensusConfig()
+	}
+	if timing := consensusConfig.Timing(); kai.chainConfig.ConsensusTiming != nil && !kai.chainConfig.ConsensusTiming.Equal(timing) {
+		logger.Warn("Consensus timing differs from the chain config", "local", timing, "chain", kai.chainConfig.ConsensusTiming)
+	}
 
 	// Set zeroFee to blockchain
 	kai.blockchain.IsZeroFee = config.IsZeroFee
+	kai.blockchain.AddressIndex = config.AddressIndex
 	kai.txPool = tx_pool.NewTxPool(config.TxPool, kai.chainConfig, kai.blockchain)
 	if consensusConfig.WaitForTxs() {
 		kai.txPool.EnableTxsAvailable()
@@ -129,6 +138,7 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 	if err != nil {
 		return nil, err
 	}
+	validatorSet.ReplayProposer(int64(block.Height()))
 
 	blockID := types.BlockID{
 		Hash:        block.Hash(),
@@ -142,6 +152,7 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 	//	return nil, err
 	//}
 
+	receipts := kai.blockchain.DB().ReadReceipts(block.Hash(), block.Height())
 	state := consensus.LastestBlockState{
 		ChainID:                     "kaicon", // TODO(thientn): considers merging this with protocolmanger.ChainID
 		LastBlockHeight:             cmn.NewBigUint64(block.Height()),
@@ -151,6 +162,8 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 		LastValidators:              validatorSet,
 		LastHeightValidatorsChanged: cmn.NewBigInt32(-1),
 		AppHash:                     kai.blockchain.ReadAppHash(block.Height()),
+		LastReceiptHash:             types.DeriveSha(receipts),
+		LastBloom:                   types.CreateBloom(receipts),
 		LastBlockTotalTx:            cmn.NewBigInt64(int64(block.NumTxs())),
 	}
 	consensusState := consensus.NewConsensusState(
@@ -179,6 +192,12 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 		return nil, err
 	}
 	kai.protocolManager.SetAcceptTxs(config.AcceptTxs)
+	if config.IsPrivate {
+		if kai.nodeChecker, err = permissioned.NewNodeChecker(kai.logger, kai.blockchain); err != nil {
+			return nil, err
+		}
+		kai.protocolManager.SetPeerChecker(kai.nodeChecker)
+	}
 	kai.csManager.SetProtocol(kai.protocolManager)
 
 	return kai, nil
@@ -189,16 +208,19 @@ func newKardiaService(ctx *node.ServiceContext, config *Config) (*KardiaService,
 func NewKardiaService(ctx *node.ServiceContext) (node.Service, error) {
 	chainConfig := ctx.Config.MainChainConfig
 	kai, err := newKardiaService(ctx, &Config{
-		NetworkId:   chainConfig.NetworkId,
-		ServiceName: chainConfig.ServiceName,
-		ChainId:     chainConfig.ChainId,
-		DBInfo:      chainConfig.DBInfo,
-		Genesis:     chainConfig.Genesis,
-		TxPool:      chainConfig.TxPool,
-		AcceptTxs:   chainConfig.AcceptTxs,
-		IsZeroFee:   chainConfig.IsZeroFee,
-		IsPrivate:   chainConfig.IsPrivate,
-		BaseAccount: chainConfig.BaseAccount,
+		NetworkId:      chainConfig.NetworkId,
+		ServiceName:    chainConfig.ServiceName,
+		ChainId:        chainConfig.ChainId,
+		DBInfo:         chainConfig.DBInfo,
+		Genesis:        chainConfig.Genesis,
+		TxPool:         chainConfig.TxPool,
+		AcceptTxs:      chainConfig.AcceptTxs,
+		IsZeroFee:      chainConfig.IsZeroFee,
+		AddressIndex:   chainConfig.AddressIndex,
+		LogsBlockRange: chainConfig.LogsBlockRange,
+		IsPrivate:      chainConfig.IsPrivate,
+		BaseAccount:    chainConfig.BaseAccount,
+		Consensus:      chainConfig.Consensus,
 	})
 
 	if err != nil {
@@ -227,6 +249,11 @@ func (s *KardiaService) Start(srvr *p2p.Server) error {
 	// Figures out a max peers count based on the server limits.
 	maxPeers := srvr.MaxPeers
 
+	// Rejects unpermitted nodes as early as the handshakes of a private network.
+	if s.nodeChecker != nil {
+		srvr.AddAdmissionCheck(s.nodeChecker.CheckNode)
+	}
+
 	// Starts the networking layer.
 	s.protocolManager.Start(maxPeers)
 
@@ -268,16 +295,41 @@ func (s *KardiaService) APIs() []rpc.API {
 			Service:   NewPublicTransactionAPI(s),
 			Public:    true,
 		},
+		{
+			Namespace: "txpool",
+			Version:   "1.0",
+			Service:   NewPublicTxPoolAPI(s),
+			Public:    true,
+		},
 		{
 			Namespace: "account",
 			Version:   "1.0",
 			Service:   NewPublicAccountAPI(s),
 			Public:    true,
 		},
+		{
+			Namespace: "staking",
+			Version:   "1.0",
+			Service:   NewPublicStakingAPI(s),
+			Public:    true,
+		},
+		{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   NewPrivateAdminAPI(s),
+			Public:    false,
+		},
+		{
+			Namespace: "debug",
+			Version:   "1.0",
+			Service:   NewPrivateDebugAPI(s),
+			Public:    false,
+		},
 	}
 }
 
-func (s *KardiaService) TxPool() *tx_pool.TxPool            { return s.txPool }
-func (s *KardiaService) BlockChain() *blockchain.BlockChain { return s.blockchain }
-func (s *KardiaService) ChainConfig() *types.ChainConfig    { return s.chainConfig }
-func (s *KardiaService) DB() types.StoreDB                  { return s.kaiDb }
+func (s *KardiaService) TxPool() *tx_pool.TxPool                       { return s.txPool }
+func (s *KardiaService) BlockChain() *blockchain.BlockChain            { return s.blockchain }
+func (s *KardiaService) ChainConfig() *types.ChainConfig               { return s.chainConfig }
+func (s *KardiaService) DB() types.StoreDB                             { return s.kaiDb }
+func (s *KardiaService) ConsensusManager() *consensus.ConsensusManager { return s.csManager }
@@ -0,0 +1,101 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package permissioned
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/kai/base"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/metrics"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+)
+
+// ErrNodeNotPermitted is returned when a node is not registered in the permission contract.
+var ErrNodeNotPermitted = errors.New("node is not registered in the permission contract")
+
+var rejectedNodeMeter = metrics.NewRegisteredMeter("permissioned/nodes/rejected", nil)
+
+// NodeChecker checks nodes against the permission contract at the head of a chain.
+// It is safe for concurrent use.
+type NodeChecker struct {
+	logger log.Logger
+
+	mtx  sync.Mutex // protects util, whose state is swapped on every check
+	util *PermissionSmcUtil
+	bc   base.BaseBlockChain
+	root common.Hash // storage root of the permission contract seen by Changed
+}
+
+// NewNodeChecker returns a checker of the permission contract deployed on the given chain.
+func NewNodeChecker(logger log.Logger, bc base.BaseBlockChain) (*NodeChecker, error) {
+	util, err := NewSmcPermissionUtil(bc)
+	if err != nil {
+		return nil, err
+	}
+	c := &NodeChecker{logger: logger, util: util, bc: bc}
+	c.root = util.StateDb.GetStorageRoot(*util.ContractAddress)
+	return c, nil
+}
+
+// CheckNode returns nil if the node with the given ID is registered in the permission
+// contract at the current head, ErrNodeNotPermitted or the failure of the call otherwise.
+func (c *NodeChecker) CheckNode(id discover.NodeID) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	stateDb, err := c.bc.State()
+	if err != nil {
+		rejectedNodeMeter.Mark(1)
+		return err
+	}
+	c.util.StateDb = stateDb
+	// The public key of a node in the contract is the hex encoding of its ID.
+	_, nodeType, _, _, err := c.util.GetNodeInfo(id.String())
+	if err != nil {
+		rejectedNodeMeter.Mark(1)
+		return err
+	}
+	if nodeType == nil || nodeType.Sign() == 0 {
+		rejectedNodeMeter.Mark(1)
+		c.logger.Debug("Node is not permitted", "id", id.TerminalString())
+		return ErrNodeNotPermitted
+	}
+	return nil
+}
+
+// Changed reports whether the storage of the permission contract changed at the current
+// head since the previous call, i.e. whether nodes may have been added or removed.
+func (c *NodeChecker) Changed() (bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	stateDb, err := c.bc.State()
+	if err != nil {
+		return false, err
+	}
+	root := stateDb.GetStorageRoot(*c.util.ContractAddress)
+	if root == c.root {
+		return false, nil
+	}
+	c.root = root
+	return true, nil
+}
@@ -0,0 +1,75 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package permissioned
+
+import (
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+)
+
+// stateChain is a chain whose head state is set by the test.
+type stateChain struct {
+	*blockchain.BlockChain
+	state *state.StateDB
+}
+
+func (c *stateChain) State() (*state.StateDB, error) {
+	return c.state, nil
+}
+
+func TestNodeChecker(t *testing.T) {
+	bc := newTestChain(t)
+	genesisState, err := bc.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &stateChain{BlockChain: bc, state: genesisState}
+	checker, err := NewNodeChecker(log.New(), chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := discover.NodeID{1}
+	if err := checker.CheckNode(id); err != ErrNodeNotPermitted {
+		t.Fatalf("CheckNode of an unknown node = %v, want %v", err, ErrNodeNotPermitted)
+	}
+	if changed, err := checker.Changed(); err != nil || changed {
+		t.Fatalf("Changed without new head = %v, %v, want false", changed, err)
+	}
+
+	// A new head registering the node admits it.
+	chain.state = genesisState.Copy()
+	addNode(t, bc, checker.util, chain.state, id.String())
+	chain.state.IntermediateRoot(true)
+	if changed, err := checker.Changed(); err != nil || !changed {
+		t.Fatalf("Changed after addNode = %v, %v, want true", changed, err)
+	}
+	if changed, err := checker.Changed(); err != nil || changed {
+		t.Fatalf("Changed twice = %v, %v, want false", changed, err)
+	}
+	if err := checker.CheckNode(id); err != nil {
+		t.Fatalf("CheckNode of a registered node = %v", err)
+	}
+	if err := checker.CheckNode(discover.NodeID{2}); err != ErrNodeNotPermitted {
+		t.Fatalf("CheckNode of another node = %v, want %v", err, ErrNodeNotPermitted)
+	}
+}
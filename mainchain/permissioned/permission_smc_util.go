@@ -19,6 +19,7 @@
 package permissioned
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"math/big"
 	"strings"
@@ -27,7 +28,9 @@ import (
 	"github.com/kardiachain/go-kardia/kai/base"
 	"github.com/kardiachain/go-kardia/kai/state"
 	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/kvm/smc/bindings"
 	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/log"
 	kvm2 "github.com/kardiachain/go-kardia/mainchain/kvm"
@@ -50,6 +53,18 @@ type PermissionSmcUtil struct {
 	SenderAddress    *common.Address
 	SenderPrivateKey *ecdsa.PrivateKey
 	bc               base.BaseBlockChain
+	caller           *bindings.PermissionCaller
+}
+
+// stateCaller implements bind.ContractCaller with static calls against the current
+// StateDb of util, which callers may swap between calls, the block height being ignored.
+type stateCaller struct {
+	util *PermissionSmcUtil
+}
+
+// CallContract implements bind.ContractCaller.
+func (c stateCaller) CallContract(ctx context.Context, call bind.CallMsg, blockHeight uint64) ([]byte, error) {
+	return CallStaticKardiaMasterSmc(call.From, *call.To, c.util.bc, call.Data, c.util.StateDb)
 }
 
 func NewSmcPermissionUtil(bc base.BaseBlockChain) (*PermissionSmcUtil, error) {
@@ -68,68 +83,48 @@ func NewSmcPermissionUtil(bc base.BaseBlockChain) (*PermissionSmcUtil, error) {
 		log.Error("Error reading abi", "err", err)
 		return nil, err
 	}
-	return &PermissionSmcUtil{Abi: &abi, StateDb: stateDb, ContractAddress: &permissionSmcAddr,
-		SenderAddress: &bc.Config().BaseAccount.Address, bc: bc, SenderPrivateKey: &bc.Config().BaseAccount.PrivateKey}, nil
+	util := &PermissionSmcUtil{Abi: &abi, StateDb: stateDb, ContractAddress: &permissionSmcAddr,
+		SenderAddress: &bc.Config().BaseAccount.Address, bc: bc, SenderPrivateKey: &bc.Config().BaseAccount.PrivateKey}
+	util.caller, err = bindings.NewPermissionCaller(permissionSmcAddr, stateCaller{util: util})
+	if err != nil {
+		log.Error("Error binding permission contract", "err", err)
+		return nil, err
+	}
+	return util, nil
+}
+
+// callOpts returns the options of the permission contract calls, made by the sender.
+func (s *PermissionSmcUtil) callOpts() *bind.CallOpts {
+	return &bind.CallOpts{From: *s.SenderAddress}
 }
 
 // IsValidNode executes smart contract to check if a node with specified pubkey and nodeType is valid
 func (s *PermissionSmcUtil) IsValidNode(pubkey string, nodeType int64) (bool, error) {
-	checkNodeValidInput, err := s.Abi.Pack("isValidNode", pubkey, big.NewInt(nodeType))
-	if err != nil {
-		log.Error("Error packing check valid node input", "err", err)
-		return false, err
-	}
-	checkNodeValidResult, err := CallStaticKardiaMasterSmc(*s.SenderAddress, *s.ContractAddress, s.bc,
-		checkNodeValidInput, s.StateDb)
+	result, err := s.caller.IsValidNode(s.callOpts(), pubkey, big.NewInt(nodeType))
 	if err != nil {
 		log.Error("Error call permission contract", "err", err)
 		return false, err
 	}
-	result := big.NewInt(0).SetBytes(checkNodeValidResult)
 	return result.Cmp(big.NewInt(1)) == 0, nil
 }
 
 // GetNodeInfo executes smart contract to get info of a node specified by pubkey, returns address, nodeType, votingPower and listenAddress
 func (s *PermissionSmcUtil) GetNodeInfo(pubkey string) (common.Address, *big.Int, *big.Int, string, error) {
-	getNodeInfoInput, err := s.Abi.Pack("getNodeInfo", pubkey)
-	if err != nil {
-		log.Error("Error packing get node info input", "err", err)
-		return common.Address{}, nil, nil, "", err
-	}
-	getNodeInfoResult, err := CallStaticKardiaMasterSmc(*s.SenderAddress, *s.ContractAddress,
-		s.bc, getNodeInfoInput, s.StateDb)
+	nodeInfo, err := s.caller.GetNodeInfo(s.callOpts(), pubkey)
 	if err != nil {
 		log.Error("Error call permission contract", "err", err)
 		return common.Address{}, nil, nil, "", err
 	}
-	var nodeInfo struct {
-		Addr          common.Address
-		VotingPower   *big.Int
-		NodeType      *big.Int
-		ListenAddress string
-	}
-	err = s.Abi.Unpack(&nodeInfo, "getNodeInfo", getNodeInfoResult)
-	if err != nil {
-		log.Error("Error unpacking node info", "err", err)
-		return common.Address{}, nil, nil, "", err
-	}
 	return nodeInfo.Addr, nodeInfo.NodeType, nodeInfo.VotingPower, nodeInfo.ListenAddress, nil
 }
 
 // IsValidator executes smart contract to check if a node with specified pubkey is validator
 func (s *PermissionSmcUtil) IsValidator(pubkey string) (bool, error) {
-	checkValidatorInput, err := s.Abi.Pack("isValidator", pubkey)
-	if err != nil {
-		log.Error("Error packing check validator input", "err", err)
-		return false, err
-	}
-	checkValidatorResult, err := CallStaticKardiaMasterSmc(*s.SenderAddress, *s.ContractAddress,
-		s.bc, checkValidatorInput, s.StateDb)
+	result, err := s.caller.IsValidator(s.callOpts(), pubkey)
 	if err != nil {
 		log.Error("Error call permission contract", "err", err)
 		return false, err
 	}
-	result := big.NewInt(0).SetBytes(checkValidatorResult)
 	return result.Cmp(big.NewInt(1)) == 0, nil
 }
 
@@ -160,25 +155,7 @@ func (s *PermissionSmcUtil) RemoveNodeForPrivateChain(pubkey string, txPool *tx_
 // GetAdminNodeByIndex executes smart contract to get info of an initial node, including public key, address, listen address,
 // voting power, node type
 func (s *PermissionSmcUtil) GetAdminNodeByIndex(index int64) (string, common.Address, string, *big.Int, *big.Int, error) {
-	getInitialNodeInput, err := s.Abi.Pack("getInitialNodeByIndex", big.NewInt(index))
-	if err != nil {
-		log.Error("Error packing initial node input", "err", err)
-		return "", common.Address{}, "", nil, nil, err
-	}
-	getInitialNodeResult, err := CallStaticKardiaMasterSmc(*s.SenderAddress, *s.ContractAddress,
-		s.bc, getInitialNodeInput, s.StateDb)
-	if err != nil {
-		log.Error("Error calling permission contract", "err", err)
-		return "", common.Address{}, "", nil, nil, err
-	}
-	var initialNodeInfo struct {
-		Publickey   string
-		Addr        common.Address
-		ListenAddr  string
-		VotingPower *big.Int
-		NodeType    *big.Int
-	}
-	err = s.Abi.Unpack(&initialNodeInfo, "getInitialNodeByIndex", getInitialNodeResult)
+	initialNodeInfo, err := s.caller.GetInitialNodeByIndex(s.callOpts(), big.NewInt(index))
 	if err != nil {
 		log.Error("Error calling permission contract", "err", err)
 		return "", common.Address{}, "", nil, nil, err
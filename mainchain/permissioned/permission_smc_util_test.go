@@ -0,0 +1,101 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package permissioned
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	kvm2 "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// newTestChain returns a chain whose genesis holds the permission contract, the base
+// account being one of its initial owners.
+func newTestChain(t *testing.T) *blockchain.BlockChain {
+	owner := common.HexToAddress("0xc1fe56E3F58D3244F606306611a5d10c8333f1f6")
+	privateKey, _ := crypto.HexToECDSA("8843ebcb1021b00ae9a644db6617f9c6d870e5fd53624cefe374c1d2d710fd06")
+	kaiDb := kvstore.NewStoreDB(memorydb.New())
+	g := genesis.DefaulTestnetFullGenesisBlock(map[string]*big.Int{owner.String(): configs.InitValueInCell}, configs.GenesisContracts)
+	chainConfig, _, err := genesis.SetupGenesisBlock(log.New(), kaiDb, g, &types.BaseAccount{
+		Address:    owner,
+		PrivateKey: *privateKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc, err := blockchain.NewBlockChain(log.New(), kaiDb, chainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bc
+}
+
+// addNode registers the node with the given public key in the permission contract
+// on the given state.
+func addNode(t *testing.T, bc *blockchain.BlockChain, util *PermissionSmcUtil, stateDb *state.StateDB, pubkey string) {
+	input, err := util.Abi.Pack("addNode", pubkey, common.HexToAddress("0x01"), big.NewInt(1), big.NewInt(0), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := kvm2.NewKVMContextFromDualNodeCall(*util.SenderAddress, bc.CurrentHeader(), bc)
+	vmenv := kvm.NewKVM(ctx, stateDb, kvm.Config{})
+	if _, _, err := vmenv.Call(kvm.AccountRef(*util.SenderAddress), *util.ContractAddress, input, uint64(MaximumGasToCallStaticFunction), big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPermissionSmcUtil_StateDb(t *testing.T) {
+	bc := newTestChain(t)
+	util, err := NewSmcPermissionUtil(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := common.Bytes2Hex(make([]byte, 64))
+	if valid, err := util.IsValidNode(pubkey, 1); err != nil || valid {
+		t.Fatalf("IsValidNode before addNode = %v, %v, want false", valid, err)
+	}
+
+	// Add the node on a copy of the state, the util keeping the original one.
+	genesisState := util.StateDb
+	stateDb := genesisState.Copy()
+	addNode(t, bc, util, stateDb, pubkey)
+	if valid, err := util.IsValidNode(pubkey, 1); err != nil || valid {
+		t.Fatalf("IsValidNode on the previous state = %v, %v, want false", valid, err)
+	}
+
+	util.StateDb = stateDb
+	if valid, err := util.IsValidNode(pubkey, 1); err != nil || !valid {
+		t.Fatalf("IsValidNode after swapping the state = %v, %v, want true", valid, err)
+	}
+	util.StateDb = genesisState
+	if valid, err := util.IsValidNode(pubkey, 1); err != nil || valid {
+		t.Fatalf("IsValidNode after restoring the state = %v, %v, want false", valid, err)
+	}
+}
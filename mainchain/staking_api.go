@@ -0,0 +1,294 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package kai
+
+import (
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// ValidatorJSON is a validator of a consensus period along with its node.
+type ValidatorJSON struct {
+	Address     string `json:"address"`
+	Node        string `json:"node,omitempty"`
+	Name        string `json:"name,omitempty"`
+	VotingPower int64  `json:"votingPower"`
+	Stakes      string `json:"stakes,omitempty"`
+}
+
+// ValidatorSetJSON is the validator set of a consensus period.
+type ValidatorSetJSON struct {
+	StartHeight uint64           `json:"startHeight"`
+	EndHeight   uint64           `json:"endHeight"`
+	Validators  []*ValidatorJSON `json:"validators"`
+}
+
+// StakerJSON is the stake of a staker contract in a node.
+type StakerJSON struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+// StakingNodeJSON is an available node of the PoS master contract, a candidate
+// of the validator sets.
+type StakingNodeJSON struct {
+	Address          string        `json:"address"`
+	Owner            string        `json:"owner"`
+	Validator        string        `json:"validator"`
+	Name             string        `json:"name"`
+	RewardPercentage uint16        `json:"rewardPercentage"`
+	LockedPeriod     uint64        `json:"lockedPeriod"`
+	MinimumStakes    string        `json:"minimumStakes"`
+	Balance          string        `json:"balance"`
+	Stakes           string        `json:"stakes"`
+	Stakers          []*StakerJSON `json:"stakers"`
+	RejectedBlocks   string        `json:"rejectedBlocks"`
+	ValidatedBlocks  string        `json:"validatedBlocks"`
+}
+
+// DelegationJSON is a stake of a delegator in a node.
+type DelegationJSON struct {
+	Node         string `json:"node"`
+	Staker       string `json:"staker"`
+	Amount       string `json:"amount"`
+	StartedAt    uint64 `json:"startedAt"`
+	UnlockHeight uint64 `json:"unlockHeight"`
+}
+
+// PendingNodeJSON is a node waiting for the votes of the available nodes to join
+// them.
+type PendingNodeJSON struct {
+	Index  uint64 `json:"index"`
+	Node   string `json:"node"`
+	Stakes string `json:"stakes"`
+	Votes  uint64 `json:"votes"`
+}
+
+// DeleteRequestJSON is a request to remove an available node waiting for votes.
+type DeleteRequestJSON struct {
+	Index     uint64 `json:"index"`
+	NodeIndex uint64 `json:"nodeIndex"`
+	Node      string `json:"node"`
+	Stakes    string `json:"stakes"`
+	Votes     uint64 `json:"votes"`
+}
+
+// PublicStakingAPI offers the RPC methods reading the PoS contracts: validator
+// sets, nodes, their stakers and the delegations. All read the head state.
+type PublicStakingAPI struct {
+	kaiService *KardiaService
+}
+
+// NewPublicStakingAPI creates a new staking API.
+func NewPublicStakingAPI(kaiService *KardiaService) *PublicStakingAPI {
+	return &PublicStakingAPI{kaiService}
+}
+
+func (a *PublicStakingAPI) state() (*state.StateDB, error) {
+	return a.kaiService.blockchain.State()
+}
+
+// nodesByValidator returns the available nodes by the address of their node key.
+func (a *PublicStakingAPI) nodesByValidator(st *state.StateDB) (map[common.Address]*kvm.StakingNode, error) {
+	nodes, err := kvm.GetAvailableNodes(a.kaiService.blockchain, st)
+	if err != nil {
+		return nil, err
+	}
+	byValidator := make(map[common.Address]*kvm.StakingNode, len(nodes))
+	for _, node := range nodes {
+		byValidator[node.Validator] = node
+	}
+	return byValidator, nil
+}
+
+// Validators returns the validator set of the current consensus period.
+func (a *PublicStakingAPI) Validators() (*ValidatorSetJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := a.nodesByValidator(st)
+	if err != nil {
+		return nil, err
+	}
+	valSet := a.kaiService.csManager.GetRoundState().Validators.Copy()
+	set := &ValidatorSetJSON{
+		StartHeight: uint64(valSet.StartHeight),
+		EndHeight:   uint64(valSet.EndHeight),
+		Validators:  make([]*ValidatorJSON, 0, valSet.Size()),
+	}
+	for _, val := range valSet.Validators {
+		v := &ValidatorJSON{Address: val.Address.Hex(), VotingPower: val.VotingPower}
+		if node, ok := nodes[val.Address]; ok {
+			v.Node, v.Name, v.Stakes = node.Address.Hex(), node.Name, node.Stakes.String()
+		}
+		set.Validators = append(set.Validators, v)
+	}
+	return set, nil
+}
+
+// NextValidators returns the validator set of the next consensus period once
+// collected, nil before.
+func (a *PublicStakingAPI) NextValidators() (*ValidatorSetJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	latest, err := kvm.GetLatestValidators(a.kaiService.blockchain, st)
+	if err != nil {
+		return nil, err
+	}
+	if current := a.kaiService.csManager.GetRoundState().Validators; latest.StartHeight <= uint64(current.EndHeight) {
+		return nil, nil
+	}
+	nodes, err := kvm.GetAvailableNodes(a.kaiService.blockchain, st)
+	if err != nil {
+		return nil, err
+	}
+	set := &ValidatorSetJSON{
+		StartHeight: latest.StartHeight,
+		EndHeight:   latest.EndHeight,
+		Validators:  make([]*ValidatorJSON, 0, len(latest.Nodes)),
+	}
+	for _, address := range latest.Nodes {
+		v := &ValidatorJSON{Node: address.Hex()}
+		for _, node := range nodes {
+			if node.Address == address {
+				// voting power is the stakes in KAI, as in kvm.CollectValidatorSet
+				v.Address, v.Name, v.Stakes = node.Validator.Hex(), node.Name, node.Stakes.String()
+				v.VotingPower = new(big.Int).Div(node.Stakes, kvm.KAI).Int64()
+			}
+		}
+		set.Validators = append(set.Validators, v)
+	}
+	return set, nil
+}
+
+// Nodes returns the available nodes, the candidates of the next validator sets.
+func (a *PublicStakingAPI) Nodes() ([]*StakingNodeJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := kvm.GetAvailableNodes(a.kaiService.blockchain, st)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*StakingNodeJSON, len(nodes))
+	for i, node := range nodes {
+		result[i] = newStakingNodeJSON(node)
+	}
+	return result, nil
+}
+
+// Node returns the available node of the given node contract address, nil if
+// there is none.
+func (a *PublicStakingAPI) Node(address string) (*StakingNodeJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	node, err := kvm.GetAvailableNode(a.kaiService.blockchain, st, common.HexToAddress(address))
+	if err != nil || node == nil {
+		return nil, err
+	}
+	return newStakingNodeJSON(node), nil
+}
+
+// Delegations returns the stakes of the given delegator, either a staker contract
+// or the owner of staker contracts, along with the heights they unlock at.
+func (a *PublicStakingAPI) Delegations(address string) ([]*DelegationJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	delegations, err := kvm.GetDelegations(a.kaiService.blockchain, st, common.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*DelegationJSON, len(delegations))
+	for i, d := range delegations {
+		result[i] = &DelegationJSON{
+			Node:         d.Node.Hex(),
+			Staker:       d.Staker.Hex(),
+			Amount:       d.Amount.String(),
+			StartedAt:    d.StartedAt,
+			UnlockHeight: d.UnlockHeight,
+		}
+	}
+	return result, nil
+}
+
+// PendingNodes returns the nodes waiting for votes to join the available nodes.
+func (a *PublicStakingAPI) PendingNodes() ([]*PendingNodeJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := kvm.GetPendingNodes(a.kaiService.blockchain, st)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PendingNodeJSON, len(pending))
+	for i, p := range pending {
+		result[i] = &PendingNodeJSON{Index: p.Index, Node: p.Node.Hex(), Stakes: p.Stakes.String(), Votes: p.Votes}
+	}
+	return result, nil
+}
+
+// DeleteRequests returns the requests to remove available nodes with their votes.
+func (a *PublicStakingAPI) DeleteRequests() ([]*DeleteRequestJSON, error) {
+	st, err := a.state()
+	if err != nil {
+		return nil, err
+	}
+	requests, err := kvm.GetDeleteRequests(a.kaiService.blockchain, st)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*DeleteRequestJSON, len(requests))
+	for i, r := range requests {
+		result[i] = &DeleteRequestJSON{Index: r.Index, NodeIndex: r.NodeIndex, Node: r.Node.Hex(), Stakes: r.Stakes.String(), Votes: r.Votes}
+	}
+	return result, nil
+}
+
+func newStakingNodeJSON(node *kvm.StakingNode) *StakingNodeJSON {
+	stakers := make([]*StakerJSON, len(node.Stakers))
+	for i, staker := range node.Stakers {
+		stakers[i] = &StakerJSON{Address: staker.Address.Hex(), Amount: staker.Amount.String()}
+	}
+	return &StakingNodeJSON{
+		Address:          node.Address.Hex(),
+		Owner:            node.Owner.Hex(),
+		Validator:        node.Validator.Hex(),
+		Name:             node.Name,
+		RewardPercentage: node.RewardPercentage,
+		LockedPeriod:     node.LockedPeriod,
+		MinimumStakes:    node.MinimumStakes.String(),
+		Balance:          node.Balance.String(),
+		Stakes:           node.Stakes.String(),
+		Stakers:          stakers,
+		RejectedBlocks:   node.RejectedBlocks.String(),
+		ValidatedBlocks:  node.ValidatedBlocks.String(),
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx_pool
+
+import (
+	"errors"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// droppedTxsLimit is the number of recently dropped transactions remembered by the pool.
+const droppedTxsLimit = 4096
+
+var (
+	errQueuedTooLong    = errors.New("queued for too long")
+	errPoolFlushed      = errors.New("pool flushed")
+	errOutpriced        = errors.New("pool full of better priced transactions")
+	errAccountQueueFull = errors.New("account queue full")
+	errPendingFull      = errors.New("pending pool full")
+	errQueueFull        = errors.New("queue full")
+)
+
+// DropReason tells why a transaction left the pool without being included in a block.
+type DropReason string
+
+const (
+	DropNonceTooLow       DropReason = "nonce too low"
+	DropUnderpriced       DropReason = "underpriced"
+	DropReplaced          DropReason = "replaced"
+	DropInsufficientFunds DropReason = "insufficient funds"
+	DropGasLimit          DropReason = "exceeds block gas limit"
+	DropEvicted           DropReason = "evicted"
+	DropInvalid           DropReason = "invalid"
+	DropFailed            DropReason = "failed in block"
+)
+
+// DroppedTx describes a transaction recently dropped or rejected by the pool.
+type DroppedTx struct {
+	Hash        common.Hash
+	Reason      DropReason
+	Err         string      // Error behind the drop, if any
+	ReplacedBy  common.Hash // Transaction replacing this one, for DropReplaced
+	BlockHeight uint64      // Block the transaction failed in, for DropFailed
+	Time        time.Time
+}
+
+// droppedTxs is a bounded index of the recently dropped transactions, evicting
+// the oldest entries first. It is safe for concurrent use.
+type droppedTxs struct {
+	cache *lru.Cache
+}
+
+func newDroppedTxs(limit int) *droppedTxs {
+	cache, _ := lru.New(limit)
+	return &droppedTxs{cache: cache}
+}
+
+// add records the drop of a transaction, overriding any previous record.
+func (d *droppedTxs) add(drop *DroppedTx) {
+	drop.Time = time.Now()
+	d.cache.Add(drop.Hash, drop)
+}
+
+// get returns the record of a dropped transaction, or nil if unknown.
+func (d *droppedTxs) get(hash common.Hash) *DroppedTx {
+	if drop, ok := d.cache.Get(hash); ok {
+		return drop.(*DroppedTx)
+	}
+	return nil
+}
+
+// remove forgets a transaction, e.g. when it enters the pool again.
+func (d *droppedTxs) remove(hash common.Hash) {
+	d.cache.Remove(hash)
+}
+
+// dropReason maps a validation error of the pool to the reason of the rejection.
+func dropReason(err error) DropReason {
+	switch err {
+	case ErrNonceTooLow:
+		return DropNonceTooLow
+	case ErrUnderpriced, ErrReplaceUnderpriced:
+		return DropUnderpriced
+	case ErrInsufficientFunds:
+		return DropInsufficientFunds
+	case ErrGasLimit:
+		return DropGasLimit
+	default:
+		return DropInvalid
+	}
+}
+
+// recordDrop records the drop of the given transaction for the given reason.
+func (pool *TxPool) recordDrop(tx *types.Transaction, reason DropReason, err error) {
+	drop := &DroppedTx{Hash: tx.Hash(), Reason: reason}
+	if err != nil {
+		drop.Err = err.Error()
+	}
+	pool.dropped.add(drop)
+}
+
+// recordReplaced records the replacement of a transaction by another one with the
+// same nonce paying more.
+func (pool *TxPool) recordReplaced(old, tx *types.Transaction) {
+	pool.dropped.add(&DroppedTx{Hash: old.Hash(), Reason: DropReplaced, ReplacedBy: tx.Hash()})
+}
+
+// recordUnexecutables records the drop of transactions filtered out for their cost,
+// telling apart the ones exceeding the block gas limit.
+func (pool *TxPool) recordUnexecutables(txs types.Transactions) {
+	for _, tx := range txs {
+		if tx.Gas() > pool.currentMaxGas {
+			pool.recordDrop(tx, DropGasLimit, ErrGasLimit)
+		} else {
+			pool.recordDrop(tx, DropInsufficientFunds, ErrInsufficientFunds)
+		}
+	}
+}
+
+// MarkFailed records that the given transactions failed to execute in the block
+// at the given height, so they were left out of it.
+func (pool *TxPool) MarkFailed(height uint64, txs types.Transactions, errs []error) {
+	for i, tx := range txs {
+		drop := &DroppedTx{Hash: tx.Hash(), Reason: DropFailed, BlockHeight: height}
+		if i < len(errs) && errs[i] != nil {
+			drop.Err = errs[i].Error()
+		}
+		pool.dropped.add(drop)
+	}
+}
+
+// Dropped returns why the transaction with the given hash recently left the pool
+// or was rejected by it, or nil if the pool doesn't know about such a drop.
+func (pool *TxPool) Dropped(hash common.Hash) *DroppedTx {
+	return pool.dropped.get(hash)
+}
@@ -250,6 +250,8 @@ type TxPool struct {
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+
+	dropped *droppedTxs // Recently dropped transactions, with the reason why
 }
 
 type txpoolResetRequest struct {
@@ -279,6 +281,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *types.ChainConfig, chain blockC
 		reorgDoneCh:     make(chan chan struct{}),
 		reorgShutdownCh: make(chan struct{}),
 		gasPrice:        new(big.Int).SetUint64(config.PriceLimit),
+		dropped:         newDroppedTxs(droppedTxsLimit),
 	}
 	pool.locals = newAccountSet(pool.signer)
 	for _, addr := range config.Locals {
@@ -333,6 +336,9 @@ func (pool *TxPool) notifyTxsAvailable() {
 }
 
 func (pool *TxPool) State() *state.StateDB {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
 	return pool.currentState
 }
 
@@ -349,12 +355,34 @@ func (pool *TxPool) PendingSize() int {
 	return pendingSize
 }
 
-// ProposeTransactions collects transactions from pending and remove them.
-func (pool *TxPool) ProposeTransactions() []*types.Transaction {
-	return pool.GetPendingData()
+// ProposeTransactions collects the pending transactions to include in a new block,
+// the ones paying the highest gas price first while honouring the nonce order of
+// every account. Transactions are skipped along with the later ones of their account
+// once the sum of their gas limits would exceed gasLimit or their size maxBytes.
+func (pool *TxPool) ProposeTransactions(gasLimit uint64, maxBytes common.StorageSize) []*types.Transaction {
+	pending, _ := pool.Pending()
+
+	var (
+		txs      = types.NewTransactionsByPriceAndNonce(pool.signer, pending)
+		proposed = []*types.Transaction{}
+		gas      uint64
+		size     common.StorageSize
+	)
+	for tx := txs.Peek(); tx != nil; tx = txs.Peek() {
+		if tx.Gas() > gasLimit-gas || size+tx.Size() > maxBytes {
+			// The later transactions of the account can't be executed without this one.
+			txs.Pop()
+			continue
+		}
+		gas += tx.Gas()
+		size += tx.Size()
+		proposed = append(proposed, tx)
+		txs.Shift()
+	}
+	return proposed
 }
 
-// ProposeTransactions collects transactions from pending and remove them.
+// GetPendingData returns all pending transactions.
 func (pool *TxPool) GetPendingData() []*types.Transaction {
 	txs := []*types.Transaction{}
 	pending, _ := pool.Pending()
@@ -421,6 +449,7 @@ func (pool *TxPool) loop() {
 				if time.Since(pool.beats[addr]) > pool.config.Lifetime {
 					for _, tx := range pool.queue[addr].Flatten() {
 						pool.removeTx(tx.Hash(), true)
+						pool.recordDrop(tx, DropEvicted, errQueuedTooLong)
 					}
 				}
 			}
@@ -481,6 +510,25 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 	log.Info("Transaction pool price threshold updated", "price", price)
 }
 
+// Flush drops every pending and queued transaction from the pool, local ones
+// included, and returns the number of dropped transactions.
+func (pool *TxPool) Flush() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	var txs []*types.Transaction
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
+		txs = append(txs, tx)
+		return true
+	})
+	for _, tx := range txs {
+		pool.removeTx(tx.Hash(), true)
+		pool.recordDrop(tx, DropEvicted, errPoolFlushed)
+	}
+	log.Info("Transaction pool flushed", "dropped", len(txs))
+	return len(txs)
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (pool *TxPool) Nonce(addr common.Address) uint64 {
@@ -633,6 +681,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 	if err := pool.validateTx(tx, local); err != nil {
 		log.Trace("Discarding invalid transaction", "hash", hash, "err", err)
 		invalidTxMeter.Mark(1)
+		pool.recordDrop(tx, dropReason(err), err)
 		return false, err
 	}
 	// If the transaction pool is full, discard underpriced transactions
@@ -641,6 +690,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		if !local && pool.priced.Underpriced(tx, pool.locals) {
 			log.Trace("Discarding underpriced transaction", "hash", hash, "price", tx.GasPrice())
 			underpricedTxMeter.Mark(1)
+			pool.recordDrop(tx, DropUnderpriced, ErrUnderpriced)
 			return false, ErrUnderpriced
 		}
 		// New transaction is better than our worse ones, make room for it
@@ -649,6 +699,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice())
 			underpricedTxMeter.Mark(1)
 			pool.removeTx(tx.Hash(), false)
+			pool.recordDrop(tx, DropUnderpriced, errOutpriced)
 		}
 	}
 	// Try to replace an existing transaction in the pending pool
@@ -658,6 +709,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		inserted, old := list.Add(tx, pool.config.PriceBump)
 		if !inserted {
 			pendingDiscardMeter.Mark(1)
+			pool.recordDrop(tx, DropUnderpriced, ErrReplaceUnderpriced)
 			return false, ErrReplaceUnderpriced
 		}
 		// New transaction is better, replace old one
@@ -665,7 +717,9 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.recordReplaced(old, tx)
 		}
+		pool.dropped.remove(hash)
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.journalTx(from, tx)
@@ -676,8 +730,10 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 	// New transaction isn't replacing a pending one, push into queue
 	replaced, err = pool.enqueueTx(hash, tx)
 	if err != nil {
+		pool.recordDrop(tx, DropUnderpriced, err)
 		return false, err
 	}
+	pool.dropped.remove(hash)
 	// Mark local addresses and journal local transactions
 	if local {
 		if !pool.locals.contains(from) {
@@ -714,6 +770,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.recordReplaced(old, tx)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -755,6 +812,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed(1)
 
 		pendingDiscardMeter.Mark(1)
+		pool.recordDrop(tx, DropUnderpriced, ErrReplaceUnderpriced)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -763,6 +821,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed(1)
 
 		pendingReplaceMeter.Mark(1)
+		pool.recordReplaced(old, tx)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -1233,6 +1292,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 			log.Trace("Removed old queued transaction", "hash", hash)
+			pool.recordDrop(tx, DropNonceTooLow, ErrNonceTooLow)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas)
 		drops, _ := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas)
@@ -1241,6 +1301,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			pool.all.Remove(hash)
 			log.Trace("Removed unpayable queued transaction", "hash", hash)
 		}
+		pool.recordUnexecutables(drops)
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
 		// Gather all executable transactions and promote them
@@ -1262,6 +1323,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 				hash := tx.Hash()
 				pool.all.Remove(hash)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
+				pool.recordDrop(tx, DropEvicted, errAccountQueueFull)
 			}
 			queuedRateLimitMeter.Mark(int64(len(caps)))
 		}
@@ -1326,6 +1388,7 @@ func (pool *TxPool) truncatePending() {
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
 						log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
+						pool.recordDrop(tx, DropEvicted, errPendingFull)
 					}
 					pool.priced.Removed(len(caps))
 					pendingGauge.Dec(int64(len(caps)))
@@ -1353,6 +1416,7 @@ func (pool *TxPool) truncatePending() {
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
 					log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
+					pool.recordDrop(tx, DropEvicted, errPendingFull)
 				}
 				pool.priced.Removed(len(caps))
 				pendingGauge.Dec(int64(len(caps)))
@@ -1396,6 +1460,7 @@ func (pool *TxPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true)
+				pool.recordDrop(tx, DropEvicted, errQueueFull)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1405,6 +1470,7 @@ func (pool *TxPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true)
+			pool.recordDrop(txs[i], DropEvicted, errQueueFull)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1419,7 +1485,8 @@ func (pool *TxPool) demoteUnexecutables() {
 	for addr, list := range pool.pending {
 		nonce := pool.currentState.GetNonce(addr)
 
-		// Drop all transactions that are deemed too old (low nonce)
+		// Drop all transactions that are deemed too old (low nonce), these are mostly
+		// included in the new head so they aren't recorded as dropped
 		olds := list.Forward(nonce)
 		for _, tx := range olds {
 			hash := tx.Hash()
@@ -1433,6 +1500,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
 		}
+		pool.recordUnexecutables(drops)
 		pool.priced.Removed(len(olds) + len(drops))
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
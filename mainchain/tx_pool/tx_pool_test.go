@@ -1 +1,215 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
 package tx_pool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kai/events"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+type testBlockChain struct {
+	statedb       *state.StateDB
+	gasLimit      uint64
+	chainHeadFeed *event.Feed
+}
+
+func (bc *testBlockChain) CurrentBlock() *types.Block {
+	return types.NewBlockWithHeader(&types.Header{GasLimit: bc.gasLimit})
+}
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.CurrentBlock()
+}
+
+func (bc *testBlockChain) StateAt(height uint64) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *testBlockChain) DB() types.StoreDB {
+	return kvstore.NewStoreDB(memorydb.New())
+}
+
+func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- events.ChainHeadEvent) event.Subscription {
+	return bc.chainHeadFeed.Subscribe(ch)
+}
+
+// setupTxPool creates a pool on top of a state funding the accounts of the given keys.
+func setupTxPool(gasLimit uint64, keys ...*ecdsa.PrivateKey) (*TxPool, *state.StateDB) {
+	statedb, _ := state.New(log.New(), common.Hash{}, state.NewDatabase(memorydb.New()))
+	for _, key := range keys {
+		statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	}
+	blockchain := &testBlockChain{statedb, gasLimit, new(event.Feed)}
+
+	config := DefaultTxPoolConfig
+	config.Journal = ""
+	return NewTxPool(config, &types.ChainConfig{}, blockchain), statedb
+}
+
+func pricedTransaction(nonce uint64, gasLimit uint64, gasPrice int64, key *ecdsa.PrivateKey) *types.Transaction {
+	tx, _ := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(nonce, common.Address{}, big.NewInt(100), gasLimit, big.NewInt(gasPrice), nil), key)
+	return tx
+}
+
+func TestProposeTransactionsOrdering(t *testing.T) {
+	// Three accounts, each paying different prices for its transactions, with the
+	// lower nonces of the best paying account paying less than the others.
+	prices := [][]int64{{2, 6, 6}, {4, 4, 4}, {5, 3, 1}}
+	keys := make([]*ecdsa.PrivateKey, len(prices))
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	pool, statedb := setupTxPool(1000000, keys...)
+	defer pool.Stop()
+
+	var txs []*types.Transaction
+	for i, accPrices := range prices {
+		nonce := statedb.GetNonce(crypto.PubkeyToAddress(keys[i].PublicKey))
+		for j, price := range accPrices {
+			txs = append(txs, pricedTransaction(nonce+uint64(j), 21000, price, keys[i]))
+		}
+	}
+	for i, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+
+	proposed := pool.ProposeTransactions(1000000, types.MaxBlockSizeBytes)
+	if len(proposed) != len(txs) {
+		t.Fatalf("proposed transactions mismatch: have %d, want %d", len(proposed), len(txs))
+	}
+	want := []int64{5, 4, 4, 4, 3, 2, 6, 6, 1}
+	for i, tx := range proposed {
+		if tx.GasPrice().Int64() != want[i] {
+			t.Errorf("transaction %d: price mismatch: have %v, want %v", i, tx.GasPrice(), want[i])
+		}
+	}
+	// The same pending set must always be proposed in the same order.
+	again := pool.ProposeTransactions(1000000, types.MaxBlockSizeBytes)
+	for i := range proposed {
+		if proposed[i].Hash() != again[i].Hash() {
+			t.Fatalf("transaction %d: proposal is not deterministic", i)
+		}
+	}
+}
+
+func TestProposeTransactionsLimits(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 2)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	pool, statedb := setupTxPool(1000000, keys...)
+	defer pool.Stop()
+
+	// Both accounts send three transactions, the first one paying more.
+	var txs []*types.Transaction
+	for i, key := range keys {
+		nonce := statedb.GetNonce(crypto.PubkeyToAddress(key.PublicKey))
+		for j := 0; j < 3; j++ {
+			txs = append(txs, pricedTransaction(nonce+uint64(j), 21000, int64(2-i), key))
+		}
+	}
+	for i, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+
+	// The gas limit fits four transactions: all of the best paying account, then the
+	// first one of the other.
+	proposed := pool.ProposeTransactions(4*21000+20999, types.MaxBlockSizeBytes)
+	if len(proposed) != 4 {
+		t.Fatalf("proposed transactions mismatch with gas limit: have %d, want 4", len(proposed))
+	}
+	for i, tx := range proposed {
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("transaction %d mismatch with gas limit", i)
+		}
+	}
+
+	// The size limit fits two transactions.
+	proposed = pool.ProposeTransactions(1000000, txs[0].Size()*2+txs[0].Size()/2)
+	if len(proposed) != 2 {
+		t.Fatalf("proposed transactions mismatch with size limit: have %d, want 2", len(proposed))
+	}
+}
+
+func TestDroppedTransactions(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool, statedb := setupTxPool(1000000, key)
+	defer pool.Stop()
+
+	nonce := statedb.GetNonce(crypto.PubkeyToAddress(key.PublicKey))
+	tx := pricedTransaction(nonce, 21000, 1, key)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	// Replacing the pending transaction records it as replaced
+	replacement := pricedTransaction(nonce, 21000, 2, key)
+	if err := pool.addRemoteSync(replacement); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	if drop := pool.Dropped(tx.Hash()); drop == nil || drop.Reason != DropReplaced || drop.ReplacedBy != replacement.Hash() {
+		t.Errorf("replaced transaction record mismatch: %+v", drop)
+	}
+	if drop := pool.Dropped(replacement.Hash()); drop != nil {
+		t.Errorf("pooled transaction recorded as dropped: %+v", drop)
+	}
+	// Rejected transactions are recorded with the reason of the rejection
+	stale := pricedTransaction(nonce-1, 21000, 1, key)
+	if err := pool.addRemoteSync(stale); err != ErrNonceTooLow {
+		t.Fatalf("stale transaction error mismatch: have %v, want %v", err, ErrNonceTooLow)
+	}
+	if drop := pool.Dropped(stale.Hash()); drop == nil || drop.Reason != DropNonceTooLow {
+		t.Errorf("stale transaction record mismatch: %+v", drop)
+	}
+	// Failures in blocks are recorded with the height of the block
+	pool.MarkFailed(5, types.Transactions{replacement}, []error{ErrInsufficientFunds})
+	if drop := pool.Dropped(replacement.Hash()); drop == nil || drop.Reason != DropFailed || drop.BlockHeight != 5 || drop.Err != ErrInsufficientFunds.Error() {
+		t.Errorf("failed transaction record mismatch: %+v", drop)
+	}
+}
+
+func TestDroppedTransactionsLimit(t *testing.T) {
+	dropped := newDroppedTxs(2)
+	hashes := []common.Hash{{1}, {2}, {3}}
+	for _, hash := range hashes {
+		dropped.add(&DroppedTx{Hash: hash, Reason: DropEvicted})
+	}
+	if dropped.get(hashes[0]) != nil {
+		t.Errorf("oldest dropped transaction not evicted")
+	}
+	for _, hash := range hashes[1:] {
+		if dropped.get(hash) == nil {
+			t.Errorf("dropped transaction %x missing", hash)
+		}
+	}
+}
@@ -19,11 +19,16 @@
 package node
 
 import (
+	"errors"
+	"fmt"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/kardiachain/go-kardia/lib/log"
 	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
 )
 
 // PublicNodeAPI offers helper utils
@@ -80,19 +85,261 @@ func (s *PublicNodeAPI) NodeInfo() *NodeInfo {
 	}
 }
 
-// ConfirmAddPeer adds static peer, this is used by the Kardia network proxy.
-func (s *PublicNodeAPI) ConfirmAddPeer(peerURL string) { //TODO:Make this accessible to only the proxy
-	if err := s.node.AddPeer(peerURL); err != nil {
-		log.Error("ConfirmAddPeer API error", "err", err, "peerURL", peerURL)
+func (s *PublicNodeAPI) CheckFull() bool {
+	return s.node.server.CheckFull()
+}
+
+// adminNamespace is the namespace of the privileged APIs, which are never
+// served on the public HTTP endpoint.
+const adminNamespace = "admin"
+
+// PrivateAdminAPI is the collection of administrative APIs exposed over the
+// private admin endpoint.
+type PrivateAdminAPI struct {
+	node *Node
+}
+
+// NewPrivateAdminAPI creates a new API definition for the private admin methods
+// of the node itself.
+func NewPrivateAdminAPI(node *Node) *PrivateAdminAPI {
+	return &PrivateAdminAPI{node}
+}
+
+// AddPeer requests connecting to a remote node, and also maintaining the new
+// connection at all times, even reconnecting if it is lost.
+func (api *PrivateAdminAPI) AddPeer(url string) (bool, error) {
+	if api.node.Server() == nil {
+		return false, ErrNodeStopped
+	}
+	if err := api.node.AddPeer(url); err != nil {
+		log.Error("AddPeer API error", "err", err, "peerURL", url)
+		return false, err
+	}
+	return true, nil
+}
+
+// ConfirmAddPeer adds a static peer, this is used by the Kardia network proxy.
+func (api *PrivateAdminAPI) ConfirmAddPeer(url string) (bool, error) {
+	return api.AddPeer(url)
+}
+
+// PrivateNodeAPI keeps the peer methods moved from the node module to the admin
+// module, served on the IPC and admin endpoints only.
+//
+// Deprecated: use the admin module.
+type PrivateNodeAPI struct {
+	admin *PrivateAdminAPI
+}
+
+// AddPeer forwards to admin_addPeer.
+//
+// Deprecated: use admin_addPeer.
+func (api *PrivateNodeAPI) AddPeer(url string) (bool, error) {
+	return api.admin.AddPeer(url)
+}
+
+// ConfirmAddPeer forwards to admin_addPeer.
+//
+// Deprecated: use admin_addPeer.
+func (api *PrivateNodeAPI) ConfirmAddPeer(url string) (bool, error) {
+	return api.admin.AddPeer(url)
+}
+
+// RemovePeer disconnects from a remote node if the connection exists and stops
+// maintaining it.
+func (api *PrivateAdminAPI) RemovePeer(url string) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	node, err := discover.ParseNode(url)
+	if err != nil {
+		return false, fmt.Errorf("invalid enode: %v", err)
 	}
+	server.RemovePeer(node)
+	return true, nil
 }
 
-func (s *PublicNodeAPI) AddPeer(peerURL string) {
-	if err := s.node.AddPeer(peerURL); err != nil {
-		log.Error("AddPeer API error", "err", err, "peerURL", peerURL)
+// AddTrustedPeer allows a remote node to always connect, even if slots are full.
+func (api *PrivateAdminAPI) AddTrustedPeer(url string) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
 	}
+	node, err := discover.ParseNode(url)
+	if err != nil {
+		return false, fmt.Errorf("invalid enode: %v", err)
+	}
+	server.AddTrustedPeer(node)
+	return true, nil
 }
 
-func (s *PublicNodeAPI) CheckFull() bool {
-	return s.node.server.CheckFull()
+// RemoveTrustedPeer removes a remote node from the trusted peer set, but it
+// does not disconnect it automatically.
+func (api *PrivateAdminAPI) RemoveTrustedPeer(url string) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	node, err := discover.ParseNode(url)
+	if err != nil {
+		return false, fmt.Errorf("invalid enode: %v", err)
+	}
+	server.RemoveTrustedPeer(node)
+	return true, nil
+}
+
+// TrustedPeers returns the node IDs of all trusted peers.
+func (api *PrivateAdminAPI) TrustedPeers() ([]string, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	ids := make([]string, 0)
+	for _, id := range server.TrustedPeers() {
+		ids = append(ids, id.String())
+	}
+	return ids, nil
+}
+
+// SetLogLevel sets the log level of the given module, or the global log level
+// if module is empty. A module is the tag of a logger, such as a service name.
+// An empty level drops the level of the module, so the global one applies.
+func (api *PrivateAdminAPI) SetLogLevel(module string, level string) (bool, error) {
+	handler, ok := log.Root().GetHandler().(*log.LevelHandler)
+	if !ok {
+		return false, errors.New("log level is not adjustable")
+	}
+	if module != "" && level == "" {
+		handler.ResetModuleLevel(module)
+		return true, nil
+	}
+	lvl, err := log.LvlFromString(level)
+	if err != nil {
+		return false, err
+	}
+	if module == "" {
+		handler.SetLevel(lvl)
+	} else {
+		handler.SetModuleLevel(module, lvl)
+	}
+	return true, nil
+}
+
+// StartRPC starts the HTTP RPC API server. Unspecified parameters default to
+// the node configuration.
+func (api *PrivateAdminAPI) StartRPC(host *string, port *int, cors *string, apis *string, vhosts *string) (bool, error) {
+	api.node.lock.Lock()
+	defer api.node.lock.Unlock()
+
+	if api.node.httpHandler != nil {
+		return false, fmt.Errorf("HTTP RPC already running on %s", api.node.httpEndpoint)
+	}
+	if host == nil {
+		h := DefaultHTTPHost
+		if api.node.config.HTTPHost != "" {
+			h = api.node.config.HTTPHost
+		}
+		host = &h
+	}
+	if port == nil {
+		port = &api.node.config.HTTPPort
+	}
+	allowedOrigins := api.node.config.HTTPCors
+	if cors != nil {
+		allowedOrigins = splitAndTrim(*cors)
+	}
+	allowedVHosts := api.node.config.HTTPVirtualHosts
+	if vhosts != nil {
+		allowedVHosts = splitAndTrim(*vhosts)
+	}
+	modules := api.node.config.HTTPModules
+	if apis != nil {
+		modules = splitAndTrim(*apis)
+	}
+	endpoint := fmt.Sprintf("%s:%d", *host, *port)
+	if err := api.node.startHTTP(endpoint, publicAPIs(api.node.rpcAPIs), modules, allowedOrigins, allowedVHosts); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StopRPC terminates an already running HTTP RPC API endpoint.
+func (api *PrivateAdminAPI) StopRPC() (bool, error) {
+	api.node.lock.Lock()
+	defer api.node.lock.Unlock()
+
+	if api.node.httpHandler == nil {
+		return false, errors.New("HTTP RPC not running")
+	}
+	api.node.stopHTTP()
+	return true, nil
+}
+
+// splitAndTrim splits a comma separated list and trims the spaces of every
+// element, dropping the empty ones.
+func splitAndTrim(input string) []string {
+	result := make([]string, 0)
+	for _, r := range strings.Split(input, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// PeerScores returns the reputation of every peer that misbehaved recently or
+// is currently banned.
+func (api *PrivateAdminAPI) PeerScores() ([]*p2p.PeerScore, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerScores(), nil
+}
+
+// BanPeer disconnects the given peer and refuses to connect with it for the
+// given number of seconds. Zero bans the peer for the configured ban duration.
+// The peer is identified either by its node ID or by its enode URL.
+func (api *PrivateAdminAPI) BanPeer(id string, seconds uint64) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	nodeID, err := parseNodeID(id)
+	if err != nil {
+		return false, err
+	}
+	if err := server.BanPeer(nodeID, time.Duration(seconds)*time.Second); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnbanPeer lifts the ban of the given peer and clears its penalty.
+func (api *PrivateAdminAPI) UnbanPeer(id string) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	nodeID, err := parseNodeID(id)
+	if err != nil {
+		return false, err
+	}
+	if err := server.UnbanPeer(nodeID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// parseNodeID parses either a hex encoded node ID or an enode URL.
+func parseNodeID(id string) (discover.NodeID, error) {
+	if strings.HasPrefix(id, "enode://") {
+		node, err := discover.ParseNode(id)
+		if err != nil {
+			return discover.NodeID{}, err
+		}
+		return node.ID, nil
+	}
+	return discover.HexID(id)
 }
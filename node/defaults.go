@@ -26,11 +26,13 @@ import (
 
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/lib/p2p/nat"
+	"github.com/kardiachain/go-kardia/rpc"
 )
 
 const (
-	DefaultHTTPHost = "0.0.0.0" // Default host interface for the HTTP RPC server
-	DefaultHTTPPort = 8545      // Default TCP port for the HTTP RPC server
+	DefaultHTTPHost = "0.0.0.0"    // Default host interface for the HTTP RPC server
+	DefaultHTTPPort = 8545         // Default TCP port for the HTTP RPC server
+	DefaultIPCPath  = "kardia.ipc" // Default file name of the IPC endpoint, in the instance directory
 
 	DefaultDbCache   = 16 // 16MB memory allocated for leveldb cache, for each chains
 	DefaultDbHandles = 32 // 32 file handlers allocated for leveldb, for each chains
@@ -41,25 +43,30 @@ const (
 	DefaultNetworkID  = 100
 	MainChainID = 1
 	KardiaServiceName = "KARDIA"
+
+	DefaultLogsBlockRange = 10000 // maximum number of blocks a kai_getLogs query may span
 )
 
 // DefaultConfig contains reasonable default settings.
 var DefaultConfig = NodeConfig{
 	DataDir:          DefaultDataDir(),
+	IPCPath:          DefaultIPCPath,
 	HTTPPort:         DefaultHTTPPort,
 	HTTPModules:      []string{"node", "kai", "tx", "account", "dual", "neo"},
 	HTTPVirtualHosts: []string{"0.0.0.0", "localhost"},
 	HTTPCors:         []string{"*"},
+	HTTPLimits:       rpc.DefaultLimits,
 	P2P: p2p.Config{
 		ListenAddr: ":30303",
 		MaxPeers:   25,
 		NAT:        nat.Any(),
 	},
 	MainChainConfig: MainChainConfig{
-		ServiceName:  KardiaServiceName,
-		ChainId:      MainChainID,
-		NetworkId:    DefaultNetworkID,
-		AcceptTxs:    1, // 1 is to allow new transactions, 0 is not
+		ServiceName:    KardiaServiceName,
+		ChainId:        MainChainID,
+		NetworkId:      DefaultNetworkID,
+		AcceptTxs:      1, // 1 is to allow new transactions, 0 is not
+		LogsBlockRange: DefaultLogsBlockRange,
 	},
 	DualChainConfig: DualChainConfig{
 		DBInfo: storage.NewLevelDbInfo(DualChainDataDir, DefaultDbCache, DefaultDbHandles),
@@ -50,6 +50,14 @@ type Node struct {
 	httpListener  net.Listener // HTTP RPC listener socket to server API requests
 	httpHandler   *rpc.Server  // HTTP RPC request handler to process the API requests
 
+	ipcEndpoint string       // IPC endpoint to listen at (empty = IPC disabled)
+	ipcListener net.Listener // IPC RPC listener socket to serve API requests
+	ipcHandler  *rpc.Server  // IPC RPC request handler to process the API requests
+
+	adminEndpoint string       // Admin endpoint (interface + port) to listen at (empty = admin disabled)
+	adminListener net.Listener // Admin RPC listener socket to server API requests
+	adminHandler  *rpc.Server  // Admin RPC request handler to process the API requests
+
 	lock sync.RWMutex
 	log  log.Logger
 }
@@ -75,6 +83,8 @@ func (n *Node) Start() error {
 
 	// RPC Endpoint
 	n.httpEndpoint = n.config.HTTPEndpoint()
+	n.adminEndpoint = n.config.AdminEndpoint()
+	n.ipcEndpoint = n.config.IPCEndpoint()
 
 	// Generate node PrivKey
 	n.serverConfig = n.config.P2P
@@ -170,6 +180,9 @@ func (n *Node) Stop() error {
 		}
 	}
 
+	n.stopHTTP()
+	n.stopAdmin()
+	n.stopIPC()
 	n.server.Stop()
 	n.services = nil
 	n.server = nil
@@ -193,7 +206,16 @@ func (n *Node) startRPC(services map[string]Service) error {
 		apis = append(apis, service.APIs()...)
 	}
 
-	if err := n.startHTTP(n.httpEndpoint, apis, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts); err != nil {
+	if err := n.startIPC(apis); err != nil {
+		return err
+	}
+	if err := n.startHTTP(n.httpEndpoint, publicAPIs(apis), n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts); err != nil {
+		n.stopIPC()
+		return err
+	}
+	if err := n.startAdmin(n.adminEndpoint, apis); err != nil {
+		n.stopHTTP()
+		n.stopIPC()
 		return err
 	}
 
@@ -201,16 +223,52 @@ func (n *Node) startRPC(services map[string]Service) error {
 	return nil
 }
 
+// startIPC initializes and starts the IPC RPC endpoint, serving every module.
+func (n *Node) startIPC(apis []rpc.API) error {
+	if n.ipcEndpoint == "" {
+		return nil
+	}
+	listener, handler, err := rpc.StartIPCEndpoint(n.ipcEndpoint, apis)
+	if err != nil {
+		return err
+	}
+	n.log.Info("IPC endpoint opened", "url", n.ipcEndpoint)
+
+	n.ipcListener = listener
+	n.ipcHandler = handler
+
+	return nil
+}
+
+// stopIPC terminates the IPC RPC endpoint.
+func (n *Node) stopIPC() {
+	if n.ipcListener != nil {
+		n.ipcListener.Close()
+		n.ipcListener = nil
+
+		n.log.Info("IPC endpoint closed", "url", n.ipcEndpoint)
+	}
+	if n.ipcHandler != nil {
+		n.ipcHandler.Stop()
+		n.ipcHandler = nil
+	}
+}
+
+// IPCEndpoint retrieves the current IPC endpoint used by the protocol stack.
+func (n *Node) IPCEndpoint() string {
+	return n.ipcEndpoint
+}
+
 // startHTTP initializes and starts the HTTP RPC endpoint.
 func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors []string, vhosts []string) error {
 	if endpoint == "" {
 		return nil
 	}
-	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts)
+	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, n.config.HTTPLimits, n.config.HTTPAuth)
 	if err != nil {
 		return err
 	}
-	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","))
+	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","), "auth", n.config.HTTPAuth != nil)
 
 	n.httpEndpoint = endpoint
 	n.httpListener = listener
@@ -233,6 +291,77 @@ func (n *Node) stopHTTP() {
 	}
 }
 
+// startAdmin initializes and starts the admin RPC endpoint, serving the admin
+// module only. Outside of a loopback interface the host header can't be trusted,
+// so the clients must authenticate with the AdminAuth credentials.
+func (n *Node) startAdmin(endpoint string, apis []rpc.API) error {
+	if endpoint == "" {
+		return nil
+	}
+	vhosts := []string{"localhost"}
+	if !n.config.adminLoopback() {
+		if n.config.AdminAuth == nil {
+			return fmt.Errorf("admin endpoint on %s requires AdminAuth", n.config.AdminHost)
+		}
+		vhosts = n.config.HTTPVirtualHosts
+	}
+	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, privilegedAPIs(apis), []string{adminNamespace, "node"}, nil, vhosts, n.config.HTTPLimits, n.config.AdminAuth)
+	if err != nil {
+		return err
+	}
+	n.log.Info("Admin endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "vhosts", strings.Join(vhosts, ","), "auth", n.config.AdminAuth != nil)
+
+	n.adminEndpoint = endpoint
+	n.adminListener = listener
+	n.adminHandler = handler
+
+	return nil
+}
+
+// stopAdmin terminates the admin RPC endpoint.
+func (n *Node) stopAdmin() {
+	if n.adminListener != nil {
+		n.adminListener.Close()
+		n.adminListener = nil
+
+		n.log.Info("Admin endpoint closed", "url", fmt.Sprintf("http://%s", n.adminEndpoint))
+	}
+	if n.adminHandler != nil {
+		n.adminHandler.Stop()
+		n.adminHandler = nil
+	}
+}
+
+// publicAPIs filters out the privileged APIs, which are only ever served on the
+// IPC and admin endpoints.
+func publicAPIs(apis []rpc.API) []rpc.API {
+	var filtered []rpc.API
+	for _, api := range apis {
+		if !privileged(api) {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
+// privilegedAPIs keeps the privileged APIs only, served on the admin endpoint.
+func privilegedAPIs(apis []rpc.API) []rpc.API {
+	var filtered []rpc.API
+	for _, api := range apis {
+		if privileged(api) {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
+// privileged reports whether the API is the admin module or one of the
+// deprecated admin methods of the node module.
+func privileged(api rpc.API) bool {
+	_, deprecated := api.Service.(*PrivateNodeAPI)
+	return api.Namespace == adminNamespace || deprecated
+}
+
 // Server returns p2p server of node.
 func (n *Node) Server() *p2p.Server {
 	n.lock.RLock()
@@ -348,6 +477,18 @@ func (n *Node) apis() []rpc.API {
 			Service:   NewPublicNodeAPI(n),
 			Public:    true,
 		},
+		{
+			Namespace: adminNamespace,
+			Version:   "1.0",
+			Service:   NewPrivateAdminAPI(n),
+			Public:    false,
+		},
+		{
+			Namespace: "node",
+			Version:   "1.0",
+			Service:   &PrivateNodeAPI{NewPrivateAdminAPI(n)},
+			Public:    false,
+		},
 	}
 }
 
@@ -390,6 +531,11 @@ func (n *Node) CallProxy(method string, reqNode, targetNode *discover.Node) erro
 		TCP: reqNode.TCP,
 		RPC: uint16(n.config.HTTPPort),
 	}
+	// The proxy confirms peers through the admin module, which is served on
+	// the admin endpoint if any.
+	if n.config.AdminHost != "" {
+		request.ReqNode.RPC = uint16(n.config.AdminPort)
+	}
 
 	request.TargetNode = &proxyNode{}
 	if targetNode != nil {
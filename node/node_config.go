@@ -23,12 +23,14 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/kardiachain/go-kardia/mainchain/permissioned"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/kardiachain/go-kardia/configs"
 	"github.com/kardiachain/go-kardia/dualchain/event_pool"
 	"github.com/kardiachain/go-kardia/kai/base"
 	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
@@ -40,6 +42,7 @@ import (
 	"github.com/kardiachain/go-kardia/lib/p2p"
 	"github.com/kardiachain/go-kardia/mainchain/genesis"
 	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
 )
 
@@ -62,6 +65,10 @@ type MainChainConfig struct {
 	AcceptTxs uint32
 	// IsZeroFee is true then sender will be refunded all gas spent for a transaction
 	IsZeroFee bool
+	// AddressIndex is true then transactions are indexed by the addresses they involve
+	AddressIndex bool
+	// LogsBlockRange is the maximum number of blocks a kai_getLogs query may span, 0 for no limit
+	LogsBlockRange uint64
 	// IsPrivate is true then peerId will be checked through smc to make sure that it has permission to access the chain
 	IsPrivate bool
 	NetworkId uint64
@@ -70,6 +77,8 @@ type MainChainConfig struct {
 	ServiceName string
 	// BaseAccount defines account which is used to execute internal smart contracts
 	BaseAccount *types.BaseAccount
+	// Consensus holds the consensus timing and empty-block policy (nil = defaults)
+	Consensus *configs.ConsensusConfig
 }
 
 type DualChainConfig struct {
@@ -91,6 +100,8 @@ type DualChainConfig struct {
 	BaseAccount *types.BaseAccount
 	// Dual Network ID
 	DualNetworkID uint64
+	// Consensus holds the consensus timing and empty-block policy (nil = defaults)
+	Consensus *configs.ConsensusConfig
 }
 
 type NodeConfig struct {
@@ -111,6 +122,10 @@ type NodeConfig struct {
 	DataDir string
 	// Configuration of peer-to-peer networking.
 	P2P p2p.Config
+	// IPCPath is the requested location to place the IPC endpoint, serving every
+	// module including admin. If the path is a simple file name, it is placed
+	// inside the instance directory. If the path is empty, IPC is disabled.
+	IPCPath string `toml:",omitempty"`
 	// HTTPHost is the host interface on which to start the HTTP RPC server. If this
 	// field is empty, no HTTP API endpoint will be started.
 	HTTPHost string `toml:",omitempty"`
@@ -134,6 +149,24 @@ type NodeConfig struct {
 	// If the module list is empty, all RPC API endpoints designated public will be
 	// exposed.
 	HTTPModules []string `toml:",omitempty"`
+	// HTTPLimits bounds the batch sizes, the execution time and the request rate
+	// of the clients of the HTTP RPC endpoint.
+	HTTPLimits rpc.Limits `toml:",omitempty"`
+	// HTTPAuth requires the clients of the HTTP RPC endpoint to authenticate with
+	// a JWT token or an API key, restricting each of them to the modules of its
+	// credentials. If it is nil, every exposed module is served to everyone.
+	HTTPAuth *rpc.AuthConfig `toml:",omitempty"`
+	// AdminHost is the host interface on which to start the admin RPC server,
+	// serving the privileged admin module only. If this field is empty, no admin
+	// endpoint will be started. The admin module is never served on the HTTP
+	// endpoint, whatever HTTPModules is.
+	AdminHost string `toml:",omitempty"`
+	// AdminPort is the TCP port number on which to start the admin RPC server.
+	AdminPort int `toml:",omitempty"`
+	// AdminAuth requires the clients of the admin RPC endpoint to authenticate with
+	// a JWT token or an API key granting the admin module. It is required when
+	// AdminHost is not a loopback interface.
+	AdminAuth *rpc.AuthConfig `toml:",omitempty"`
 	// KeyStoreDir is the file system folder that contains private keys. The directory can
 	// be specified as a relative path, in which case it is resolved relative to the
 	// current directory.
@@ -243,6 +276,41 @@ func (c *NodeConfig) HTTPEndpoint() string {
 	return fmt.Sprintf("%s:%d", c.HTTPHost, c.HTTPPort)
 }
 
+// IPCEndpoint resolves an IPC endpoint based on the configured path, placing
+// simple file names inside the instance directory, or the temporary directory
+// for nodes without data directory.
+func (c *NodeConfig) IPCEndpoint() string {
+	if c.IPCPath == "" {
+		return ""
+	}
+	if filepath.Base(c.IPCPath) == c.IPCPath {
+		if c.DataDir == "" {
+			return filepath.Join(os.TempDir(), c.IPCPath)
+		}
+		return filepath.Join(c.instanceDir(), c.IPCPath)
+	}
+	return c.IPCPath
+}
+
+// adminLoopback returns whether the admin endpoint only listens on a loopback
+// interface.
+func (c *NodeConfig) adminLoopback() bool {
+	if c.AdminHost == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(c.AdminHost)
+	return ip != nil && ip.IsLoopback()
+}
+
+// AdminEndpoint resolves an admin endpoint based on the configured host interface
+// and port parameters.
+func (c *NodeConfig) AdminEndpoint() string {
+	if c.AdminHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.AdminHost, c.AdminPort)
+}
+
 // DefaultHTTPEndpoint returns the HTTP endpoint used by default.
 func DefaultHTTPEndpoint() string {
 	config := &NodeConfig{HTTPHost: DefaultHTTPHost, HTTPPort: DefaultHTTPPort}
@@ -18,7 +18,11 @@
 
 package node
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 
 var nodeIndexTests = []struct {
@@ -56,3 +60,20 @@ func TestNodeMetadata_NodeID(t *testing.T) {
 		t.Fatal("NodeID does not match")
 	}
 }
+
+func TestIPCEndpoint(t *testing.T) {
+	tests := []struct {
+		config   NodeConfig
+		expected string
+	}{
+		{NodeConfig{Name: "node1", DataDir: "/data"}, ""},
+		{NodeConfig{Name: "node1", DataDir: "/data", IPCPath: "kardia.ipc"}, filepath.Join("/data", "node1", "kardia.ipc")},
+		{NodeConfig{Name: "node1", IPCPath: "kardia.ipc"}, filepath.Join(os.TempDir(), "kardia.ipc")},
+		{NodeConfig{Name: "node1", DataDir: "/data", IPCPath: "/run/kardia.ipc"}, "/run/kardia.ipc"},
+	}
+	for i, test := range tests {
+		if endpoint := test.config.IPCEndpoint(); endpoint != test.expected {
+			t.Errorf("test %d: IPC endpoint mismatch: have %s, want %s", i, endpoint, test.expected)
+		}
+	}
+}
@@ -19,6 +19,10 @@
 package node
 
 import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/kardiachain/go-kardia/lib/crypto"
@@ -147,3 +151,133 @@ func TestNodeRegisteringService(t *testing.T) {
 		t.Fatalf("TrivialService didn't run Stop()")
 	}
 }
+
+// Tests that the admin module is never served on the public HTTP endpoint.
+func TestPublicAPIsExcludeAdmin(t *testing.T) {
+	node, err := NewNode(testNodeConfig())
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	apis := publicAPIs(node.apis())
+	if len(apis) == 0 {
+		t.Fatal("public node APIs are missing")
+	}
+	for _, api := range apis {
+		if _, deprecated := api.Service.(*PrivateNodeAPI); api.Namespace == adminNamespace || deprecated {
+			t.Fatalf("admin API %T served publicly", api.Service)
+		}
+	}
+}
+
+// Tests that the deprecated peer methods of the node module are served on the
+// admin endpoint only.
+func TestDeprecatedNodeAPI(t *testing.T) {
+	config := testNodeConfig()
+	config.HTTPHost, config.AdminHost = "127.0.0.1", "127.0.0.1"
+	node, err := NewNode(config)
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	if err := node.startHTTP(config.HTTPEndpoint(), publicAPIs(node.apis()), []string{"node"}, nil, nil); err != nil {
+		t.Fatalf("failed to start the HTTP endpoint: %v", err)
+	}
+	defer node.stopHTTP()
+	if err := node.startAdmin(config.AdminEndpoint(), node.apis()); err != nil {
+		t.Fatalf("failed to start the admin endpoint: %v", err)
+	}
+	defer node.stopAdmin()
+
+	call := func(listener net.Listener, method, params string) int {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+		resp, err := http.Post("http://"+listener.Addr().String(), "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var res struct {
+			Error *struct{ Code int }
+		}
+		json.NewDecoder(resp.Body).Decode(&res)
+		if res.Error == nil {
+			return 0
+		}
+		return res.Error.Code
+	}
+	const methodNotFound = -32601
+	for _, method := range []string{"node_addPeer", "node_confirmAddPeer"} {
+		if code := call(node.httpListener, method, `["invalid"]`); code != methodNotFound {
+			t.Errorf("%s on the HTTP endpoint: error code %d, want %d", method, code, methodNotFound)
+		}
+		if code := call(node.adminListener, method, `["invalid"]`); code == methodNotFound {
+			t.Errorf("%s not served on the admin endpoint", method)
+		}
+	}
+	if code := call(node.httpListener, "node_nodeName", "[]"); code != 0 {
+		t.Errorf("node_nodeName on the HTTP endpoint: error code %d", code)
+	}
+}
+
+// Tests that the admin endpoint requires authentication outside of a loopback interface.
+func TestAdminEndpointAuth(t *testing.T) {
+	config := testNodeConfig()
+	config.AdminHost = "0.0.0.0"
+	node, err := NewNode(config)
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	if err := node.startAdmin(config.AdminEndpoint(), node.apis()); err == nil {
+		node.stopAdmin()
+		t.Fatal("admin endpoint started on all interfaces without AdminAuth")
+	}
+
+	config.AdminHost = "127.0.0.1"
+	if err := node.startAdmin(config.AdminEndpoint(), node.apis()); err != nil {
+		t.Fatalf("failed to start the loopback admin endpoint: %v", err)
+	}
+	node.stopAdmin()
+
+	config.AdminHost = "0.0.0.0"
+	config.AdminAuth = &rpc.AuthConfig{APIKeys: map[string][]string{"secret": {adminNamespace}}}
+	if err := node.startAdmin(config.AdminEndpoint(), node.apis()); err != nil {
+		t.Fatalf("failed to start the authenticated admin endpoint: %v", err)
+	}
+	defer node.stopAdmin()
+
+	url := "http://" + node.adminListener.Addr().String()
+	body := `{"jsonrpc":"2.0","id":1,"method":"admin_removePeer","params":[""]}`
+	for _, tt := range []struct {
+		key          string
+		status       int
+		unauthorized bool
+	}{
+		{"", http.StatusOK, true},
+		{"wrong", http.StatusUnauthorized, false},
+		{"secret", http.StatusOK, false},
+	} {
+		req, _ := http.NewRequest("POST", url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if tt.key != "" {
+			req.Header.Set("X-Api-Key", tt.key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var res struct {
+			Error *struct{ Code int }
+		}
+		json.NewDecoder(resp.Body).Decode(&res)
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("API key %q: status %d, want %d", tt.key, resp.StatusCode, tt.status)
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		// An authorized call may fail, but not for lacking credentials.
+		unauthorized := res.Error != nil && res.Error.Code == -32001
+		if unauthorized != tt.unauthorized {
+			t.Errorf("API key %q: response %+v, unauthorized %v", tt.key, res.Error, tt.unauthorized)
+		}
+	}
+}
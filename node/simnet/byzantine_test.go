@@ -0,0 +1,147 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simnet
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/consensus"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// runClock advances the given clock quickly until the returned function is
+// called, so simulated timeouts expire in a fraction of their duration.
+func runClock(clock *consensus.SimClock) (stop func()) {
+	quit, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-quit:
+				return
+			case <-time.After(time.Millisecond):
+				clock.Advance(20 * time.Millisecond)
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		<-done
+	}
+}
+
+func TestEquivocationEvidence(t *testing.T) {
+	nw := newTestNetwork(t, Config{
+		Byzantine: map[int]*consensus.ByzantineConfig{2: {EquivocateHeight: 3}},
+	})
+	defer nw.Stop()
+
+	// Every honest validator must catch the double votes of node3
+	err := nw.waitFor(testTimeout, func() error {
+		for _, n := range nw.Nodes()[:2] {
+			var found bool
+			for _, ev := range n.Kardia().ConsensusManager().Evidence() {
+				dve, ok := ev.(*types.DuplicateVoteEvidence)
+				if !ok || dve.Address() != nw.Node(2).Address() {
+					return fmt.Errorf("%s has unexpected evidence %v", n.Name(), ev)
+				}
+				if !dve.Height().EqualsInt(3) {
+					return fmt.Errorf("%s has evidence of height %v, want 3", n.Name(), dve.Height())
+				}
+				found = true
+			}
+			if !found {
+				return fmt.Errorf("%s has no evidence", n.Name())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSilentRounds(t *testing.T) {
+	// Every proposer withholds its proposal in rounds 0 and 1 of height 3, so
+	// both rounds must time out and the block be committed in round 2
+	silent := &consensus.ByzantineConfig{SilentHeight: 3, SilentFromRound: 0, SilentToRound: 1}
+	clock := consensus.NewSimClock(time.Now())
+	nw := newTestNetwork(t, Config{
+		Clock:     clock,
+		Byzantine: map[int]*consensus.ByzantineConfig{0: silent, 1: silent, 2: silent},
+	})
+	defer nw.Stop()
+	defer runClock(clock)()
+
+	if err := nw.WaitForHeight(5, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range nw.Nodes() {
+		if round := n.db.StoreDB().ReadCommit(3).Round(); !round.EqualsInt(2) {
+			t.Errorf("%s committed height 3 in round %v, want 2", n.Name(), round)
+		}
+		if round := n.db.StoreDB().ReadCommit(4).Round(); !round.EqualsInt(0) {
+			t.Errorf("%s committed height 4 in round %v, want 0", n.Name(), round)
+		}
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSilentVotes(t *testing.T) {
+	clock := consensus.NewSimClock(time.Now())
+	nw := newTestNetwork(t, Config{
+		Clock:     clock,
+		Byzantine: map[int]*consensus.ByzantineConfig{0: {SilentHeight: 3, SilentToRound: 100, SilentVotes: true}},
+	})
+	defer nw.Stop()
+	defer runClock(clock)()
+
+	// All three validators are needed for a quorum, so no block may be
+	// committed while node1 withholds its votes
+	if err := nw.WaitForHeight(2, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second)
+	for _, n := range nw.Nodes() {
+		if height := n.Height(); height != 2 {
+			t.Errorf("%s at height %d, want 2", n.Name(), height)
+		}
+	}
+}
+
+func TestGossipDelay(t *testing.T) {
+	nw := newTestNetwork(t, Config{
+		Byzantine: map[int]*consensus.ByzantineConfig{1: {GossipDelay: 20 * time.Millisecond}},
+	})
+	defer nw.Stop()
+
+	if err := nw.WaitForHeight(5, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simnet
+
+import (
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/storage/kvstore"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// nodeDB implements storage.DbInfo to start a node on an in-memory database,
+// which outlives the node so that it can be restarted on its chain.
+type nodeDB struct {
+	db *memorydb.Database
+}
+
+func newNodeDB() *nodeDB {
+	return &nodeDB{db: memorydb.New()}
+}
+
+// Name implements storage.DbInfo.
+func (db *nodeDB) Name() string {
+	return "Memory"
+}
+
+// Start implements storage.DbInfo, returning a store on the node's database.
+func (db *nodeDB) Start() (types.StoreDB, error) {
+	return db.StoreDB(), nil
+}
+
+// StoreDB returns a store on the node's database. Closing the store leaves the
+// database open.
+func (db *nodeDB) StoreDB() types.StoreDB {
+	return kvstore.NewStoreDB(unclosable{db.db})
+}
+
+// unclosable is a database ignoring Close.
+type unclosable struct {
+	kaidb.Database
+}
+
+func (unclosable) Close() error { return nil }
@@ -0,0 +1,206 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simnet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+)
+
+func TestBlockRewards(t *testing.T) {
+	nw := newTestNetwork(t, Config{})
+	defer nw.Stop()
+
+	const height = 3
+	if err := nw.WaitForHeight(height+1, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	nodeReward := new(big.Int).Div(blockReward, big.NewInt(2))
+	for _, n := range nw.Nodes() {
+		bc := n.Kardia().BlockChain()
+		// Every block pays half of the reward to the node of its proposer
+		st, err := bc.StateAt(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total := new(big.Int)
+		for _, node := range genesisNodes {
+			total.Add(total, st.GetBalance(common.HexToAddress(node)))
+		}
+		if want := new(big.Int).Mul(nodeReward, big.NewInt(height)); total.Cmp(want) != 0 {
+			t.Errorf("%s: nodes rewarded %v after block %d, want %v", n.Name(), total, height, want)
+		}
+
+		// and logs it in a receipt of no transaction
+		block := bc.GetBlockByHeight(height)
+		var proposer common.Address
+		for i, other := range nw.Nodes() {
+			if other.Address() == block.Header().Validator {
+				proposer = common.HexToAddress(genesisNodes[i])
+			}
+		}
+		receipts := n.db.StoreDB().ReadReceipts(block.Hash(), height)
+		if len(receipts) != 1 || len(receipts[0].Logs) == 0 {
+			t.Fatalf("%s: block %d has receipts %v, want the system receipt", n.Name(), height, receipts)
+		}
+		if log := receipts[0].Logs[0]; log.Topics[1] != proposer.Hash() || new(big.Int).SetBytes(log.Data).Cmp(nodeReward) != 0 {
+			t.Errorf("%s: block %d logs reward %x to %x, want %v to %x", n.Name(), height, log.Data, log.Topics[1], nodeReward, proposer)
+		}
+	}
+}
+
+func TestConsensusPeriod(t *testing.T) {
+	nw := newTestNetwork(t, Config{ConsensusPeriod: 20})
+	defer nw.Stop()
+
+	// The validators of the second period are collected by block 10 and take
+	// over after block 20
+	if err := nw.WaitForHeight(25, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range nw.Nodes() {
+		vals, err := kvm.CollectValidatorSet(n.Kardia().BlockChain())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals.StartHeight != 21 || vals.EndHeight != 41 || vals.Size() != len(nw.Nodes()) {
+			t.Errorf("%s: validators %v, want the 3 validators of blocks 21 to 41", n.Name(), vals)
+		}
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests that the PoS contracts keep working once the precompiled contracts of
+// PrecompiledContractsV1 are available.
+func TestPrecompilesV1(t *testing.T) {
+	activation := uint64(5)
+	nw := newTestNetwork(t, Config{ConsensusPeriod: 20, PrecompilesV1Height: &activation})
+	defer nw.Stop()
+
+	// The validators of the second period are collected by the master contract
+	// after the activation
+	if err := nw.WaitForHeight(25, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	nodeReward := new(big.Int).Div(blockReward, big.NewInt(2))
+	for _, n := range nw.Nodes() {
+		bc := n.Kardia().BlockChain()
+		if !bc.Config().IsPrecompilesV1(bc.CurrentBlock().Height()) {
+			t.Fatalf("%s: precompiles not active at block %d", n.Name(), bc.CurrentBlock().Height())
+		}
+		vals, err := kvm.CollectValidatorSet(bc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals.StartHeight != 21 || vals.EndHeight != 41 || vals.Size() != len(nw.Nodes()) {
+			t.Errorf("%s: validators %v, want the 3 validators of blocks 21 to 41", n.Name(), vals)
+		}
+		// Every block keeps rewarding its proposer
+		st, err := bc.StateAt(20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total := new(big.Int)
+		for _, node := range genesisNodes {
+			total.Add(total, st.GetBalance(common.HexToAddress(node)))
+		}
+		if want := new(big.Int).Mul(nodeReward, big.NewInt(20)); total.Cmp(want) != 0 {
+			t.Errorf("%s: nodes rewarded %v after block 20, want %v", n.Name(), total, want)
+		}
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStakingAPI(t *testing.T) {
+	nw := newTestNetwork(t, Config{})
+	defer nw.Stop()
+
+	if err := nw.WaitForHeight(2, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	n := nw.Nodes()[0]
+	api := kai.NewPublicStakingAPI(n.Kardia())
+
+	vals, err := api.Validators()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vals.Validators) != len(nw.Nodes()) {
+		t.Fatalf("validators %v, want %d", vals.Validators, len(nw.Nodes()))
+	}
+	for _, val := range vals.Validators {
+		if val.Node == "" || val.Stakes != stakeAmount.String() {
+			t.Errorf("validator %+v, want a genesis node staking %v", val, stakeAmount)
+		}
+	}
+
+	nodes, err := api.Nodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != len(genesisNodes) {
+		t.Fatalf("nodes %v, want the %d genesis nodes", nodes, len(genesisNodes))
+	}
+	for i, address := range genesisNodes {
+		node, err := api.Node(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node == nil || node.Owner != common.HexToAddress(genesisOwners[i]).Hex() || node.Validator != nw.Nodes()[i].Address().Hex() {
+			t.Fatalf("node %s is %+v, want the node of %s", address, node, nw.Nodes()[i].Name())
+		}
+		if len(node.Stakers) != 1 || node.Stakers[0].Address != common.HexToAddress(genesisStakers[i]).Hex() || node.Stakers[0].Amount != stakeAmount.String() {
+			t.Errorf("node %s has stakers %+v, want %s staking %v", address, node.Stakers, genesisStakers[i], stakeAmount)
+		}
+
+		delegations, err := api.Delegations(genesisOwners[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(delegations) != 1 || delegations[0].Node != node.Address || delegations[0].Amount != stakeAmount.String() {
+			t.Errorf("owner %s has delegations %+v, want %v in %s", genesisOwners[i], delegations, stakeAmount, address)
+		}
+	}
+	if node, err := api.Node(genesisStakers[0]); err != nil || node != nil {
+		t.Errorf("node %s is %+v, %v, want none", genesisStakers[0], node, err)
+	}
+
+	pending, err := api.PendingNodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending nodes %+v, want none", pending)
+	}
+	requests, err := api.DeleteRequests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("delete requests %+v, want none", requests)
+	}
+}
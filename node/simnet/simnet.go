@@ -0,0 +1,641 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package simnet runs networks of Kardia validator nodes in a single process for
+// end-to-end tests. Every node keeps its chain in memory and talks to its peers
+// over in-memory pipes instead of TCP, so tests can partition the network, crash
+// and restart validators and submit transactions without any external setup.
+package simnet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/consensus"
+	"github.com/kardiachain/go-kardia/kai/pos"
+	smc "github.com/kardiachain/go-kardia/kvm/smc"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/p2p"
+	"github.com/kardiachain/go-kardia/lib/p2p/discover"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/node"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	// pollInterval is the interval of checking the heights of the nodes.
+	pollInterval = 20 * time.Millisecond
+
+	networkID = 100
+	chainID   = 1
+)
+
+var (
+	errNetworkStopped = errors.New("network stopped")
+	errNodeStopped    = errors.New("node stopped")
+	errNodeRunning    = errors.New("node already running")
+)
+
+// Genesis PoS contracts of the network. The master contract only knows the
+// genesis nodes and stakers at these addresses, owned by genesisOwners, which
+// caps the number of validators to three.
+var (
+	masterAddress  = common.HexToAddress("0x0000000000000000000000000000000000000009")
+	genesisNodes   = []string{"0x0000000000000000000000000000000000000010", "0x0000000000000000000000000000000000000011", "0x0000000000000000000000000000000000000012"}
+	genesisStakers = []string{"0x0000000000000000000000000000000000000020", "0x0000000000000000000000000000000000000021", "0x0000000000000000000000000000000000000022"}
+	genesisOwners  = []string{"0xc1fe56E3F58D3244F606306611a5d10c8333f1f6", "0x7cefC13B6E2aedEeDFB7Cb6c32457240746BAEe5", "0xfF3dac4f04dDbD24dE5D6039F90596F0a8bb08fd"}
+	maxValidators  = len(genesisNodes)
+
+	// genesisBalance is the balance of every genesis account.
+	genesisBalance = genesis.ToCell(1000000000)
+	// stakeAmount is the genesis stake of every validator.
+	stakeAmount = genesis.ToCell(2000000)
+	// blockReward is paid for every block, half to the node of its proposer and
+	// half to the stakers of that node.
+	blockReward = big.NewInt(1000000000000000)
+)
+
+// Config holds Network options.
+type Config struct {
+	Validators int                      // number of validator nodes, at most 3 (default 3)
+	Observers  int                      // number of non-validating nodes
+	Consensus  *configs.ConsensusConfig // consensus timing of all nodes (default FastConsensusConfig)
+
+	// ConsensusPeriod is the number of blocks of a consensus period, whose
+	// validators are collected 10 blocks before its end (default 100000000).
+	ConsensusPeriod uint64
+
+	// Clock is the consensus clock of all nodes (default the system clock).
+	// A consensus.SimClock lets tests decide when timeouts expire.
+	Clock consensus.Clock
+
+	// Byzantine holds the faulty behaviors of validators by node index.
+	Byzantine map[int]*consensus.ByzantineConfig
+
+	// PrecompilesV1Height is the height from which the precompiled contracts of
+	// kvm.PrecompiledContractsV1 are available (default never).
+	PrecompilesV1Height *uint64
+}
+
+// FastConsensusConfig returns a consensus config with short timeouts, producing
+// blocks as fast as the nodes agree on them.
+func FastConsensusConfig() *configs.ConsensusConfig {
+	cfg := configs.DefaultConsensusConfig()
+	cfg.TimeoutPropose = 1000 * time.Millisecond
+	cfg.TimeoutProposeDelta = 200 * time.Millisecond
+	cfg.TimeoutPrevote = 200 * time.Millisecond
+	cfg.TimeoutPrevoteDelta = 100 * time.Millisecond
+	cfg.TimeoutPrecommit = 200 * time.Millisecond
+	cfg.TimeoutPrecommitDelta = 100 * time.Millisecond
+	cfg.TimeoutCommit = 100 * time.Millisecond
+	cfg.SkipTimeoutCommit = true
+	cfg.CreateEmptyBlocksInterval = 0
+	cfg.PeerGossipSleepDuration = 10 * time.Millisecond
+	cfg.PeerQueryMaj23SleepDuration = 200 * time.Millisecond
+	return cfg
+}
+
+// Network is a network of in-process nodes. All nodes share a genesis with one
+// genesis validator per validator node; the observers follow the chain only.
+type Network struct {
+	cfg         Config
+	dir         string
+	genesis     *genesis.Genesis
+	baseAccount *types.BaseAccount
+	nodes       []*Node
+
+	lock    sync.Mutex
+	blocked map[[2]int]bool       // pairs of nodes that can't reach each other
+	links   map[[2]int][]net.Conn // connections between running nodes
+	stopped bool
+}
+
+// New creates a network of validator and observer nodes. The nodes are started
+// by Start.
+func New(cfg Config) (*Network, error) {
+	if cfg.Validators == 0 {
+		cfg.Validators = maxValidators
+	}
+	if cfg.Validators > maxValidators {
+		return nil, fmt.Errorf("at most %d validators supported", maxValidators)
+	}
+	if cfg.Consensus == nil {
+		cfg.Consensus = FastConsensusConfig()
+	}
+	if cfg.ConsensusPeriod == 0 {
+		cfg.ConsensusPeriod = 100000000
+	}
+	dir, err := ioutil.TempDir("", "simnet")
+	if err != nil {
+		return nil, err
+	}
+	baseKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	nw := &Network{
+		cfg:         cfg,
+		dir:         dir,
+		baseAccount: &types.BaseAccount{Address: crypto.PubkeyToAddress(baseKey.PublicKey), PrivateKey: *baseKey},
+		blocked:     make(map[[2]int]bool),
+		links:       make(map[[2]int][]net.Conn),
+	}
+	for i := 0; i < cfg.Validators+cfg.Observers; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		nw.nodes = append(nw.nodes, &Node{network: nw, index: i, key: key, db: newNodeDB()})
+	}
+	nw.genesis = nw.makeGenesis()
+	return nw, nil
+}
+
+// makeGenesis creates the genesis of the network, funding the base account, the
+// nodes and the genesis owners and deploying the PoS contracts with a staked genesis node
+// for every validator.
+func (nw *Network) makeGenesis() *genesis.Genesis {
+	alloc := genesis.GenesisAlloc{
+		nw.baseAccount.Address: {Balance: genesisBalance},
+	}
+	for _, owner := range genesisOwners {
+		alloc[common.HexToAddress(owner)] = genesis.GenesisAccount{Balance: genesisBalance}
+	}
+	for _, n := range nw.nodes {
+		alloc[n.Address()] = genesis.GenesisAccount{Balance: genesisBalance}
+	}
+	consensus := pos.ConsensusInfo{
+		MaxViolatePercentageAllowed: 50,
+		FetchNewValidatorsTime:      10,
+		BlockReward:                 blockReward,
+		MaxValidators:               uint64(maxValidators),
+		ConsensusPeriodInBlock:      nw.cfg.ConsensusPeriod,
+		MinimumStakes:               stakeAmount,
+		LockedPeriod:                100000000,
+		Master: pos.MasterSmartContract{
+			Address:       masterAddress,
+			ByteCode:      smc.MasterByteCode,
+			ABI:           smc.MasterAbi,
+			GenesisAmount: genesisBalance,
+		},
+		Nodes:   pos.Nodes{ABI: smc.NodeAbi, ByteCode: smc.NodeByteCode},
+		Stakers: pos.Stakers{ABI: smc.StakerAbi, ByteCode: smc.StakerByteCode},
+	}
+	for i, n := range nw.nodes[:nw.cfg.Validators] {
+		owner, nodeAddress := common.HexToAddress(genesisOwners[i]), common.HexToAddress(genesisNodes[i])
+		consensus.Nodes.GenesisInfo = append(consensus.Nodes.GenesisInfo, pos.GenesisNodeInfo{
+			Address:          nodeAddress,
+			Owner:            owner,
+			PubKey:           common.Bytes2Hex(crypto.FromECDSAPub(&n.key.PublicKey)[1:]),
+			Name:             n.Name(),
+			RewardPercentage: 50,
+		})
+		consensus.Stakers.GenesisInfo = append(consensus.Stakers.GenesisInfo, pos.GenesisStakeInfo{
+			Address:     common.HexToAddress(genesisStakers[i]),
+			Owner:       owner,
+			StakedNode:  nodeAddress,
+			StakeAmount: stakeAmount,
+		})
+	}
+	chainConfig := *configs.TestnetChainConfig
+	chainConfig.ConsensusTiming = nw.cfg.Consensus.Timing()
+	chainConfig.SystemPhasesHeight = new(uint64)
+	chainConfig.PrecompilesV1Height = nw.cfg.PrecompilesV1Height
+	return &genesis.Genesis{
+		Config:        &chainConfig,
+		GasLimit:      16777216,
+		Alloc:         alloc,
+		ConsensusInfo: consensus,
+	}
+}
+
+// Start starts all nodes and connects every node to every other node.
+func (nw *Network) Start() error {
+	for _, n := range nw.nodes {
+		if err := n.Start(); err != nil {
+			nw.Stop()
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops all running nodes and removes the data of the network.
+func (nw *Network) Stop() {
+	nw.lock.Lock()
+	nw.stopped = true
+	nw.lock.Unlock()
+
+	for _, n := range nw.nodes {
+		n.Stop()
+	}
+	os.RemoveAll(nw.dir)
+}
+
+// Nodes returns all nodes of the network, running or not.
+func (nw *Network) Nodes() []*Node {
+	return nw.nodes
+}
+
+// Node returns the node with the given index.
+func (nw *Network) Node(i int) *Node {
+	return nw.nodes[i]
+}
+
+// Partition splits the network into the given groups of node indexes. Nodes of
+// different groups are disconnected and can't reconnect until Heal. Nodes not
+// listed in any group are cut off from every node.
+func (nw *Network) Partition(groups ...[]int) {
+	group := make(map[int]int)
+	for g, indexes := range groups {
+		for _, i := range indexes {
+			group[i] = g + 1
+		}
+	}
+	var cut []net.Conn
+	nw.lock.Lock()
+	for i := range nw.nodes {
+		for j := i + 1; j < len(nw.nodes); j++ {
+			if group[i] != 0 && group[i] == group[j] {
+				continue
+			}
+			pair := [2]int{i, j}
+			nw.blocked[pair] = true
+			cut = append(cut, nw.links[pair]...)
+			delete(nw.links, pair)
+		}
+	}
+	nw.lock.Unlock()
+
+	for _, conn := range cut {
+		conn.Close()
+	}
+}
+
+// Heal removes all partitions and reconnects the running nodes.
+func (nw *Network) Heal() {
+	nw.lock.Lock()
+	nw.blocked = make(map[[2]int]bool)
+	nw.lock.Unlock()
+
+	for _, n := range nw.nodes {
+		nw.connectAll(n)
+	}
+}
+
+// connectAll connects the given node to all running nodes it is not yet
+// connected to and not partitioned from.
+func (nw *Network) connectAll(n *Node) {
+	for _, peer := range nw.nodes {
+		if peer != n {
+			nw.connect(n, peer)
+		}
+	}
+}
+
+// connect connects the given nodes over an in-memory pipe, unless they are
+// partitioned, not running or already connected.
+func (nw *Network) connect(from, to *Node) {
+	pair := [2]int{from.index, to.index}
+	if pair[0] > pair[1] {
+		pair[0], pair[1] = pair[1], pair[0]
+	}
+	nw.lock.Lock()
+	defer nw.lock.Unlock()
+
+	fromSrv, toSrv := from.server(), to.server()
+	switch {
+	case nw.stopped || nw.blocked[pair]:
+		return
+	case fromSrv == nil || toSrv == nil:
+		return
+	case len(nw.links[pair]) > 0 && isPeer(fromSrv, to.ID()):
+		return
+	}
+	for _, conn := range nw.links[pair] {
+		conn.Close()
+	}
+	fromConn, toConn := net.Pipe()
+	nw.links[pair] = []net.Conn{fromConn, toConn}
+
+	dest := &discover.Node{ID: to.ID(), IP: net.IPv4(127, 0, 0, 1)}
+	go toSrv.SetupConn(toConn, 0, nil)
+	go fromSrv.SetupConn(fromConn, 0, dest)
+}
+
+// dropLinks closes all connections of the given node.
+func (nw *Network) dropLinks(n *Node) {
+	var dropped []net.Conn
+	nw.lock.Lock()
+	for pair, conns := range nw.links {
+		if pair[0] == n.index || pair[1] == n.index {
+			dropped = append(dropped, conns...)
+			delete(nw.links, pair)
+		}
+	}
+	nw.lock.Unlock()
+
+	for _, conn := range dropped {
+		conn.Close()
+	}
+}
+
+// isPeer reports whether the server is connected to the node with the given ID.
+func isPeer(srv *p2p.Server, id discover.NodeID) bool {
+	for _, p := range srv.Peers() {
+		if p.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForHeight waits until every running node has reached the given height.
+func (nw *Network) WaitForHeight(height uint64, timeout time.Duration) error {
+	return nw.waitFor(timeout, func() error {
+		for _, n := range nw.nodes {
+			if !n.Running() {
+				continue
+			}
+			if have := n.Height(); have < height {
+				return fmt.Errorf("%s at height %d, want %d", n.Name(), have, height)
+			}
+		}
+		return nil
+	})
+}
+
+// waitFor polls the given condition until it holds or the timeout expires,
+// returning the last error of the condition then.
+func (nw *Network) waitFor(timeout time.Duration, cond func() error) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := cond()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout after %v: %v", timeout, err)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// CheckSafety verifies that all running nodes committed the same blocks, up to
+// the lowest height among them.
+func (nw *Network) CheckSafety() error {
+	var running []*Node
+	height := uint64(0)
+	for _, n := range nw.nodes {
+		if !n.Running() {
+			continue
+		}
+		if h := n.Height(); len(running) == 0 || h < height {
+			height = h
+		}
+		running = append(running, n)
+	}
+	for h := uint64(1); h <= height; h++ {
+		want := running[0].BlockHash(h)
+		for _, n := range running[1:] {
+			if have := n.BlockHash(h); have != want {
+				return fmt.Errorf("fork at height %d: %s has %x, %s has %x", h, running[0].Name(), want, n.Name(), have)
+			}
+		}
+	}
+	return nil
+}
+
+// Node is a validator or observer node of a Network. It can be stopped and
+// restarted, keeping its key and chain.
+type Node struct {
+	network *Network
+	index   int
+	key     *ecdsa.PrivateKey
+	db      *nodeDB
+
+	lock sync.Mutex
+	node *node.Node // nil unless running
+}
+
+// Name returns the name of the node.
+func (n *Node) Name() string {
+	return fmt.Sprintf("node%d", n.index+1)
+}
+
+// Key returns the node key, which is also the key of its funded genesis account
+// and, for validators, of the validator.
+func (n *Node) Key() *ecdsa.PrivateKey {
+	return n.key
+}
+
+// Address returns the address of the node's genesis account.
+func (n *Node) Address() common.Address {
+	return crypto.PubkeyToAddress(n.key.PublicKey)
+}
+
+// ID returns the p2p node ID of the node.
+func (n *Node) ID() discover.NodeID {
+	return discover.PubkeyID(&n.key.PublicKey)
+}
+
+// Start starts the node and connects it to the running nodes it is not
+// partitioned from.
+func (n *Node) Start() error {
+	n.lock.Lock()
+	if n.node != nil {
+		n.lock.Unlock()
+		return errNodeRunning
+	}
+	nw := n.network
+	nw.lock.Lock()
+	stopped := nw.stopped
+	nw.lock.Unlock()
+	if stopped {
+		n.lock.Unlock()
+		return errNetworkStopped
+	}
+
+	// Keep the journal of local transactions out of the working directory
+	txPool := tx_pool.DefaultTxPoolConfig
+	txPool.Journal = filepath.Join(nw.dir, n.Name()+"-transactions.rlp")
+
+	cfg := &node.NodeConfig{
+		Name:    n.Name(),
+		DataDir: nw.dir,
+		P2P: p2p.Config{
+			PrivateKey:  n.key,
+			MaxPeers:    len(nw.nodes) * 2,
+			NoDiscovery: true,
+			NoDial:      true,
+		},
+		MainChainConfig: node.MainChainConfig{
+			DBInfo:      n.db,
+			Genesis:     nw.genesis,
+			TxPool:      txPool,
+			AcceptTxs:   1,
+			NetworkId:   networkID,
+			ChainId:     chainID,
+			ServiceName: n.Name(),
+			BaseAccount: nw.baseAccount,
+			Consensus:   nw.cfg.Consensus,
+		},
+	}
+	kn, err := node.NewNode(cfg)
+	if err != nil {
+		n.lock.Unlock()
+		return err
+	}
+	if err := kn.RegisterService(n.newKardiaService); err != nil {
+		n.lock.Unlock()
+		return err
+	}
+	if err := kn.Start(); err != nil {
+		n.lock.Unlock()
+		return err
+	}
+	n.node = kn
+	n.lock.Unlock()
+
+	nw.connectAll(n)
+	return nil
+}
+
+// newKardiaService creates the Kardia service of the node, setting up the clock
+// and faulty behaviors of its consensus.
+func (n *Node) newKardiaService(ctx *node.ServiceContext) (node.Service, error) {
+	service, err := kai.NewKardiaService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	csManager := service.(*kai.KardiaService).ConsensusManager()
+	if clock := n.network.cfg.Clock; clock != nil {
+		csManager.SetClock(clock)
+	}
+	if byzantine := n.network.cfg.Byzantine[n.index]; byzantine != nil {
+		csManager.SetByzantine(byzantine)
+	}
+	return service, nil
+}
+
+// Stop stops the node, as if it crashed. Its chain is kept for a restart.
+func (n *Node) Stop() error {
+	n.lock.Lock()
+	kn := n.node
+	n.node = nil
+	n.lock.Unlock()
+
+	if kn == nil {
+		return errNodeStopped
+	}
+	n.network.dropLinks(n)
+	return kn.Stop()
+}
+
+// Restart stops the node if it is running and starts it again.
+func (n *Node) Restart() error {
+	n.Stop()
+	return n.Start()
+}
+
+// Running reports whether the node is running.
+func (n *Node) Running() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.node != nil
+}
+
+// Kardia returns the Kardia service of the node, nil if the node is not running.
+func (n *Node) Kardia() *kai.KardiaService {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.node == nil {
+		return nil
+	}
+	var service *kai.KardiaService
+	if err := n.node.Service(&service); err != nil {
+		return nil
+	}
+	return service
+}
+
+// server returns the p2p server of the node, nil if the node is not running.
+func (n *Node) server() *p2p.Server {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.node == nil {
+		return nil
+	}
+	return n.node.Server()
+}
+
+// Height returns the height of the node's head block, read from its database so
+// it is known while the node is stopped too.
+func (n *Node) Height() uint64 {
+	db := n.db.StoreDB()
+	head := db.ReadHeadBlockHash()
+	height := db.ReadHeaderNumber(head)
+	if height == nil {
+		return 0
+	}
+	return *height
+}
+
+// BlockHash returns the hash of the node's block at the given height.
+func (n *Node) BlockHash(height uint64) common.Hash {
+	return n.db.StoreDB().ReadCanonicalHash(height)
+}
+
+// SubmitTx submits the given transaction to the node's transaction pool.
+func (n *Node) SubmitTx(tx *types.Transaction) error {
+	service := n.Kardia()
+	if service == nil {
+		return errNodeStopped
+	}
+	return service.TxPool().AddLocal(tx)
+}
+
+// Transfer signs a transfer of the given amount from the node's genesis account
+// to the given address and submits it to the node.
+func (n *Node) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	service := n.Kardia()
+	if service == nil {
+		return nil, errNodeStopped
+	}
+	nonce := service.TxPool().Nonce(n.Address())
+	tx, err := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(nonce, to, amount, 21000, big.NewInt(1), nil), n.key)
+	if err != nil {
+		return nil, err
+	}
+	return tx, service.TxPool().AddLocal(tx)
+}
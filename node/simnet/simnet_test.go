@@ -0,0 +1,143 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simnet
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+const testTimeout = 60 * time.Second
+
+func newTestNetwork(t *testing.T, cfg Config) *Network {
+	nw, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return nw
+}
+
+// checkHalted verifies that no running node commits a block for a while.
+func checkHalted(t *testing.T, nw *Network) {
+	heights := make(map[*Node]uint64)
+	for _, n := range nw.Nodes() {
+		heights[n] = n.Height()
+	}
+	time.Sleep(time.Second)
+	for _, n := range nw.Nodes() {
+		if n.Running() && n.Height() > heights[n]+1 {
+			t.Fatalf("%s progressed from height %d to %d without quorum", n.Name(), heights[n], n.Height())
+		}
+	}
+}
+
+func maxHeight(nw *Network) uint64 {
+	var height uint64
+	for _, n := range nw.Nodes() {
+		if h := n.Height(); h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+func TestLiveness(t *testing.T) {
+	nw := newTestNetwork(t, Config{Observers: 1})
+	defer nw.Stop()
+
+	if err := nw.WaitForHeight(5, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrashRestart(t *testing.T) {
+	nw := newTestNetwork(t, Config{})
+	defer nw.Stop()
+
+	if err := nw.WaitForHeight(3, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	// All three validators are needed for a quorum
+	if err := nw.Node(2).Stop(); err != nil {
+		t.Fatal(err)
+	}
+	checkHalted(t, nw)
+
+	if err := nw.Node(2).Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.WaitForHeight(maxHeight(nw)+3, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPartitionHeal(t *testing.T) {
+	nw := newTestNetwork(t, Config{})
+	defer nw.Stop()
+
+	if err := nw.WaitForHeight(3, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	nw.Partition([]int{0, 1}, []int{2})
+	checkHalted(t, nw)
+
+	nw.Heal()
+	if err := nw.WaitForHeight(maxHeight(nw)+3, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	nw := newTestNetwork(t, Config{Observers: 1})
+	defer nw.Stop()
+
+	// Submit to the observer, which must relay the transaction to the validators
+	to := common.HexToAddress("0x0000000000000000000000000000000000abcdef")
+	tx, err := nw.Node(3).Transfer(to, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = nw.waitFor(testTimeout, func() error {
+		for _, n := range nw.Nodes() {
+			if found, _, _, _ := n.db.StoreDB().ReadTransaction(tx.Hash()); found == nil {
+				return fmt.Errorf("%s has not included transaction %x", n.Name(), tx.Hash())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
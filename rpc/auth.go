@@ -0,0 +1,210 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// jwtIatWindow is the clock skew allowed to the issuance time of the tokens, and
+// the lifetime of the tokens without an expiration time.
+const jwtIatWindow = 60 * time.Second
+
+// AuthConfig configures the authentication of the clients of the HTTP endpoint
+// and the modules each of them is authorized to call. A "*" module authorizes
+// every module.
+type AuthConfig struct {
+	// PublicModules are served to every client, including the ones without credentials.
+	PublicModules []string `toml:",omitempty"`
+	// JWTSecret is the HMAC-SHA256 secret of the JWT bearer tokens of the
+	// Authorization header, nil to disable tokens.
+	JWTSecret []byte `toml:",omitempty"`
+	// JWTModules are served to the clients with a valid token.
+	JWTModules []string `toml:",omitempty"`
+	// APIKeys are the API keys of the X-Api-Key header and the modules served to
+	// the clients with each of them.
+	APIKeys map[string][]string `toml:",omitempty"`
+}
+
+// ReadJWTSecret reads a hex encoded JWT secret of at least 32 bytes from a file.
+func ReadJWTSecret(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT secret in %s: %v", path, err)
+	}
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("JWT secret in %s is %d bytes, at least 32 are required", path, len(secret))
+	}
+	return secret, nil
+}
+
+// moduleSet is a set of modules, holding "*" if it has all of them.
+type moduleSet map[string]bool
+
+func newModuleSet(modules []string) moduleSet {
+	set := make(moduleSet)
+	for _, module := range modules {
+		set[strings.TrimSpace(module)] = true
+	}
+	return set
+}
+
+func (s moduleSet) allows(module string) bool {
+	return s["*"] || s[module]
+}
+
+// authInfo is the outcome of the authentication of a client.
+type authInfo struct {
+	modules moduleSet
+	apiKey  string // API key the client authenticated with, if any
+}
+
+// authKey is the context key of the authInfo of the client.
+type authKey struct{}
+
+// authorized reports whether the client sending the requests of the context may
+// call the given module. The clients of unauthenticated endpoints, such as IPC
+// ones, may call every module.
+func authorized(ctx context.Context, module string) bool {
+	info, ok := ctx.Value(authKey{}).(*authInfo)
+	return !ok || module == MetadataApi || info.modules.allows(module)
+}
+
+// authenticator authenticates the clients of the HTTP endpoint.
+type authenticator struct {
+	public  []string
+	secret  []byte
+	jwt     []string
+	apiKeys map[string][]string
+	now     func() time.Time
+}
+
+func newAuthenticator(config *AuthConfig) *authenticator {
+	return &authenticator{
+		public:  config.PublicModules,
+		secret:  config.JWTSecret,
+		jwt:     config.JWTModules,
+		apiKeys: config.APIKeys,
+		now:     time.Now,
+	}
+}
+
+// authenticate checks the credentials of the request, granting the modules of
+// every valid credential on top of the public ones. Invalid credentials fail the
+// request, rather than falling back to the public modules.
+func (a *authenticator) authenticate(r *http.Request) (*authInfo, error) {
+	modules := append([]string{}, a.public...)
+	info := new(authInfo)
+	if header := r.Header.Get("Authorization"); header != "" {
+		if a.secret == nil {
+			return nil, errors.New("token authentication is disabled")
+		}
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == header {
+			return nil, errors.New("missing bearer token")
+		}
+		if err := verifyJWT(token, a.secret, a.now()); err != nil {
+			return nil, err
+		}
+		modules = append(modules, a.jwt...)
+	}
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		keyModules, ok := a.apiKeys[key]
+		if !ok {
+			return nil, errors.New("invalid API key")
+		}
+		modules = append(modules, keyModules...)
+		info.apiKey = key
+	}
+	info.modules = newModuleSet(modules)
+	return info, nil
+}
+
+// verifyJWT checks the token is signed by the secret with HMAC-SHA256 and is
+// current: either not expired, or without expiration time issued within the
+// last jwtIatWindow.
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("unsupported token algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errors.New("malformed token signature")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errors.New("invalid token signature")
+	}
+	var claims struct {
+		Iat *int64 `json:"iat"`
+		Exp *int64 `json:"exp"`
+	}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return err
+	}
+	switch {
+	case claims.Iat == nil && claims.Exp == nil:
+		return errors.New("token has neither iat nor exp claim")
+	case claims.Iat != nil && time.Unix(*claims.Iat, 0).After(now.Add(jwtIatWindow)):
+		return errors.New("token is issued in the future")
+	case claims.Exp != nil && !now.Before(time.Unix(*claims.Exp, 0)):
+		return errors.New("token is expired")
+	case claims.Exp == nil && time.Unix(*claims.Iat, 0).Before(now.Add(-jwtIatWindow)):
+		return errors.New("token is stale")
+	}
+	return nil
+}
+
+// decodeJWTPart decodes a base64url encoded JSON part of a token.
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.New("malformed token")
+	}
+	return nil
+}
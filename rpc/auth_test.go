@@ -0,0 +1,161 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// newTestToken signs the given claims with the given secret.
+func newTestToken(alg string, claims map[string]interface{}, secret []byte) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	tests := []struct {
+		token string
+		valid bool
+	}{
+		{newTestToken("HS256", map[string]interface{}{"iat": now.Unix()}, testJWTSecret), true},
+		{newTestToken("HS256", map[string]interface{}{"iat": now.Unix() - 30}, testJWTSecret), true},
+		{newTestToken("HS256", map[string]interface{}{"exp": now.Unix() + 3600}, testJWTSecret), true},
+		{newTestToken("HS256", map[string]interface{}{"iat": now.Unix() - 600, "exp": now.Unix() + 3600}, testJWTSecret), true},
+		{newTestToken("HS256", map[string]interface{}{"iat": now.Unix() - 600}, testJWTSecret), false},
+		{newTestToken("HS256", map[string]interface{}{"iat": now.Unix() + 600}, testJWTSecret), false},
+		{newTestToken("HS256", map[string]interface{}{"exp": now.Unix()}, testJWTSecret), false},
+		{newTestToken("HS256", map[string]interface{}{}, testJWTSecret), false},
+		{newTestToken("HS256", map[string]interface{}{"iat": now.Unix()}, []byte("another secret")), false},
+		{newTestToken("none", map[string]interface{}{"iat": now.Unix()}, testJWTSecret), false},
+		{"not.a.token", false},
+		{"token", false},
+	}
+	for i, test := range tests {
+		err := verifyJWT(test.token, testJWTSecret, now)
+		if test.valid && err != nil {
+			t.Errorf("test %d: valid token rejected: %v", i, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("test %d: invalid token accepted", i)
+		}
+	}
+}
+
+func TestReadJWTSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "jwt.hex")
+
+	ioutil.WriteFile(path, []byte("0x"+strings.Repeat("ab", 32)+"\n"), 0600)
+	if secret, err := ReadJWTSecret(path); err != nil || len(secret) != 32 {
+		t.Fatalf("secret: got %x (%v)", secret, err)
+	}
+	ioutil.WriteFile(path, []byte(strings.Repeat("ab", 16)), 0600)
+	if _, err := ReadJWTSecret(path); err == nil {
+		t.Fatal("short secret accepted")
+	}
+}
+
+func TestServerAuth(t *testing.T) {
+	server := NewServer()
+	server.SetAuth(&AuthConfig{
+		PublicModules: []string{"public"},
+		JWTSecret:     testJWTSecret,
+		JWTModules:    []string{"*"},
+		APIKeys:       map[string][]string{"secret-key": {"private"}},
+	})
+	for _, module := range []string{"public", "private", "admin"} {
+		if err := server.RegisterName(module, new(LimitsTestService)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	call := func(module string, headers map[string]string) (int, testResponse) {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + module + `_echo","params":["a"]}`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		var resp testResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec.Code, resp
+	}
+	token := newTestToken("HS256", map[string]interface{}{"iat": time.Now().Unix()}, testJWTSecret)
+	tests := []struct {
+		module  string
+		headers map[string]string
+		status  int
+		code    int
+	}{
+		{"public", nil, http.StatusOK, 0},
+		{"private", nil, http.StatusOK, -32001},
+		{"private", map[string]string{apiKeyHeader: "secret-key"}, http.StatusOK, 0},
+		{"public", map[string]string{apiKeyHeader: "secret-key"}, http.StatusOK, 0},
+		{"admin", map[string]string{apiKeyHeader: "secret-key"}, http.StatusOK, -32001},
+		{"admin", map[string]string{"Authorization": "Bearer " + token}, http.StatusOK, 0},
+		{"public", map[string]string{apiKeyHeader: "wrong-key"}, http.StatusUnauthorized, 0},
+		{"public", map[string]string{"Authorization": "Bearer " + token + "x"}, http.StatusUnauthorized, 0},
+		{"public", map[string]string{"Authorization": token}, http.StatusUnauthorized, 0},
+	}
+	for i, test := range tests {
+		status, resp := call(test.module, test.headers)
+		if status != test.status {
+			t.Errorf("test %d: got status %d, want %d", i, status, test.status)
+			continue
+		}
+		if status == http.StatusOK && errorCode(resp) != test.code {
+			t.Errorf("test %d: got code %d, want %d", i, errorCode(resp), test.code)
+		}
+	}
+	// The requests of a batch are authorized one by one
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"public_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"admin_echo","params":["b"]}]`))
+	req.Header.Set("content-type", contentType)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	var resps []testResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resps); err != nil || len(resps) != 2 {
+		t.Fatalf("batch: got %s (%v)", rec.Body.Bytes(), err)
+	}
+	if errorCode(resps[0]) != 0 || errorCode(resps[1]) != -32001 {
+		t.Fatalf("batch: got codes %d and %d, want 0 and -32001", errorCode(resps[0]), errorCode(resps[1]))
+	}
+}
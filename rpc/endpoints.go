@@ -21,8 +21,11 @@ import (
 	"net"
 )
 
-// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string) (net.Listener, *Server, error) {
+// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules/limits/auth
+func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, limits Limits, auth *AuthConfig) (net.Listener, *Server, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, nil, err
+	}
 	// Generate the whitelist based on the allowed modules.
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -31,6 +34,8 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 
 	// Register all the APIs
 	handler := NewServer()
+	handler.SetLimits(limits)
+	handler.SetAuth(auth)
 	for _, api := range apis {
 
 		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
@@ -53,3 +58,23 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 	go NewHTTPServer(cors, vhosts, handler).Serve(listener)
 	return listener, handler, err
 }
+
+// StartIPCEndpoint starts an IPC endpoint serving all the given APIs. Being only
+// reachable locally, the IPC endpoint is not subject to the module whitelist.
+func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, error) {
+	// Register all the APIs exposed by the services.
+	handler := NewServer()
+	for _, api := range apis {
+		if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+			return nil, nil, err
+		}
+		log.Debug("IPC registered", "namespace", api.Namespace)
+	}
+	// All APIs registered, start the IPC listener.
+	listener, err := ipcListen(ipcEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	go handler.ServeListener(listener)
+	return listener, handler, nil
+}
@@ -64,3 +64,40 @@ type shutdownError struct{}
 func (e *shutdownError) ErrorCode() int { return -32000 }
 
 func (e *shutdownError) Error() string { return "server is shutting down" }
+
+// issued when a request doesn't complete within its timeout.
+type timeoutError struct{}
+
+func (e *timeoutError) ErrorCode() int { return -32002 }
+
+func (e *timeoutError) Error() string { return "request timed out" }
+
+// issued when the responses of a batch exceed the maximum response size.
+type responseTooLargeError struct{}
+
+func (e *responseTooLargeError) ErrorCode() int { return -32003 }
+
+func (e *responseTooLargeError) Error() string { return "response too large" }
+
+// issued when a client exceeds its request rate.
+type limitExceededError struct{}
+
+func (e *limitExceededError) ErrorCode() int { return -32005 }
+
+func (e *limitExceededError) Error() string { return "request rate limit exceeded" }
+
+// issued when too many methods are still running after their requests timed out.
+type busyError struct{}
+
+func (e *busyError) ErrorCode() int { return -32005 }
+
+func (e *busyError) Error() string { return "too many timed out requests still running" }
+
+// issued when the client isn't authorized to call the module of a method.
+type unauthorizedError struct{ module string }
+
+func (e *unauthorizedError) ErrorCode() int { return -32001 }
+
+func (e *unauthorizedError) Error() string {
+	return fmt.Sprintf("unauthorized to call the %s module", e.module)
+}
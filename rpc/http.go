@@ -34,6 +34,9 @@ import (
 const (
 	contentType             = "application/json"
 	maxRequestContentLength = 1024 * 128
+	// apiKeyHeader carries the API key of a client, which authenticates it and
+	// identifies it for rate limiting instead of its IP address.
+	apiKeyHeader = "X-Api-Key"
 )
 
 var nullAddr, _ = net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -79,10 +82,17 @@ func NewHTTPServer(cors []string, vhosts []string, srv *Server) *http.Server {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
+	// Leave the requests the time to complete before writing their response
+	writeTimeout := 10 * time.Second
+	for _, timeout := range []time.Duration{srv.limits.maxTimeout(), srv.limits.batchTimeout()} {
+		if timeout+time.Second > writeTimeout {
+			writeTimeout = timeout + time.Second
+		}
+	}
 	return &http.Server{
 		Handler:      handler,
 		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		WriteTimeout: writeTimeout,
 		IdleTimeout:  120 * time.Second,
 	}
 }
@@ -104,6 +114,15 @@ func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx = context.WithValue(ctx, "remote", r.RemoteAddr)
 	ctx = context.WithValue(ctx, "scheme", r.Proto)
 	ctx = context.WithValue(ctx, "local", r.Host)
+	if srv.auth != nil {
+		info, err := srv.auth.authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		ctx = context.WithValue(ctx, authKey{}, info)
+	}
+	ctx = withClient(ctx, httpClient(ctx, r))
 
 	body := io.LimitReader(r.Body, maxRequestContentLength)
 	codec := NewJSONCodec(&httpReadWriteNopCloser{body, w})
@@ -113,6 +132,19 @@ func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	srv.ServeSingleRequest(ctx, codec, OptionMethodInvocation)
 }
 
+// httpClient returns the identity of the client sending the request: the API key
+// it authenticated with if any, otherwise its IP address.
+func httpClient(ctx context.Context, r *http.Request) string {
+	if info, ok := ctx.Value(authKey{}).(*authInfo); ok && info.apiKey != "" {
+		return "key:" + info.apiKey
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func validateRequest(r *http.Request) (int, error) {
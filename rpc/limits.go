@@ -0,0 +1,201 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxTimedOutCalls is the number of methods still running after their requests
+// timed out beyond which the server rejects the requests with a timeout.
+const maxTimedOutCalls = 64
+
+// Limits bounds the resources the clients of a server can consume. A timed out
+// request is answered right away, but its method keeps running until it returns:
+// the methods must honour the cancellation of the context they take.
+type Limits struct {
+	// BatchItemLimit is the maximum number of requests in a batch, 0 for no limit.
+	BatchItemLimit int `toml:",omitempty"`
+	// BatchResponseMaxSize is the maximum size in bytes of the responses of a
+	// batch, 0 for no limit. The requests past it are answered with an error.
+	BatchResponseMaxSize int `toml:",omitempty"`
+	// Timeout is the execution time allowed to a request, 0 for no timeout. It is
+	// propagated through the context of the methods taking one.
+	Timeout time.Duration `toml:",omitempty"`
+	// MethodTimeouts overrides Timeout for the given methods, such as kai_estimateGas.
+	MethodTimeouts map[string]time.Duration `toml:",omitempty"`
+	// BatchTimeout is the execution time allowed to a whole batch, 0 defaults to
+	// Timeout. The requests left once it passes are answered with a timeout.
+	BatchTimeout time.Duration `toml:",omitempty"`
+	// RateLimit is the number of requests per second allowed to a client, keyed by
+	// its API key or else its IP address, 0 for no limit. Each request of a batch
+	// counts as one.
+	RateLimit float64 `toml:",omitempty"`
+	// RateBurst is the number of requests a client may send at once. It must hold
+	// a batch of BatchItemLimit requests; 0 defaults to the larger of BatchItemLimit
+	// and a second of requests.
+	RateBurst int `toml:",omitempty"`
+}
+
+// DefaultLimits are the limits of the public HTTP endpoint.
+var DefaultLimits = Limits{
+	BatchItemLimit:       100,
+	BatchResponseMaxSize: 25 * 1000 * 1000,
+	Timeout:              30 * time.Second,
+	MethodTimeouts: map[string]time.Duration{
+		"kai_kardiaCall":  5 * time.Second,
+		"kai_estimateGas": 10 * time.Second,
+	},
+}
+
+// Validate returns an error if the limits reject some batches forever, their
+// requests outnumbering the burst of the rate limiter.
+func (l *Limits) Validate() error {
+	if l.RateLimit > 0 && l.RateBurst > 0 && l.RateBurst < l.BatchItemLimit {
+		return fmt.Errorf("rate burst %d is below the batch item limit %d", l.RateBurst, l.BatchItemLimit)
+	}
+	return nil
+}
+
+// rateBurst returns the number of requests a client may send at once.
+func (l *Limits) rateBurst() int {
+	if l.RateBurst > 0 {
+		return l.RateBurst
+	}
+	burst := int(math.Ceil(l.RateLimit))
+	if burst < l.BatchItemLimit {
+		burst = l.BatchItemLimit
+	}
+	return burst
+}
+
+// timeout returns the execution time allowed to the given method.
+func (l *Limits) timeout(method string) time.Duration {
+	if timeout, ok := l.MethodTimeouts[method]; ok {
+		return timeout
+	}
+	return l.Timeout
+}
+
+// batchTimeout returns the execution time allowed to a batch.
+func (l *Limits) batchTimeout() time.Duration {
+	if l.BatchTimeout > 0 {
+		return l.BatchTimeout
+	}
+	return l.Timeout
+}
+
+// maxTimeout returns the longest execution time allowed to a method, 0 if one
+// of them has no timeout.
+func (l *Limits) maxTimeout() time.Duration {
+	max := l.Timeout
+	for _, timeout := range l.MethodTimeouts {
+		if max == 0 || timeout == 0 {
+			return 0
+		}
+		if timeout > max {
+			max = timeout
+		}
+	}
+	return max
+}
+
+// clientKey is the context key of the identity clients are rate limited by.
+type clientKey struct{}
+
+// withClient returns a context identifying the client sending the requests.
+func withClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// clientFromContext returns the identity of the client, false for the clients
+// which aren't rate limited such as IPC ones.
+func clientFromContext(ctx context.Context) (string, bool) {
+	client, ok := ctx.Value(clientKey{}).(string)
+	return client, ok
+}
+
+// bucket holds the tokens of a client, refilled as time passes.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter is a token bucket rate limiter per client.
+type rateLimiter struct {
+	rate  float64 // tokens refilled per second
+	burst float64 // capacity of the buckets
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes n tokens from the bucket of the given client, reporting whether
+// it held enough of them.
+func (l *rateLimiter) allow(client string, n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// sweep drops the buckets refilled up to their capacity every minute, as they
+// are no different from new ones.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
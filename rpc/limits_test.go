@@ -0,0 +1,304 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type LimitsTestService struct{}
+
+func (s *LimitsTestService) Echo(str string) string {
+	return str
+}
+
+func (s *LimitsTestService) Sleep(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *LimitsTestService) Large() string {
+	return strings.Repeat("a", 1000)
+}
+
+// BlockingTestService has a method ignoring its context.
+type BlockingTestService struct {
+	release chan struct{}
+}
+
+func (s *BlockingTestService) Wait() {
+	<-s.release
+}
+
+type testResponse struct {
+	Id     json.RawMessage `json:"id"`
+	Result interface{}     `json:"result"`
+	Error  *jsonError      `json:"error"`
+}
+
+func newLimitsTestServer(t *testing.T, limits Limits) *Server {
+	server := NewServer()
+	server.SetLimits(limits)
+	if err := server.RegisterName("test", new(LimitsTestService)); err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+// post sends the body to the server over HTTP and returns the raw response.
+func post(server *Server, body string) json.RawMessage {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("content-type", contentType)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	return rec.Body.Bytes()
+}
+
+func postBatch(t *testing.T, server *Server, body string) []testResponse {
+	var resps []testResponse
+	if err := json.Unmarshal(post(server, body), &resps); err != nil {
+		t.Fatalf("invalid batch response: %v", err)
+	}
+	return resps
+}
+
+func errorCode(resp testResponse) int {
+	if resp.Error == nil {
+		return 0
+	}
+	return resp.Error.Code
+}
+
+func TestServerBatch(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{})
+	resps := postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_missing"},{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["c"]}]`)
+	if len(resps) != 3 {
+		t.Fatalf("responses: got %d, want 3", len(resps))
+	}
+	if resps[0].Result != "a" || resps[2].Result != "c" {
+		t.Errorf("unexpected results %v, %v", resps[0].Result, resps[2].Result)
+	}
+	if code := errorCode(resps[1]); code != -32601 {
+		t.Errorf("missing method: got code %d, want -32601", code)
+	}
+	var resp testResponse
+	if err := json.Unmarshal(post(server, `[]`), &resp); err != nil || errorCode(resp) != -32600 {
+		t.Errorf("empty batch: got %+v (%v)", resp, err)
+	}
+}
+
+func TestServerBatchItemLimit(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{BatchItemLimit: 2})
+	if resps := postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]}]`); len(resps) != 2 {
+		t.Fatalf("responses: got %d, want 2", len(resps))
+	}
+	var resp testResponse
+	if err := json.Unmarshal(post(server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]},{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["c"]}]`), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if code := errorCode(resp); code != -32600 {
+		t.Fatalf("batch too large: got code %d, want -32600", code)
+	}
+}
+
+func TestServerBatchResponseMaxSize(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{BatchResponseMaxSize: 1500})
+	resps := postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_large"},{"jsonrpc":"2.0","id":2,"method":"test_large"},{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["c"]}]`)
+	if len(resps) != 3 {
+		t.Fatalf("responses: got %d, want 3", len(resps))
+	}
+	for i, want := range []int{0, -32003, -32003} {
+		if code := errorCode(resps[i]); code != want {
+			t.Errorf("response %d: got code %d, want %d", i, code, want)
+		}
+	}
+}
+
+func TestServerTimeout(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{
+		Timeout:        time.Minute,
+		MethodTimeouts: map[string]time.Duration{"test_sleep": 10 * time.Millisecond},
+	})
+	var resp testResponse
+	if err := json.Unmarshal(post(server, `{"jsonrpc":"2.0","id":1,"method":"test_sleep"}`), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if code := errorCode(resp); code != -32002 {
+		t.Fatalf("timed out request: got code %d, want -32002", code)
+	}
+	if err := json.Unmarshal(post(server, `{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["a"]}`), &resp); err != nil || resp.Result != "a" {
+		t.Fatalf("request: got %+v (%v)", resp, err)
+	}
+}
+
+func TestServerBatchTimeout(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{
+		Timeout:        time.Minute,
+		MethodTimeouts: map[string]time.Duration{"test_sleep": 30 * time.Millisecond},
+		BatchTimeout:   50 * time.Millisecond,
+	})
+	// The second sleep is cut short by the batch deadline, leaving no time to
+	// the requests after it.
+	start := time.Now()
+	resps := postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_sleep"},{"jsonrpc":"2.0","id":2,"method":"test_sleep"},{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["c"]}]`)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("batch took %v", elapsed)
+	}
+	if len(resps) != 3 {
+		t.Fatalf("responses: got %d, want 3", len(resps))
+	}
+	for i, resp := range resps {
+		if code := errorCode(resp); code != -32002 {
+			t.Errorf("response %d: got code %d, want -32002", i, code)
+		}
+	}
+	resps = postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]}]`)
+	if errorCode(resps[0]) != 0 || errorCode(resps[1]) != 0 {
+		t.Fatalf("batch within the deadline: %+v", resps)
+	}
+}
+
+func TestHTTPServerWriteTimeout(t *testing.T) {
+	for _, test := range []struct {
+		limits Limits
+		want   time.Duration
+	}{
+		{Limits{}, 10 * time.Second},
+		{Limits{Timeout: 30 * time.Second}, 31 * time.Second},
+		{Limits{Timeout: 30 * time.Second, MethodTimeouts: map[string]time.Duration{"test_sleep": time.Minute}}, 61 * time.Second},
+		{Limits{Timeout: 30 * time.Second, BatchTimeout: 2 * time.Minute}, 121 * time.Second},
+	} {
+		server := NewServer()
+		server.SetLimits(test.limits)
+		if got := NewHTTPServer(nil, nil, server).WriteTimeout; got != test.want {
+			t.Errorf("limits %+v: write timeout %v, want %v", test.limits, got, test.want)
+		}
+	}
+}
+
+func TestServerTimedOutCalls(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{Timeout: 5 * time.Millisecond})
+	service := &BlockingTestService{release: make(chan struct{})}
+	if err := server.RegisterName("blocking", service); err != nil {
+		t.Fatal(err)
+	}
+	var resp testResponse
+	for i := 0; i < maxTimedOutCalls; i++ {
+		if err := json.Unmarshal(post(server, `{"jsonrpc":"2.0","id":1,"method":"blocking_wait"}`), &resp); err != nil || errorCode(resp) != -32002 {
+			t.Fatalf("request %d: got %+v (%v), want code -32002", i, resp, err)
+		}
+	}
+	if err := json.Unmarshal(post(server, `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]}`), &resp); err != nil || errorCode(resp) != -32005 {
+		t.Fatalf("request over the timed out calls: got %+v (%v), want code -32005", resp, err)
+	}
+
+	close(service.release)
+	for start := time.Now(); atomic.LoadInt32(&server.timedOut) != 0; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("timed out calls: got %d, want 0", atomic.LoadInt32(&server.timedOut))
+		}
+	}
+	if err := json.Unmarshal(post(server, `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]}`), &resp); err != nil || resp.Result != "a" {
+		t.Fatalf("request after the timed out calls returned: got %+v (%v)", resp, err)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := newLimitsTestServer(t, Limits{RateLimit: 0.001, RateBurst: 3})
+	resps := postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]}]`)
+	if errorCode(resps[0]) != 0 || errorCode(resps[1]) != 0 {
+		t.Fatalf("batch within the burst was limited: %+v", resps)
+	}
+	resps = postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]}]`)
+	for i, resp := range resps {
+		if code := errorCode(resp); code != -32005 {
+			t.Errorf("response %d: got code %d, want -32005", i, code)
+		}
+	}
+	var resp testResponse
+	if err := json.Unmarshal(post(server, `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]}`), &resp); err != nil || resp.Result != "a" {
+		t.Fatalf("request within the burst: got %+v (%v)", resp, err)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.allow("a", 1) || !limiter.allow("a", 1) {
+		t.Fatal("requests within the burst were limited")
+	}
+	if limiter.allow("a", 1) {
+		t.Fatal("request over the burst was allowed")
+	}
+	if !limiter.allow("b", 2) {
+		t.Fatal("clients share their buckets")
+	}
+	now = now.Add(time.Second)
+	if !limiter.allow("a", 1) || limiter.allow("a", 1) {
+		t.Fatal("bucket didn't refill by the rate")
+	}
+	if limiter.allow("c", 3) {
+		t.Fatal("batch over the burst was allowed")
+	}
+	now = now.Add(time.Hour)
+	limiter.allow("a", 1)
+	if len(limiter.buckets) != 1 {
+		t.Fatalf("idle buckets: got %d, want 1", len(limiter.buckets))
+	}
+}
+
+func TestServerRateBurst(t *testing.T) {
+	// Unset, the burst holds a full batch.
+	server := newLimitsTestServer(t, Limits{BatchItemLimit: 2, RateLimit: 0.001})
+	resps := postBatch(t, server, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]}]`)
+	for i, resp := range resps {
+		if code := errorCode(resp); code != 0 {
+			t.Errorf("response %d: got code %d, want 0", i, code)
+		}
+	}
+	for _, tt := range []struct {
+		limits Limits
+		burst  int
+	}{
+		{Limits{RateLimit: 0.5}, 1},
+		{Limits{RateLimit: 10.5}, 11},
+		{Limits{RateLimit: 10, BatchItemLimit: 100}, 100},
+		{Limits{RateLimit: 10, RateBurst: 200, BatchItemLimit: 100}, 200},
+	} {
+		if burst := tt.limits.rateBurst(); burst != tt.burst {
+			t.Errorf("%+v: got burst %d, want %d", tt.limits, burst, tt.burst)
+		}
+	}
+	limits := Limits{RateLimit: 10, RateBurst: 5, BatchItemLimit: 100}
+	if err := limits.Validate(); err == nil {
+		t.Error("burst below the batch item limit was accepted")
+	}
+	if _, _, err := StartHTTPEndpoint("127.0.0.1:0", nil, nil, nil, nil, limits, nil); err == nil {
+		t.Error("endpoint started with a burst below the batch item limit")
+	}
+}
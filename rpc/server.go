@@ -18,6 +18,7 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"runtime"
@@ -73,6 +74,26 @@ func (s *RPCService) Modules() map[string]string {
 	return modules
 }
 
+// SetLimits bounds the batch sizes, the execution time and the request rate of
+// the clients of the server. It must be called before serving requests.
+func (s *Server) SetLimits(limits Limits) {
+	s.limits = limits
+	s.limiter = nil
+	if limits.RateLimit > 0 {
+		s.limiter = newRateLimiter(limits.RateLimit, limits.rateBurst())
+	}
+}
+
+// SetAuth requires the HTTP clients of the server to authenticate, restricting
+// each of them to the modules of its credentials. A nil config serves every module
+// to everyone. It must be called before serving requests.
+func (s *Server) SetAuth(config *AuthConfig) {
+	s.auth = nil
+	if config != nil {
+		s.auth = newAuthenticator(config)
+	}
+}
+
 // RegisterName will create a service for the given rcvr type under the given name. When no methods on the given rcvr
 // match the criteria to be either a RPC method or a subscription an error is returned. Otherwise a new service is
 // created and added to the service collection this server instance serves.
@@ -158,7 +179,7 @@ func (s *Server) serveRequest(ctx context.Context, codec ServerCodec, singleShot
 
 	// test if the server is ordered to stop
 	for atomic.LoadInt32(&s.run) == 1 {
-		reqs, batch, err := s.readRequest(codec)
+		reqs, batch, err := s.readRequest(ctx, codec)
 		if err != nil {
 			// If a parsing error occurred, send an error
 			if err.Error() != "EOF" {
@@ -173,25 +194,29 @@ func (s *Server) serveRequest(ctx context.Context, codec ServerCodec, singleShot
 		// check if server is ordered to shutdown and return an error
 		// telling the client that his request failed.
 		if atomic.LoadInt32(&s.run) != 1 {
-			err = &shutdownError{}
-			if batch {
-				resps := make([]interface{}, len(reqs))
-				for i, r := range reqs {
-					resps[i] = codec.CreateErrorResponse(&r.id, err)
-				}
-				codec.Write(resps)
-			} else {
-				codec.Write(codec.CreateErrorResponse(&reqs[0].id, err))
-			}
+			s.reject(codec, reqs, batch, &shutdownError{})
 			return nil
 		}
-		// We focus on single rpc request for now
+		if batch && s.limits.BatchItemLimit > 0 && len(reqs) > s.limits.BatchItemLimit {
+			codec.Write(codec.CreateErrorResponse(nil, &invalidRequestError{fmt.Sprintf("batch too large, at most %d requests are allowed", s.limits.BatchItemLimit)}))
+			if singleShot {
+				return nil
+			}
+			continue
+		}
+		if client, ok := clientFromContext(ctx); ok && s.limiter != nil && !s.limiter.allow(client, len(reqs)) {
+			s.reject(codec, reqs, batch, &limitExceededError{})
+			if singleShot {
+				return nil
+			}
+			continue
+		}
 		if singleShot {
-			s.exec(ctx, codec, reqs[0])
-			// if batch {
-			// 	s.execBatch(ctx, codec, reqs)
-			// } else {
-			// }
+			if batch {
+				s.execBatch(ctx, codec, reqs)
+			} else {
+				s.exec(ctx, codec, reqs[0])
+			}
 			return nil
 		}
 		// For multi-shot connections, start a goroutine to serve and loop back
@@ -199,17 +224,29 @@ func (s *Server) serveRequest(ctx context.Context, codec ServerCodec, singleShot
 
 		go func(reqs []*serverRequest, batch bool) {
 			defer pend.Done()
-			s.exec(ctx, codec, reqs[0])
-			// if batch {
-			// s.execBatch(ctx, codec, reqs)
-			// } else {
-
-			// }
+			if batch {
+				s.execBatch(ctx, codec, reqs)
+			} else {
+				s.exec(ctx, codec, reqs[0])
+			}
 		}(reqs, batch)
 	}
 	return nil
 }
 
+// reject answers every given request with the given error.
+func (s *Server) reject(codec ServerCodec, reqs []*serverRequest, batch bool, err Error) {
+	if batch {
+		resps := make([]interface{}, len(reqs))
+		for i, r := range reqs {
+			resps[i] = codec.CreateErrorResponse(&r.id, err)
+		}
+		codec.Write(resps)
+	} else {
+		codec.Write(codec.CreateErrorResponse(&reqs[0].id, err))
+	}
+}
+
 // ServeCodec reads incoming requests from codec, calls the appropriate callback and writes the
 // response back using the given codec. It will block until the codec is closed or the server is
 // stopped. In either case the codec is closed.
@@ -299,6 +336,12 @@ func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverReque
 		return codec.CreateErrorResponse(&req.id, rpcErr), nil
 	}
 
+	// bound the execution time of the method, cancelling its context on timeout
+	if timeout := s.limits.timeout(req.svcname + serviceMethodSeparator + formatName(req.callb.method.Name)); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	arguments := []reflect.Value{req.callb.rcvr}
 	if req.callb.hasCtx {
 		arguments = append(arguments, reflect.ValueOf(ctx))
@@ -308,7 +351,10 @@ func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverReque
 	}
 
 	// execute RPC method and return result
-	reply := req.callb.method.Func.Call(arguments)
+	reply, rpcErr := s.call(ctx, req.callb, arguments)
+	if rpcErr != nil {
+		return codec.CreateErrorResponse(&req.id, rpcErr), nil
+	}
 	if len(reply) == 0 {
 		return codec.CreateResponse(req.id, nil), nil
 	}
@@ -322,6 +368,56 @@ func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverReque
 	return codec.CreateResponse(req.id, reply[0].Interface()), nil
 }
 
+// call executes the method of the callback. Once the context has a deadline, the
+// method runs in its own goroutine and the call gives up on it when the deadline
+// passes, leaving the methods ignoring their context to finish in the background.
+// Up to maxTimedOutCalls of them may be running, the requests with a deadline
+// being rejected beyond it.
+func (s *Server) call(ctx context.Context, callb *callback, arguments []reflect.Value) ([]reflect.Value, Error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return callb.method.Func.Call(arguments), nil
+	}
+	if atomic.LoadInt32(&s.timedOut) >= maxTimedOutCalls {
+		return nil, &busyError{}
+	}
+	const (
+		running = iota
+		returned
+		abandoned
+	)
+	var state int32 = running
+	done := make(chan []reflect.Value, 1)
+	go func() {
+		defer func() {
+			if !atomic.CompareAndSwapInt32(&state, running, returned) {
+				atomic.AddInt32(&s.timedOut, -1)
+			}
+		}()
+		defer func() {
+			if err := recover(); err != nil {
+				const size = 64 << 10
+				buf := make([]byte, size)
+				buf = buf[:runtime.Stack(buf, false)]
+				log.Error("RPC method crashed", "method", callb.method.Name, "err", err, "stack", string(buf))
+				close(done)
+			}
+		}()
+		done <- callb.method.Func.Call(arguments)
+	}()
+	select {
+	case reply, ok := <-done:
+		if !ok {
+			return nil, &callbackError{"method handler crashed"}
+		}
+		return reply, nil
+	case <-ctx.Done():
+		if atomic.CompareAndSwapInt32(&state, running, abandoned) {
+			atomic.AddInt32(&s.timedOut, 1)
+		}
+		return nil, &timeoutError{}
+	}
+}
+
 // exec executes the given request and writes the result back using the codec.
 func (s *Server) exec(ctx context.Context, codec ServerCodec, req *serverRequest) {
 	var response interface{}
@@ -343,10 +439,68 @@ func (s *Server) exec(ctx context.Context, codec ServerCodec, req *serverRequest
 	}
 }
 
+// execBatch executes the given requests and writes the result back using the codec.
+// It will only write the response back when the last request is processed. Once
+// the responses exceed the maximum size or the batch times out, the remaining
+// requests are answered with an error instead.
+func (s *Server) execBatch(ctx context.Context, codec ServerCodec, requests []*serverRequest) {
+	var (
+		responses = make([]interface{}, len(requests))
+		callbacks []func()
+		size      int
+		maxSize   = s.limits.BatchResponseMaxSize
+	)
+	// bound the execution time of the whole batch, each request taking its share
+	if timeout := s.limits.batchTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for i, req := range requests {
+		if maxSize > 0 && size > maxSize {
+			responses[i] = codec.CreateErrorResponse(&req.id, &responseTooLargeError{})
+			continue
+		}
+		if ctx.Err() != nil {
+			responses[i] = codec.CreateErrorResponse(&req.id, &timeoutError{})
+			continue
+		}
+		var callback func()
+		if req.err != nil {
+			responses[i] = codec.CreateErrorResponse(&req.id, req.err)
+		} else {
+			responses[i], callback = s.handle(ctx, codec, req)
+		}
+		if maxSize > 0 {
+			if encoded, err := json.Marshal(responses[i]); err == nil {
+				size += len(encoded)
+			}
+			if size > maxSize {
+				responses[i] = codec.CreateErrorResponse(&req.id, &responseTooLargeError{})
+				continue
+			}
+		}
+		if callback != nil {
+			callbacks = append(callbacks, callback)
+		}
+	}
+
+	if err := codec.Write(responses); err != nil {
+		log.Error(fmt.Sprintf("%v\n", err))
+		codec.Close()
+	}
+
+	// when request holds one of more subscribe requests this allows these subscriptions to be activated
+	for _, c := range callbacks {
+		c()
+	}
+}
+
 // readRequest requests the next (batch) request from the codec. It will return the collection
 // of requests, an indication if the request was a batch, the invalid request identifier and an
-// error when the request could not be read/parsed.
-func (s *Server) readRequest(codec ServerCodec) ([]*serverRequest, bool, Error) {
+// error when the request could not be read/parsed. The requests to modules the client isn't
+// authorized to call are answered with an error.
+func (s *Server) readRequest(ctx context.Context, codec ServerCodec) ([]*serverRequest, bool, Error) {
 	reqs, batch, err := codec.ReadRequestHeaders()
 	if err != nil {
 		return nil, batch, err
@@ -375,6 +529,11 @@ func (s *Server) readRequest(codec ServerCodec) ([]*serverRequest, bool, Error)
 			continue
 		}
 
+		if !authorized(ctx, r.service) {
+			requests[i] = &serverRequest{id: r.id, err: &unauthorizedError{r.service}}
+			continue
+		}
+
 		if svc, ok = s.services[r.service]; !ok { // rpc method isn't available
 			log.Info("RPC service called was not registered in the ServiceRegistry")
 			requests[i] = &serverRequest{id: r.id, err: &methodNotFoundError{r.service, r.method}}
@@ -17,9 +17,18 @@
 package rpc
 
 import (
-	mapset "github.com/deckarep/golang-set"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
+
+	mapset "github.com/deckarep/golang-set"
+
+	"github.com/kardiachain/go-kardia/lib/common"
 )
 
 // API describes the set of methods offered over the RPC interface
@@ -69,6 +78,11 @@ type Server struct {
 	run      int32
 	codecsMu sync.Mutex
 	codecs   mapset.Set
+
+	limits   Limits
+	limiter  *rateLimiter   // nil when clients aren't rate limited
+	auth     *authenticator // nil when HTTP clients aren't authenticated
+	timedOut int32          // methods still running after their request timed out
 }
 
 // rpcRequest represents a raw incoming RPC request
@@ -110,3 +124,155 @@ type ServerCodec interface {
 	// Closed when underlying connection is closed
 	Closed() <-chan interface{}
 }
+
+// BlockNumber is the height of a block, or one of the "pending", "latest" and
+// "earliest" tags selecting the pending state, the head and the genesis block.
+type BlockNumber int64
+
+const (
+	PendingBlockNumber  = BlockNumber(-2)
+	LatestBlockNumber   = BlockNumber(-1)
+	EarliestBlockNumber = BlockNumber(0)
+)
+
+// UnmarshalJSON parses the given JSON fragment into a BlockNumber. It supports:
+// - "latest", "earliest" or "pending" as string arguments
+// - the block number as a JSON number, a decimal or a 0x prefixed hex string
+// Returned errors:
+// - an invalid block number error when the given argument isn't a known string
+// - an out of range error when the given block number is either too little or too large
+func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
+	input := strings.TrimSpace(string(data))
+	if len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"' {
+		input = input[1 : len(input)-1]
+	}
+	switch input {
+	case "earliest":
+		*bn = EarliestBlockNumber
+		return nil
+	case "latest":
+		*bn = LatestBlockNumber
+		return nil
+	case "pending":
+		*bn = PendingBlockNumber
+		return nil
+	}
+	number, err := strconv.ParseUint(input, 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block number %s", data)
+	}
+	if number > math.MaxInt64 {
+		return fmt.Errorf("block number %d is out of range", number)
+	}
+	*bn = BlockNumber(number)
+	return nil
+}
+
+// MarshalJSON encodes the tags as strings and the heights as numbers.
+func (bn BlockNumber) MarshalJSON() ([]byte, error) {
+	switch bn {
+	case PendingBlockNumber:
+		return []byte(`"pending"`), nil
+	case LatestBlockNumber:
+		return []byte(`"latest"`), nil
+	}
+	return []byte(strconv.FormatInt(int64(bn), 10)), nil
+}
+
+func (bn BlockNumber) String() string {
+	switch bn {
+	case PendingBlockNumber:
+		return "pending"
+	case LatestBlockNumber:
+		return "latest"
+	}
+	return strconv.FormatInt(int64(bn), 10)
+}
+
+// BlockNumberOrHash selects a block either by number, including the tags of
+// BlockNumber, or by hash.
+type BlockNumberOrHash struct {
+	BlockNumber *BlockNumber `json:"blockNumber,omitempty"`
+	BlockHash   *common.Hash `json:"blockHash,omitempty"`
+}
+
+// UnmarshalJSON parses a block number, a block tag, a 0x prefixed block hash or
+// an object holding either a blockNumber or a blockHash field.
+func (bnh *BlockNumberOrHash) UnmarshalJSON(data []byte) error {
+	var obj struct {
+		BlockNumber *BlockNumber `json:"blockNumber"`
+		BlockHash   *string      `json:"blockHash"`
+	}
+	if err := json.Unmarshal(data, &obj); err == nil {
+		if (obj.BlockNumber == nil) == (obj.BlockHash == nil) {
+			return fmt.Errorf("cannot specify both or neither of blockNumber and blockHash")
+		}
+		if obj.BlockHash != nil {
+			if !isBlockHash(*obj.BlockHash) {
+				return fmt.Errorf("invalid block hash %q", *obj.BlockHash)
+			}
+			hash := common.HexToHash(*obj.BlockHash)
+			*bnh = BlockNumberOrHash{BlockHash: &hash}
+			return nil
+		}
+		*bnh = BlockNumberOrHash{BlockNumber: obj.BlockNumber}
+		return nil
+	}
+	var input string
+	if err := json.Unmarshal(data, &input); err == nil && isBlockHash(input) {
+		hash := common.HexToHash(input)
+		*bnh = BlockNumberOrHash{BlockHash: &hash}
+		return nil
+	}
+	var number BlockNumber
+	if err := number.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*bnh = BlockNumberOrHash{BlockNumber: &number}
+	return nil
+}
+
+// isBlockHash reports whether the input is a 0x prefixed hex encoded hash.
+func isBlockHash(input string) bool {
+	if len(input) != 2+2*common.HashLength || !strings.HasPrefix(input, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(input[2:])
+	return err == nil
+}
+
+// Number returns the selected block number, false if the block is selected by hash.
+func (bnh BlockNumberOrHash) Number() (BlockNumber, bool) {
+	if bnh.BlockNumber != nil {
+		return *bnh.BlockNumber, true
+	}
+	return BlockNumber(0), false
+}
+
+// Hash returns the selected block hash, false if the block is selected by number.
+func (bnh BlockNumberOrHash) Hash() (common.Hash, bool) {
+	if bnh.BlockHash != nil {
+		return *bnh.BlockHash, true
+	}
+	return common.Hash{}, false
+}
+
+func (bnh BlockNumberOrHash) String() string {
+	if bnh.BlockHash != nil {
+		return bnh.BlockHash.Hex()
+	}
+	if bnh.BlockNumber != nil {
+		return bnh.BlockNumber.String()
+	}
+	return "nil"
+}
+
+// BlockNumberOrHashWithNumber selects the block of the given number or tag.
+func BlockNumberOrHashWithNumber(blockNr BlockNumber) BlockNumberOrHash {
+	return BlockNumberOrHash{BlockNumber: &blockNr}
+}
+
+// BlockNumberOrHashWithHash selects the block of the given hash.
+func BlockNumberOrHashWithHash(hash common.Hash) BlockNumberOrHash {
+	return BlockNumberOrHash{BlockHash: &hash}
+}
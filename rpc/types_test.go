@@ -0,0 +1,100 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+func TestBlockNumberJSONUnmarshal(t *testing.T) {
+	tests := []struct {
+		input    string
+		mustFail bool
+		expected BlockNumber
+	}{
+		{`0`, false, BlockNumber(0)},
+		{`12`, false, BlockNumber(12)},
+		{`"0x0"`, false, BlockNumber(0)},
+		{`"0x1f"`, false, BlockNumber(31)},
+		{`"42"`, false, BlockNumber(42)},
+		{`"pending"`, false, PendingBlockNumber},
+		{`"latest"`, false, LatestBlockNumber},
+		{`"earliest"`, false, EarliestBlockNumber},
+		{`-1`, true, BlockNumber(0)},
+		{`"0x"`, true, BlockNumber(0)},
+		{`"0xg"`, true, BlockNumber(0)},
+		{`"0xffffffffffffffff"`, true, BlockNumber(0)},
+		{`"safe"`, true, BlockNumber(0)},
+		{`true`, true, BlockNumber(0)},
+	}
+	for i, test := range tests {
+		var num BlockNumber
+		err := json.Unmarshal([]byte(test.input), &num)
+		if test.mustFail && err == nil {
+			t.Errorf("Test %d should fail", i)
+			continue
+		}
+		if !test.mustFail && err != nil {
+			t.Errorf("Test %d should pass but got err: %v", i, err)
+			continue
+		}
+		if num != test.expected {
+			t.Errorf("Test %d got unexpected value, want %d, got %d", i, test.expected, num)
+		}
+	}
+}
+
+func TestBlockNumberOrHashUnmarshalJSON(t *testing.T) {
+	hash := common.HexToHash("0x1fe8c1bb0bb8e6d1a6b37e3b8c5ab0d0e7fd2c4c3e0b1cb4e31cb5d1d6fa0c2b")
+	tests := []struct {
+		input    string
+		mustFail bool
+		expected BlockNumberOrHash
+	}{
+		{`"0x1"`, false, BlockNumberOrHashWithNumber(1)},
+		{`7`, false, BlockNumberOrHashWithNumber(7)},
+		{`"latest"`, false, BlockNumberOrHashWithNumber(LatestBlockNumber)},
+		{`"pending"`, false, BlockNumberOrHashWithNumber(PendingBlockNumber)},
+		{`"earliest"`, false, BlockNumberOrHashWithNumber(EarliestBlockNumber)},
+		{`"` + hash.Hex() + `"`, false, BlockNumberOrHashWithHash(hash)},
+		{`{"blockNumber":"latest"}`, false, BlockNumberOrHashWithNumber(LatestBlockNumber)},
+		{`{"blockHash":"` + hash.Hex() + `"}`, false, BlockNumberOrHashWithHash(hash)},
+		{`{"blockNumber":"0x1","blockHash":"` + hash.Hex() + `"}`, true, BlockNumberOrHash{}},
+		{`{}`, true, BlockNumberOrHash{}},
+		{`{"blockHash":"0x1"}`, true, BlockNumberOrHash{}},
+		{`"0x1fe8"`, false, BlockNumberOrHashWithNumber(0x1fe8)},
+		{`"unknown"`, true, BlockNumberOrHash{}},
+	}
+	for i, test := range tests {
+		var bnh BlockNumberOrHash
+		err := json.Unmarshal([]byte(test.input), &bnh)
+		if test.mustFail && err == nil {
+			t.Errorf("Test %d should fail", i)
+			continue
+		}
+		if !test.mustFail && err != nil {
+			t.Errorf("Test %d should pass but got err: %v", i, err)
+			continue
+		}
+		if bnh.String() != test.expected.String() {
+			t.Errorf("Test %d got unexpected value, want %s, got %s", i, test.expected, bnh)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright 2018 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// abigen generates Go bindings for a Kardia contract from its ABI and optional
+// bytecode.
+//
+//	abigen --abi token.abi --bin token.bin --type Token --pkg token --out token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+)
+
+type flags struct {
+	abi  string
+	bin  string
+	kind string
+	pkg  string
+	out  string
+}
+
+func initFlag(args *flags) {
+	flag.StringVar(&args.abi, "abi", "", "path to the contract ABI json to bind, - for STDIN")
+	flag.StringVar(&args.bin, "bin", "", "path to the contract bytecode (generate deploy method)")
+	flag.StringVar(&args.kind, "type", "", "go struct name for the binding (default = ABI file name)")
+	flag.StringVar(&args.pkg, "pkg", "", "package name to generate the binding into")
+	flag.StringVar(&args.out, "out", "", "output file for the generated binding (default = stdout)")
+}
+
+var args flags
+
+func init() {
+	initFlag(&args)
+}
+
+func main() {
+	flag.Parse()
+	if err := run(args); err != nil {
+		fmt.Fprintf(os.Stderr, "abigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args flags) error {
+	if args.abi == "" {
+		return fmt.Errorf("no contract ABI specified (--abi)")
+	}
+	if args.pkg == "" {
+		return fmt.Errorf("no destination package specified (--pkg)")
+	}
+	var (
+		input []byte
+		err   error
+	)
+	if args.abi == "-" {
+		input, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		input, err = ioutil.ReadFile(args.abi)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read input ABI: %v", err)
+	}
+	var bin []byte
+	if args.bin != "" {
+		if bin, err = ioutil.ReadFile(args.bin); err != nil {
+			return fmt.Errorf("failed to read input bytecode: %v", err)
+		}
+	}
+	kind := args.kind
+	if kind == "" {
+		if args.abi == "-" {
+			return fmt.Errorf("no type name specified for STDIN input (--type)")
+		}
+		kind = strings.TrimSuffix(filepath.Base(args.abi), filepath.Ext(args.abi))
+	}
+	code, err := bind.Bind([]string{kind}, []string{string(input)}, []string{string(bin)}, args.pkg)
+	if err != nil {
+		return fmt.Errorf("failed to generate ABI binding: %v", err)
+	}
+	if args.out == "" {
+		fmt.Print(code)
+		return nil
+	}
+	return ioutil.WriteFile(args.out, []byte(code), 0644)
+}
@@ -0,0 +1,313 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/lib/log"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+)
+
+// Prove constructs a merkle proof for key. The result contains all encoded nodes
+// on the path to the value at key. The value itself is also included in the last
+// node and can be retrieved by verifying the proof.
+//
+// If the trie does not contain a value for key, the returned proof contains all
+// nodes of the longest existing prefix of the key (at least the root node), ending
+// with the node that proves the absence of the key.
+func (t *Trie) Prove(key []byte, fromLevel uint, proofDb kaidb.KeyValueWriter) error {
+	// Collect all nodes on the path to key.
+	key = keybytesToHex(key)
+	var nodes []node
+	tn := t.root
+	for len(key) > 0 && tn != nil {
+		switch n := tn.(type) {
+		case *shortNode:
+			if len(key) < len(n.Key) || !bytes.Equal(n.Key, key[:len(n.Key)]) {
+				// The trie doesn't contain the key.
+				tn = nil
+			} else {
+				tn = n.Val
+				key = key[len(n.Key):]
+			}
+			nodes = append(nodes, n)
+		case *fullNode:
+			tn = n.Children[key[0]]
+			key = key[1:]
+			nodes = append(nodes, n)
+		case hashNode:
+			var err error
+			tn, err = t.resolveHash(n, nil)
+			if err != nil {
+				log.Error(fmt.Sprintf("Unhandled trie error: %v", err))
+				return err
+			}
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
+		}
+	}
+	hasher := newHasher(0, 0, nil)
+	defer returnHasherToPool(hasher)
+
+	for i, n := range nodes {
+		// Don't bother checking for errors here since hasher panics
+		// if encoding doesn't work and we're not writing to any database.
+		n, _, _ = hasher.hashChildren(n, nil)
+		hn, _ := hasher.store(n, nil, false)
+		if hash, ok := hn.(hashNode); ok || i == 0 {
+			// If the node's database encoding is a hash (or is the
+			// root node), it becomes a proof element.
+			if fromLevel > 0 {
+				fromLevel--
+			} else {
+				enc, _ := rlp.EncodeToBytes(n)
+				if !ok {
+					hash = hasher.makeHashNode(enc)
+				}
+				if err := proofDb.Put(hash, enc); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Prove constructs a merkle proof for key. The result contains all encoded nodes
+// on the path to the value at key. The value itself is also included in the last
+// node and can be retrieved by verifying the proof.
+//
+// If the trie does not contain a value for key, the returned proof contains all
+// nodes of the longest existing prefix of the key (at least the root node), ending
+// with the node that proves the absence of the key.
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb kaidb.KeyValueWriter) error {
+	return t.trie.Prove(t.hashKey(key), fromLevel, proofDb)
+}
+
+// ProofList is a kaidb.KeyValueWriter collecting the encoded nodes of a merkle
+// proof in path order, as written by Prove.
+type ProofList [][]byte
+
+// Put appends the node to the proof.
+func (n *ProofList) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+	return nil
+}
+
+// Delete is not supported by proofs.
+func (n *ProofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// VerifyProof checks merkle proofs. The given proof must contain the value for
+// key in a trie with the given root hash. VerifyProof returns an error if the
+// proof contains invalid trie nodes or the wrong value.
+//
+// Proofs generated by SecureTrie.Prove must be verified against the hashed key,
+// i.e. crypto.Keccak256(key).
+func VerifyProof(rootHash common.Hash, key []byte, proofDb kaidb.KeyValueReader) (value []byte, nodes int, err error) {
+	key = keybytesToHex(key)
+	wantHash := rootHash
+	for i := 0; ; i++ {
+		buf, _ := proofDb.Get(wantHash[:])
+		if buf == nil {
+			return nil, i, fmt.Errorf("proof node %d (hash %064x) missing", i, wantHash)
+		}
+		n, err := decodeNode(wantHash[:], buf, 0)
+		if err != nil {
+			return nil, i, fmt.Errorf("bad proof node %d: %v", i, err)
+		}
+		keyrest, cld := get(n, key)
+		switch cld := cld.(type) {
+		case nil:
+			// The trie doesn't contain the key.
+			return nil, i, nil
+		case hashNode:
+			key = keyrest
+			copy(wantHash[:], cld)
+		case valueNode:
+			return cld, i + 1, nil
+		}
+	}
+}
+
+func get(tn node, key []byte) ([]byte, node) {
+	for {
+		switch n := tn.(type) {
+		case *shortNode:
+			if len(key) < len(n.Key) || !bytes.Equal(n.Key, key[:len(n.Key)]) {
+				return nil, nil
+			}
+			tn = n.Val
+			key = key[len(n.Key):]
+		case *fullNode:
+			tn = n.Children[key[0]]
+			key = key[1:]
+		case hashNode:
+			return key, n
+		case nil:
+			return key, nil
+		case valueNode:
+			return nil, n
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
+		}
+	}
+}
+
+// ProveRange iterates the trie starting at key start and returns at most max
+// consecutive key/value pairs. The proof nodes needed to verify that the returned
+// entries are exactly the content of the trie between start and the last returned
+// key are written into proofDb: the proof of start itself and the path of every
+// returned leaf. A max of zero or less returns every remaining entry.
+func (t *Trie) ProveRange(start []byte, max int, proofDb kaidb.KeyValueWriter) (keys, values [][]byte, err error) {
+	if err := t.Prove(start, 0, proofDb); err != nil {
+		return nil, nil, err
+	}
+	it := NewIterator(t.NodeIterator(start))
+	for (max <= 0 || len(keys) < max) && it.Next() {
+		keys = append(keys, common.CopyBytes(it.Key))
+		values = append(values, common.CopyBytes(it.Value))
+		for _, enc := range it.Prove() {
+			if err := proofDb.Put(crypto.Keccak256(enc), enc); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if it.Err != nil {
+		return nil, nil, it.Err
+	}
+	return keys, values, nil
+}
+
+// ProveRange iterates the trie starting at the hashed key start and returns at
+// most max consecutive entries together with their range proof. Note the returned
+// keys are the hashed keys as stored in the underlying trie; use GetKey to map
+// them back to their preimages.
+func (t *SecureTrie) ProveRange(start []byte, max int, proofDb kaidb.KeyValueWriter) (keys, values [][]byte, err error) {
+	return t.trie.ProveRange(start, max, proofDb)
+}
+
+// VerifyRangeProof checks a proof generated by ProveRange. It succeeds only if
+// keys and values are exactly the entries of the trie with the given root hash
+// between start and the last key (inclusive), in iteration order. If keys is
+// empty, the proof must show that the trie holds no entry at or after start.
+func VerifyRangeProof(rootHash common.Hash, start []byte, keys, values [][]byte, proofDb kaidb.KeyValueReader) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("inconsistent proof data, keys: %d, values: %d", len(keys), len(values))
+	}
+	// Like the node iterator, treat start as a path prefix rather than a full
+	// key, so entries under it are part of the range.
+	origin := keybytesToHex(start)
+	v := &rangeVerifier{
+		proofDb: proofDb,
+		origin:  origin[:len(origin)-1],
+	}
+	if len(keys) > 0 {
+		v.limit = keybytesToHex(keys[len(keys)-1])
+	}
+	if rootHash != emptyRoot {
+		if err := v.walk(hashNode(rootHash.Bytes()), nil); err != nil {
+			return err
+		}
+	}
+	if len(v.keys) != len(keys) {
+		return fmt.Errorf("range mismatch, want %d entries, proof has %d", len(keys), len(v.keys))
+	}
+	for i := range keys {
+		if !bytes.Equal(v.keys[i], keys[i]) {
+			return fmt.Errorf("key %d mismatch, want %x, proof has %x", i, keys[i], v.keys[i])
+		}
+		if !bytes.Equal(v.values[i], values[i]) {
+			return fmt.Errorf("value %d mismatch for key %x", i, keys[i])
+		}
+	}
+	return nil
+}
+
+var errRangeProofMissingNode = errors.New("range proof is missing a node")
+
+// rangeVerifier walks every part of a trie covered by a range proof and collects
+// the leaves within [origin, limit]. A nil limit means the range is unbounded.
+type rangeVerifier struct {
+	proofDb kaidb.KeyValueReader
+	origin  []byte
+	limit   []byte
+
+	keys   [][]byte
+	values [][]byte
+}
+
+// covers reports whether the subtrie rooted at the given hex path may contain
+// keys within the verified range.
+func (v *rangeVerifier) covers(path []byte) bool {
+	if comparePrefix(path, v.origin) < 0 {
+		return false
+	}
+	return v.limit == nil || comparePrefix(path, v.limit) <= 0
+}
+
+func (v *rangeVerifier) walk(n node, path []byte) error {
+	if !v.covers(path) {
+		return nil
+	}
+	switch n := n.(type) {
+	case nil:
+		return nil
+	case hashNode:
+		buf, _ := v.proofDb.Get(n)
+		if buf == nil {
+			return fmt.Errorf("%v: hash %x, path %x", errRangeProofMissingNode, []byte(n), path)
+		}
+		resolved, err := decodeNode(n, buf, 0)
+		if err != nil {
+			return fmt.Errorf("bad proof node %x: %v", []byte(n), err)
+		}
+		return v.walk(resolved, path)
+	case *shortNode:
+		return v.walk(n.Val, concat(path, n.Key...))
+	case *fullNode:
+		for i, child := range n.Children {
+			if err := v.walk(child, concat(path, byte(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case valueNode:
+		v.keys = append(v.keys, hexToKeybytes(path))
+		v.values = append(v.values, common.CopyBytes(n))
+		return nil
+	default:
+		panic(fmt.Sprintf("%T: invalid node: %v", n, n))
+	}
+}
+
+// comparePrefix compares the hex path a with the bound b over their common
+// length, mirroring the order in which the trie iterator visits its leaves.
+func comparePrefix(a, b []byte) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	return bytes.Compare(a[:n], b[:n])
+}
@@ -0,0 +1,353 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	mrand "math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+func init() {
+	mrand.Seed(time.Now().Unix())
+}
+
+// makeProvers creates Merkle trie provers based on different implementations to
+// test all variations.
+func makeProvers(trie *Trie) []func(key []byte) *memorydb.Database {
+	var provers []func(key []byte) *memorydb.Database
+
+	// Create a direct trie based Merkle prover
+	provers = append(provers, func(key []byte) *memorydb.Database {
+		proof := memorydb.New()
+		trie.Prove(key, 0, proof)
+		return proof
+	})
+	// Create a leaf iterator based Merkle prover
+	provers = append(provers, func(key []byte) *memorydb.Database {
+		proof := memorydb.New()
+		if it := NewIterator(trie.NodeIterator(key)); it.Next() && bytes.Equal(key, it.Key) {
+			for _, p := range it.Prove() {
+				proof.Put(crypto.Keccak256(p), p)
+			}
+		}
+		return proof
+	})
+	return provers
+}
+
+func TestProof(t *testing.T) {
+	trie, vals := randomTrie(500)
+	root := trie.Hash()
+	for i, prover := range makeProvers(trie) {
+		for _, kv := range vals {
+			proof := prover(kv.k)
+			if proof == nil {
+				t.Fatalf("prover %d: missing key %x while constructing proof", i, kv.k)
+			}
+			val, _, err := VerifyProof(root, kv.k, proof)
+			if err != nil {
+				t.Fatalf("prover %d: failed to verify proof for key %x: %v\nraw proof: %x", i, kv.k, err, proof)
+			}
+			if !bytes.Equal(val, kv.v) {
+				t.Fatalf("prover %d: verified value mismatch for key %x: have %x, want %x", i, kv.k, val, kv.v)
+			}
+		}
+	}
+}
+
+func TestOneElementProof(t *testing.T) {
+	trie := new(Trie)
+	updateString(trie, "k", "v")
+	for i, prover := range makeProvers(trie) {
+		proof := prover([]byte("k"))
+		if proof == nil {
+			t.Fatalf("prover %d: nil proof", i)
+		}
+		if proof.Len() != 1 {
+			t.Errorf("prover %d: proof should have one element", i)
+		}
+		val, _, err := VerifyProof(trie.Hash(), []byte("k"), proof)
+		if err != nil {
+			t.Fatalf("prover %d: failed to verify proof: %v\nraw proof: %x", i, err, proof)
+		}
+		if !bytes.Equal(val, []byte("v")) {
+			t.Fatalf("prover %d: verified value mismatch: have %x, want 'k'", i, val)
+		}
+	}
+}
+
+func TestBadProof(t *testing.T) {
+	trie, vals := randomTrie(800)
+	root := trie.Hash()
+	for i, prover := range makeProvers(trie) {
+		for _, kv := range vals {
+			proof := prover(kv.k)
+			if proof == nil {
+				t.Fatalf("prover %d: nil proof", i)
+			}
+			it := proof.NewIterator()
+			for i, d := 0, mrand.Intn(proof.Len()); i <= d; i++ {
+				it.Next()
+			}
+			key := it.Key()
+			val, _ := proof.Get(key)
+			proof.Delete(key)
+			it.Release()
+
+			mutateByte(val)
+			proof.Put(crypto.Keccak256(val), val)
+
+			if _, _, err := VerifyProof(root, kv.k, proof); err == nil {
+				t.Fatalf("prover %d: expected proof to fail for key %x", i, kv.k)
+			}
+		}
+	}
+}
+
+// Tests that missing keys can also be proven. The test explicitly uses a single
+// entry trie and checks for missing keys both before and after the single entry.
+func TestMissingKeyProof(t *testing.T) {
+	trie := new(Trie)
+	updateString(trie, "k", "v")
+
+	for i, key := range []string{"a", "j", "l", "z"} {
+		proof := memorydb.New()
+		trie.Prove([]byte(key), 0, proof)
+
+		if proof.Len() != 1 {
+			t.Errorf("test %d: proof should have one element", i)
+		}
+		val, _, err := VerifyProof(trie.Hash(), []byte(key), proof)
+		if err != nil {
+			t.Fatalf("test %d: failed to verify proof: %v\nraw proof: %x", i, err, proof)
+		}
+		if val != nil {
+			t.Fatalf("test %d: verified value mismatch: have %x, want nil", i, val)
+		}
+	}
+}
+
+func TestSecureTrieProof(t *testing.T) {
+	trie := newEmptySecure()
+	for i := byte(0); i < 100; i++ {
+		trie.Update([]byte{i}, []byte{i, i})
+	}
+	root := trie.Hash()
+	for i := byte(0); i < 100; i++ {
+		proof := memorydb.New()
+		if err := trie.Prove([]byte{i}, 0, proof); err != nil {
+			t.Fatalf("key %x: failed to prove: %v", i, err)
+		}
+		val, _, err := VerifyProof(root, crypto.Keccak256([]byte{i}), proof)
+		if err != nil {
+			t.Fatalf("key %x: failed to verify proof: %v", i, err)
+		}
+		if !bytes.Equal(val, []byte{i, i}) {
+			t.Fatalf("key %x: verified value mismatch: have %x, want %x", i, val, []byte{i, i})
+		}
+	}
+}
+
+func TestRangeProof(t *testing.T) {
+	trie, vals := randomTrie(500)
+	root := trie.Hash()
+
+	entries := sortedEntries(vals)
+	for i := 0; i < 100; i++ {
+		start := mrand.Intn(len(entries))
+		max := mrand.Intn(len(entries)-start) + 1
+
+		proof := memorydb.New()
+		keys, values, err := trie.ProveRange(entries[start].k, max, proof)
+		if err != nil {
+			t.Fatalf("failed to prove range: %v", err)
+		}
+		if len(keys) != max {
+			t.Fatalf("range length mismatch: have %d, want %d", len(keys), max)
+		}
+		for j := range keys {
+			if !bytes.Equal(keys[j], entries[start+j].k) || !bytes.Equal(values[j], entries[start+j].v) {
+				t.Fatalf("entry %d mismatch: have %x=%x, want %x=%x", j, keys[j], values[j], entries[start+j].k, entries[start+j].v)
+			}
+		}
+		if err := VerifyRangeProof(root, entries[start].k, keys, values, proof); err != nil {
+			t.Fatalf("failed to verify range proof: %v", err)
+		}
+	}
+}
+
+func TestBadRangeProof(t *testing.T) {
+	trie, vals := randomTrie(500)
+	root := trie.Hash()
+
+	entries := sortedEntries(vals)
+	for i := 0; i < 100; i++ {
+		start := mrand.Intn(len(entries) - 3)
+		max := mrand.Intn(len(entries)-start-2) + 3
+
+		proof := memorydb.New()
+		keys, values, err := trie.ProveRange(entries[start].k, max, proof)
+		if err != nil {
+			t.Fatalf("failed to prove range: %v", err)
+		}
+		// Dropping an entry in the middle of the range must be detected.
+		index := 1 + mrand.Intn(len(keys)-2)
+		droppedKeys := append(append([][]byte{}, keys[:index]...), keys[index+1:]...)
+		droppedVals := append(append([][]byte{}, values[:index]...), values[index+1:]...)
+		if err := VerifyRangeProof(root, entries[start].k, droppedKeys, droppedVals, proof); err == nil {
+			t.Fatalf("expected range proof with dropped entry %d to fail", index)
+		}
+		// Modifying a value must be detected.
+		modified := append([][]byte{}, values...)
+		modified[index] = append(common.CopyBytes(modified[index]), 0x00)
+		if err := VerifyRangeProof(root, entries[start].k, keys, modified, proof); err == nil {
+			t.Fatalf("expected range proof with modified value %d to fail", index)
+		}
+		// Claiming the range ends early must be detected, since the proof
+		// shows the trie holds further entries after the start.
+		if err := VerifyRangeProof(root, entries[start].k, nil, nil, proof); err == nil {
+			t.Fatal("expected empty range proof to fail")
+		}
+	}
+}
+
+func TestEmptyRangeProof(t *testing.T) {
+	trie, vals := randomTrie(100)
+	root := trie.Hash()
+
+	entries := sortedEntries(vals)
+	start := bytes.Repeat([]byte{0xff}, 32)
+
+	proof := memorydb.New()
+	keys, values, err := trie.ProveRange(start, 0, proof)
+	if err != nil {
+		t.Fatalf("failed to prove range: %v", err)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("expected no entries after the last key, have %d", len(keys))
+	}
+	if err := VerifyRangeProof(root, start, keys, values, proof); err != nil {
+		t.Fatalf("failed to verify empty range proof: %v", err)
+	}
+	// The proof of the whole trie must verify as well.
+	proof = memorydb.New()
+	keys, values, err = trie.ProveRange(nil, 0, proof)
+	if err != nil {
+		t.Fatalf("failed to prove range: %v", err)
+	}
+	if len(keys) != len(entries) {
+		t.Fatalf("full range length mismatch: have %d, want %d", len(keys), len(entries))
+	}
+	if err := VerifyRangeProof(root, nil, keys, values, proof); err != nil {
+		t.Fatalf("failed to verify full range proof: %v", err)
+	}
+}
+
+// mutateByte changes one byte in b.
+func mutateByte(b []byte) {
+	for r := mrand.Intn(len(b)); ; {
+		new := byte(mrand.Intn(255))
+		if new != b[r] {
+			b[r] = new
+			break
+		}
+	}
+}
+
+func BenchmarkProve(b *testing.B) {
+	trie, vals := randomTrie(100)
+	var keys []string
+	for k := range vals {
+		keys = append(keys, k)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		kv := vals[keys[i%len(keys)]]
+		proofs := memorydb.New()
+		if trie.Prove(kv.k, 0, proofs); proofs.Len() == 0 {
+			b.Fatalf("zero length proof for %x", kv.k)
+		}
+	}
+}
+
+func BenchmarkVerifyProof(b *testing.B) {
+	trie, vals := randomTrie(100)
+	root := trie.Hash()
+	var keys []string
+	var proofs []*memorydb.Database
+	for k := range vals {
+		keys = append(keys, k)
+		proof := memorydb.New()
+		trie.Prove([]byte(k), 0, proof)
+		proofs = append(proofs, proof)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		im := i % len(keys)
+		if _, _, err := VerifyProof(root, []byte(keys[im]), proofs[im]); err != nil {
+			b.Fatalf("key %x: %v", keys[im], err)
+		}
+	}
+}
+
+func randomTrie(n int) (*Trie, map[string]*kv) {
+	trie := new(Trie)
+	vals := make(map[string]*kv)
+	for i := byte(0); i < 100; i++ {
+		value := &kv{common.LeftPadBytes([]byte{i}, 32), []byte{i}, false}
+		value2 := &kv{common.LeftPadBytes([]byte{i + 10}, 32), []byte{i}, false}
+		trie.Update(value.k, value.v)
+		trie.Update(value2.k, value2.v)
+		vals[string(value.k)] = value
+		vals[string(value2.k)] = value2
+	}
+	for i := 0; i < n; i++ {
+		value := &kv{randBytes(32), randBytes(20), false}
+		trie.Update(value.k, value.v)
+		vals[string(value.k)] = value
+	}
+	return trie, vals
+}
+
+// sortedEntries returns the entries of a random trie in iteration order. All
+// keys have the same length, so this is plain byte order.
+func sortedEntries(vals map[string]*kv) []*kv {
+	entries := make([]*kv, 0, len(vals))
+	for _, v := range vals {
+		entries = append(entries, v)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].k, entries[j].k) < 0
+	})
+	return entries
+}
+
+func randBytes(n int) []byte {
+	r := make([]byte, n)
+	crand.Read(r)
+	return r
+}
@@ -19,7 +19,6 @@
 package types
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -31,6 +30,7 @@ import (
 
 	"math/big"
 
+	"github.com/kardiachain/go-kardia/kai/kaidb"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/crypto/sha3"
 	"github.com/kardiachain/go-kardia/lib/log"
@@ -74,8 +74,105 @@ type Header struct {
 	AppHash        common.Hash `json:"app_hash"`        // state after txs from the previous block
 	//@huny LastResultsHash common.Hash `json:"last_results_hash"` // root hash of all results from the txs from the previous block
 
+	// commitments to the results of the txs from the previous block, only set
+	// from ChainConfig.HeaderCommitmentsHeight onwards (see IsHeaderCommitments).
+	ReceiptHash common.Hash `json:"receipts_root"` // receipts of the txs from the previous block
+	Bloom       Bloom       `json:"logs_bloom"`    // bloom filter of the logs from the previous block
+
 	// consensus info
-	//@huny EvidenceHash common.Hash `json:"evidence_hash"` // evidence included in the block
+	EvidenceHash common.Hash `json:"evidence_hash"` // evidence included in the block
+}
+
+// headerRLP is the RLP encoding of Header. Commitments is left empty for headers
+// created before the header commitments activation so their hashes don't change.
+type headerRLP struct {
+	Height         uint64
+	Time           *big.Int
+	NumTxs         uint64
+	NumDualEvents  uint64
+	GasLimit       uint64
+	GasUsed        uint64
+	LastBlockID    BlockID
+	LastCommitHash common.Hash
+	TxHash         common.Hash
+	DualEventsHash common.Hash
+	Validator      common.Address
+	ValidatorsHash common.Hash
+	ConsensusHash  common.Hash
+	AppHash        common.Hash
+	Commitments    []headerCommitmentsRLP `rlp:"tail"`
+}
+
+type headerCommitmentsRLP struct {
+	ReceiptHash  common.Hash
+	Bloom        Bloom
+	EvidenceHash common.Hash
+}
+
+// HasCommitments returns true if any of the receipt, bloom or evidence commitments is set.
+func (h *Header) HasCommitments() bool {
+	return !h.ReceiptHash.IsZero() || h.Bloom != (Bloom{}) || !h.EvidenceHash.IsZero()
+}
+
+// EncodeRLP implements rlp.Encoder, serializes Header into the RLP stream.
+func (h *Header) EncodeRLP(w io.Writer) error {
+	enc := headerRLP{
+		Height:         h.Height,
+		Time:           h.Time,
+		NumTxs:         h.NumTxs,
+		NumDualEvents:  h.NumDualEvents,
+		GasLimit:       h.GasLimit,
+		GasUsed:        h.GasUsed,
+		LastBlockID:    h.LastBlockID,
+		LastCommitHash: h.LastCommitHash,
+		TxHash:         h.TxHash,
+		DualEventsHash: h.DualEventsHash,
+		Validator:      h.Validator,
+		ValidatorsHash: h.ValidatorsHash,
+		ConsensusHash:  h.ConsensusHash,
+		AppHash:        h.AppHash,
+	}
+	if h.HasCommitments() {
+		enc.Commitments = []headerCommitmentsRLP{{
+			ReceiptHash:  h.ReceiptHash,
+			Bloom:        h.Bloom,
+			EvidenceHash: h.EvidenceHash,
+		}}
+	}
+	return rlp.Encode(w, &enc)
+}
+
+// DecodeRLP implements rlp.Decoder, decodes RLP stream to Header struct.
+func (h *Header) DecodeRLP(s *rlp.Stream) error {
+	var dec headerRLP
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	if len(dec.Commitments) > 1 {
+		return fmt.Errorf("invalid header commitments, expected at most 1, got %v", len(dec.Commitments))
+	}
+	*h = Header{
+		Height:         dec.Height,
+		Time:           dec.Time,
+		NumTxs:         dec.NumTxs,
+		NumDualEvents:  dec.NumDualEvents,
+		GasLimit:       dec.GasLimit,
+		GasUsed:        dec.GasUsed,
+		LastBlockID:    dec.LastBlockID,
+		LastCommitHash: dec.LastCommitHash,
+		TxHash:         dec.TxHash,
+		DualEventsHash: dec.DualEventsHash,
+		Validator:      dec.Validator,
+		ValidatorsHash: dec.ValidatorsHash,
+		ConsensusHash:  dec.ConsensusHash,
+		AppHash:        dec.AppHash,
+	}
+	if len(dec.Commitments) == 1 {
+		h.ReceiptHash = dec.Commitments[0].ReceiptHash
+		h.Bloom = dec.Commitments[0].Bloom
+		h.EvidenceHash = dec.Commitments[0].EvidenceHash
+	}
+	return nil
 }
 
 // Hash returns the block hash of the header, which is simply the keccak256 hash of its
@@ -322,6 +419,9 @@ func (b *Block) LastCommitHash() common.Hash { return b.header.LastCommitHash }
 func (b *Block) TxHash() common.Hash         { return b.header.TxHash }
 func (b *Block) LastCommit() *Commit         { return b.lastCommit }
 func (b *Block) AppHash() common.Hash        { return b.header.AppHash }
+func (b *Block) ReceiptHash() common.Hash    { return b.header.ReceiptHash }
+func (b *Block) Bloom() Bloom                { return b.header.Bloom }
+func (b *Block) EvidenceHash() common.Hash   { return b.header.EvidenceHash }
 
 // TODO(namdoh): This is a hack due to rlp nature of decode both nil or empty
 // struct pointer as nil. After encoding an empty struct and send it over to
@@ -538,14 +638,30 @@ type DerivableList interface {
 }
 
 func DeriveSha(list DerivableList) common.Hash {
-	keybuf := new(bytes.Buffer)
+	return deriveTrie(list).Hash()
+
+	//return common.BytesToHash([]byte(""))
+}
+
+// DeriveProof writes the Merkle proof of the item at index into proofDb. The
+// proof verifies against DeriveSha(list) with key DeriveKey(index).
+func DeriveProof(list DerivableList, index int, proofDb kaidb.KeyValueWriter) error {
+	if index < 0 || index >= list.Len() {
+		return fmt.Errorf("index %d out of range [0, %d)", index, list.Len())
+	}
+	return deriveTrie(list).Prove(DeriveKey(index), 0, proofDb)
+}
+
+// DeriveKey returns the trie key DeriveSha stores the item at index under.
+func DeriveKey(index int) []byte {
+	key, _ := rlp.EncodeToBytes(uint(index))
+	return key
+}
+
+func deriveTrie(list DerivableList) *trie.Trie {
 	t := new(trie.Trie)
 	for i := 0; i < list.Len(); i++ {
-		keybuf.Reset()
-		rlp.Encode(keybuf, uint(i))
-		t.Update(keybuf.Bytes(), list.GetRlp(i))
+		t.Update(DeriveKey(i), list.GetRlp(i))
 	}
-	return t.Hash()
-
-	//return common.BytesToHash([]byte(""))
+	return t
 }
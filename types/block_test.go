@@ -75,6 +75,44 @@ func TestBlockEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestHeaderEncodeDecodeCommitments(t *testing.T) {
+	header := CreateNewBlock(1).Header()
+
+	// Headers without commitments keep their legacy encoding.
+	legacy, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal("encode error: ", err)
+	}
+	header.ReceiptHash = EmptyRootHash
+	header.Bloom = BytesToBloom([]byte{0x01})
+	encoded, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal("encode error: ", err)
+	}
+	if len(encoded) <= len(legacy) {
+		t.Fatal("commitments are not encoded")
+	}
+
+	var decoded Header
+	if err := rlp.DecodeBytes(encoded, &decoded); err != nil {
+		t.Fatal("decode error: ", err)
+	}
+	if decoded.ReceiptHash != header.ReceiptHash || decoded.Bloom != header.Bloom || decoded.EvidenceHash != header.EvidenceHash {
+		t.Error("Encode Decode header commitments error")
+	}
+	if decoded.Hash() != header.Hash() {
+		t.Error("Encode Decode header error")
+	}
+
+	var decodedLegacy Header
+	if err := rlp.DecodeBytes(legacy, &decodedLegacy); err != nil {
+		t.Fatal("decode error: ", err)
+	}
+	if decodedLegacy.HasCommitments() {
+		t.Error("legacy header should not have commitments")
+	}
+}
+
 func TestNewDualBlock(t *testing.T) {
 	block := CreateNewDualBlock()
 	if err := block.ValidateBasic(); err != nil {
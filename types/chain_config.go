@@ -17,6 +17,22 @@ type ChainConfig struct {
 
 	// BaseAccount is used to set default execute account for
 	*BaseAccount         `json:"baseAccount,omitempty"`
+
+	// HeaderCommitmentsHeight is the height from which block headers commit to
+	// the receipts, logs bloom and evidence (nil = no activation).
+	HeaderCommitmentsHeight *uint64 `json:"headerCommitmentsHeight,omitempty"`
+
+	// SystemPhasesHeight is the height from which block rewards and consensus
+	// periods are applied by the begin and end block phases instead of
+	// transactions of the proposer (nil = no activation).
+	SystemPhasesHeight *uint64 `json:"systemPhasesHeight,omitempty"`
+
+	// PrecompilesV1Height is the height from which the precompiled contracts of
+	// kvm.PrecompiledContractsV1 are available (nil = no activation).
+	PrecompilesV1Height *uint64 `json:"precompilesV1Height,omitempty"`
+
+	// ConsensusTiming is the consensus timing agreed on by the network (nil = unknown).
+	ConsensusTiming *ConsensusTimingConfig `json:"consensusTiming,omitempty"`
 }
 
 // BaseAccount defines information for base (root) account that is used to execute internal smart contract
@@ -37,6 +53,41 @@ func (c *KaiconConfig) String() string {
 	return "kaicon"
 }
 
+// ConsensusTimingConfig is the consensus timing of a network. All timeouts are
+// in milliseconds.
+type ConsensusTimingConfig struct {
+	TimeoutPropose            uint64 `json:"timeoutPropose"`
+	TimeoutProposeDelta       uint64 `json:"timeoutProposeDelta"`
+	TimeoutPrevote            uint64 `json:"timeoutPrevote"`
+	TimeoutPrevoteDelta       uint64 `json:"timeoutPrevoteDelta"`
+	TimeoutPrecommit          uint64 `json:"timeoutPrecommit"`
+	TimeoutPrecommitDelta     uint64 `json:"timeoutPrecommitDelta"`
+	TimeoutCommit             uint64 `json:"timeoutCommit"`
+	SkipTimeoutCommit         bool   `json:"skipTimeoutCommit"`
+	CreateEmptyBlocks         bool   `json:"createEmptyBlocks"`
+	CreateEmptyBlocksInterval uint64 `json:"createEmptyBlocksInterval"`
+}
+
+// Equal reports whether both timings are the same. A nil timing only equals nil.
+func (t *ConsensusTimingConfig) Equal(other *ConsensusTimingConfig) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return *t == *other
+}
+
+// String implements the fmt.Stringer interface.
+func (t *ConsensusTimingConfig) String() string {
+	if t == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("{Propose: %d+%d Prevote: %d+%d Precommit: %d+%d Commit: %d SkipTimeoutCommit: %v CreateEmptyBlocks: %v Interval: %d}",
+		t.TimeoutPropose, t.TimeoutProposeDelta, t.TimeoutPrevote, t.TimeoutPrevoteDelta,
+		t.TimeoutPrecommit, t.TimeoutPrecommitDelta, t.TimeoutCommit,
+		t.SkipTimeoutCommit, t.CreateEmptyBlocks, t.CreateEmptyBlocksInterval,
+	)
+}
+
 // String implements the fmt.Stringer interface.
 func (c *ChainConfig) String() string {
 	var engine interface{}
@@ -46,11 +97,78 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{Engine: %v}",
+	return fmt.Sprintf("{Engine: %v ConsensusTiming: %v}",
 		engine,
+		c.ConsensusTiming,
 	)
 }
 
 func (c *ChainConfig) SetBaseAccount(baseAccount *BaseAccount) {
 	c.BaseAccount = baseAccount
-}
\ No newline at end of file
+}
+
+// IsHeaderCommitments returns whether the header of the block at the given height
+// must carry ReceiptHash, Bloom and EvidenceHash.
+func (c *ChainConfig) IsHeaderCommitments(height uint64) bool {
+	if c == nil || c.HeaderCommitmentsHeight == nil {
+		return false
+	}
+	return height >= *c.HeaderCommitmentsHeight
+}
+
+// IsSystemPhases returns whether the block at the given height applies the block
+// reward and consensus period changes in its begin and end block phases.
+func (c *ChainConfig) IsSystemPhases(height uint64) bool {
+	if c == nil || c.SystemPhasesHeight == nil {
+		return false
+	}
+	return height >= *c.SystemPhasesHeight
+}
+
+// IsPrecompilesV1 returns whether the block at the given height runs with the
+// precompiled contracts of PrecompiledContractsV1.
+func (c *ChainConfig) IsPrecompilesV1(height uint64) bool {
+	if c == nil || c.PrecompilesV1Height == nil {
+		return false
+	}
+	return height >= *c.PrecompilesV1Height
+}
+
+// CheckCompatible returns an error if newcfg moves the activation of a fork that
+// the chain, whose head is at the given height, already passed with c or would
+// have passed with newcfg.
+func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, head uint64) error {
+	forks := []struct {
+		name          string
+		stored, newcf *uint64
+	}{
+		{"HeaderCommitmentsHeight", c.HeaderCommitmentsHeight, newcfg.HeaderCommitmentsHeight},
+		{"SystemPhasesHeight", c.SystemPhasesHeight, newcfg.SystemPhasesHeight},
+		{"PrecompilesV1Height", c.PrecompilesV1Height, newcfg.PrecompilesV1Height},
+	}
+	for _, f := range forks {
+		if (isForked(f.stored, head) || isForked(f.newcf, head)) && !heightEqual(f.stored, f.newcf) {
+			return fmt.Errorf("incompatible %v at head %v: stored %v, new %v", f.name, head, heightString(f.stored), heightString(f.newcf))
+		}
+	}
+	return nil
+}
+
+// isForked returns whether a fork activated at height fork is active at head.
+func isForked(fork *uint64, head uint64) bool {
+	return fork != nil && *fork <= head
+}
+
+func heightEqual(x, y *uint64) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
+func heightString(height *uint64) string {
+	if height == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*height)
+}
@@ -19,12 +19,15 @@
 package types
 
 import (
+	"bytes"
 	"math/big"
 	"os"
 	"testing"
 
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/trie"
 )
 
 func TestReceiptEncodeRLP(t *testing.T) {
@@ -55,6 +58,32 @@ func TestReceiptEncodeRLP(t *testing.T) {
 	}
 }
 
+func TestReceiptInclusionProof(t *testing.T) {
+	receipts := make(Receipts, 0, 20)
+	for i := 0; i < 20; i++ {
+		receipt := CreateNewReceipt()
+		receipt.CumulativeGasUsed = uint64(21000 * (i + 1))
+		receipts = append(receipts, receipt)
+	}
+	root := DeriveSha(receipts)
+	for i := range receipts {
+		proof := memorydb.New()
+		if err := DeriveProof(receipts, i, proof); err != nil {
+			t.Fatalf("receipt %d: failed to prove: %v", i, err)
+		}
+		value, _, err := trie.VerifyProof(root, DeriveKey(i), proof)
+		if err != nil {
+			t.Fatalf("receipt %d: failed to verify proof: %v", i, err)
+		}
+		if !bytes.Equal(value, receipts.GetRlp(i)) {
+			t.Fatalf("receipt %d: proven value mismatch", i)
+		}
+	}
+	if err := DeriveProof(receipts, len(receipts), memorydb.New()); err == nil {
+		t.Error("expected proof of out of range receipt to fail")
+	}
+}
+
 func CreateNewReceipt() *Receipt {
 	addr := common.HexToAddress("095e7baea6a6c7c4c2dfeb977efac326af552d87")
 	emptyTx := NewTransaction(
@@ -21,6 +21,7 @@ type StoreDB interface {
 	//WriteCommit(height uint64, commit *Commit)
 	//WriteCommitRLP(height uint64, rlp rlp.RawValue)
 	WriteTxLookupEntries(block *Block)
+	WriteAddressTxs(height uint64, index uint32, hash common.Hash, addresses []common.Address)
 	StoreTxHash(hash *common.Hash)
 	StoreHash(hash *common.Hash)
 	WriteAppHash(height uint64, hash common.Hash)
@@ -50,6 +51,7 @@ type StoreDB interface {
 	ReadHeaderNumber(hash common.Hash) *uint64
 	ReadReceipts(hash common.Hash, number uint64) Receipts
 	ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64)
+	ReadAddressTxs(address common.Address, from, to uint64, skip, limit int) []common.Hash
 	ReadSmartContractAbi(address string) *abi.ABI
 	ReadEvent(address string, method string) *Watcher
 	ReadEvents(address string) (string, []*Watcher)
@@ -272,6 +272,16 @@ func (valSet *ValidatorSet) Copy() *ValidatorSet {
 	}
 }
 
+// ReplayProposer advances the proposer once per block committed with the set, from
+// StartHeight up to the given height of the latest block. The proposer rotation is
+// not persisted, so a restarted node replays it to agree with its peers on the next
+// proposer. AdvanceProposer(n) differs from n single advances, hence the loop.
+func (valSet *ValidatorSet) ReplayProposer(height int64) {
+	for h := valSet.StartHeight; h < height; h++ {
+		valSet.AdvanceProposer(1)
+	}
+}
+
 // Advances proposer a given number of times. To advance to the next proposer, call this with
 // 'times' is 1.
 func (valSet *ValidatorSet) AdvanceProposer(times int64) {